@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"github.com/NimbleArchitect/kubectl-ice/pkg/plugin"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
 )
 
 // auto updated version via gorelaser
@@ -22,6 +24,20 @@ var rootDescription = ` Ice lets you view configuration and settings of the cont
  Suggestions and improvements can be made by raising an issue here: 
     https://github.com/NimbleArchitect/kubectl-ice
 
+ Exit codes:
+    0  success
+    1  general error
+    2  configuration error, the kubeconfig could not be read or used
+    3  no pods were found
+    4  pods were found but no rows matched the filters, only with --error-on-empty
+    5  metrics are unavailable
+    6  lint checks failed, returned by probes, capabilities and security with --fail-on-warn and by
+       cpu and memory with --fail-on-missing-limits
+    7  timed out waiting for the containers to be ready, see status --wait-ready
+
+ Use -v to log what ice is doing to stderr, -v 1 shows the context, namespace and selector
+ used and -v 2 also logs each api call along with the number of items returned
+
 `
 
 func RootCmd() *cobra.Command {
@@ -43,6 +59,14 @@ func RootCmd() *cobra.Command {
 		plugin.LogDebug = true
 	}
 
+	// -v sets the klog verbosity the same as kubectl, everything is logged to stderr so the
+	// table and json output on stdout is left untouched
+	klogFlags := flag.NewFlagSet("klog", flag.ExitOnError)
+	klog.InitFlags(klogFlags)
+	cmd.PersistentFlags().AddGoFlag(klogFlags.Lookup("v"))
+	cmd.PersistentFlags().Lookup("v").Shorthand = "v"
+	cmd.PersistentFlags().Lookup("v").Usage = "log level verbosity, 1 shows the context and namespace, 2 adds each api call and 6 or higher the http requests"
+
 	plugin.InitSubCommands(cmd)
 
 	return cmd
@@ -51,7 +75,7 @@ func RootCmd() *cobra.Command {
 func InitAndExecute() {
 	if err := RootCmd().Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(plugin.ExitCode(err))
 	}
 }
 
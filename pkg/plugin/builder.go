@@ -2,11 +2,15 @@ package plugin
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apires "k8s.io/apimachinery/pkg/api/resource"
 )
 
 type Looper interface {
@@ -19,6 +23,26 @@ type Looper interface {
 	HideColumns(info BuilderInformation) []int
 }
 
+// PodListLooper is an optional extra for loopers that compare each pod against the others, SetPodList is called with
+//
+//	every pod that will be built before the first row is built, including pods read from a file or stdin
+type PodListLooper interface {
+	SetPodList(podList []v1.Pod)
+}
+
+// PodSummaryLooper is an optional extra for loopers that add a row for the whole pod under its containers, the rows
+//
+//	from BuildPodSummary are not passed to BuildBranch and it is not called in the tree view
+type PodSummaryLooper interface {
+	BuildPodSummary(pod v1.Pod, info BuilderInformation) ([][]Cell, error)
+}
+
+// managedByLabel is the well known label set by the tool that manages the pod, eg. Helm
+const managedByLabel = "app.kubernetes.io/managed-by"
+
+// lastAppliedAnnotation is added by kubectl apply, only pods applied by hand rather than created by a controller have it
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
 type RowBuilder struct {
 	Connection         *Connector
 	Table              *Table
@@ -28,22 +52,38 @@ type RowBuilder struct {
 	LoopSpec           bool     // should we loop over v1.Pod.Spec.Containers
 	LabelNodeName      string
 	labelNodeValue     string
+	ConditionNodeName  string
+	conditionNodeValue string
 	LabelPodName       string
 	labelPodValue      string
 	AnnotationPodName  string
 	annotationPodValue string
-	ShowTreeView       bool // show the standard tree view with the resource sets as the root
+	ShowManagedBy      bool   // add the MANAGED-BY and APPLIED columns
+	managedByValue     string // value of the managedByLabel on the current pod
+	appliedValue       bool   // true when the current pod has the lastAppliedAnnotation
+	ShowPodUID         bool   // add the UID column
+	podUIDValue        string // uid of the current pod
+	ShowScheduler      bool   // add the SCHEDULER column
+	schedulerValue     string // scheduler name of the current pod
+	ShowTreeView       bool   // show the standard tree view with the resource sets as the root
 	ShowPodName        bool
 	ShowInitContainers bool
 	ShowContainerType  bool
 	ShowNodeTree       bool                  // show the tree view with the nodes at the root level rather than just the resource sets at root
 	DontListContainers bool                  // dont loop through containers, only the main pod
+	ShowTimeline       bool                  // order each pods containers by the time they started
+	OrderAnnotation    string                // pod annotation holding a comma separated list of container names to order the containers by
+	HideTreeSummary    bool                  // only show the name on the pod line of the tree view, the containers keep their indentation
+	FlattenTree        bool                  // build the tree view rows without indenting the names so the table can be sorted
+	CompactTree        bool                  // show pods with a single container row on one line in the tree view
 	FilterList         map[string]matchValue // used to filter out rows from the table during Print function
 	CalcFiltered       bool                  // the filterd out rows are included in the branch calculations
 	DefaultHeaderLen   int
 	InputFilename      string // filename to be used as the source instead of reading pod information from k8s api
 	StdinChanged       bool   // have we been run as part of a shell redirect
 
+	QuantityColumns map[string]quantityFunc // columns that accept a quantity as the match value, eg LIMIT>512Mi
+
 	annotationLabel map[string]map[string]map[string]map[string]string
 	head            []string
 	filter          []matchFilter
@@ -60,6 +100,8 @@ type BuilderInformation struct {
 	Name          string // objects name
 	TreeView      bool
 	TypeName      string // k8s kind
+	Wide          bool   // show the detail columns that are only hidden to keep the table narrow
+	StartOrder    int    // position of the container in the pods start up sequence, 0 when not started or not requested
 }
 
 type matchFilter struct {
@@ -69,6 +111,9 @@ type matchFilter struct {
 	set        bool
 }
 
+// quantityFunc converts a quantity into the raw value stored in a cell
+type quantityFunc func(apires.Quantity) int64
+
 type matchValue struct {
 	operator string
 	value    string
@@ -83,9 +128,17 @@ func (b *RowBuilder) SetFlagsFrom(commonFlagList commonFlags) {
 
 	b.ShowTreeView = commonFlagList.showTreeView
 	b.ShowNodeTree = commonFlagList.showNodeTree
+	b.HideTreeSummary = commonFlagList.hideTreeSummary
+	b.FlattenTree = commonFlagList.flattenTree
+	b.CompactTree = commonFlagList.compactTree
+	b.OrderAnnotation = commonFlagList.orderAnnotation
 	b.LabelNodeName = commonFlagList.labelNodeName
+	b.ConditionNodeName = commonFlagList.conditionNodeName
 	b.LabelPodName = commonFlagList.labelPodName
 	b.AnnotationPodName = commonFlagList.annotationPodName
+	b.ShowManagedBy = commonFlagList.showManagedBy
+	b.ShowPodUID = commonFlagList.showPodUID
+	b.ShowScheduler = commonFlagList.showScheduler
 	b.FilterList = b.CommonFlags.filterList
 	b.CalcFiltered = b.CommonFlags.calcMatchOnly
 	b.InputFilename = b.CommonFlags.inputFilename
@@ -137,7 +190,7 @@ func (b *RowBuilder) Build(loop Looper) error {
 	log := logger{location: "RowBuilder:Build"}
 	log.Debug("Start")
 
-	info := BuilderInformation{TreeView: b.ShowTreeView}
+	info := BuilderInformation{TreeView: b.ShowTreeView, Wide: b.CommonFlags.showWide}
 
 	// check if our input has been redirected
 	b.StdinChanged, err = b.HasStdinChanged()
@@ -150,16 +203,15 @@ func (b *RowBuilder) Build(loop Looper) error {
 		return err
 	}
 
-	if len(b.InputFilename) == 0 && !b.StdinChanged {
-		podList, err = b.Connection.GetPods(b.PodName)
-	} else {
-		podList, err = b.loadYaml(b.InputFilename)
-	}
-
+	podList, err = b.loadPodList()
 	if err != nil {
 		return err
 	}
 
+	if l, ok := loop.(PodListLooper); ok {
+		l.SetPodList(podList)
+	}
+
 	if b.ShowTreeView {
 		err := b.populateAnnotationsLabels(podList)
 		if err != nil {
@@ -198,6 +250,11 @@ func (b *RowBuilder) Build(loop Looper) error {
 			}
 		}
 
+		// sorting only looks at the table rows so the totals held by the placeholders need moving into them
+		if b.FlattenTree {
+			b.Table.ResolvePlaceHolders()
+		}
+
 	} else {
 		err := b.BuildContainerTable(loop, &info, podList)
 		if err != nil {
@@ -228,6 +285,7 @@ func (b *RowBuilder) walkTreeCreateRow(loop Looper, info *BuilderInformation, pa
 		var err error
 
 		rowid := b.Table.AddPlaceHolderRow()
+		collapsed := false
 		info.Namespace = value.namespace
 		info.Name = value.name
 		info.TypeName = value.kind
@@ -241,10 +299,20 @@ func (b *RowBuilder) walkTreeCreateRow(loop Looper, info *BuilderInformation, pa
 
 			// check if we have any labels that need to be shown as columns
 			b.setValuesAnnotationLabel(info.Data.pod)
+			rowCount := len(b.Table.data)
 			partOut, err = b.podLoop(loop, infoPod, value.data.pod, value.indent+1)
 			if err != nil {
 				return [][]Cell{}, err
 			}
+
+			// a pod with only one container row is shown on a single line named after the pod and the container
+			if b.CompactTree && len(partOut) == 1 && len(b.Table.data) == rowCount+1 {
+				row := b.Table.RemoveLastRow()
+				nameCell := row[len(row)-len(partOut[0])-1]
+				_, containerName, _ := strings.Cut(nameCell.text, "/")
+				info.Name = value.name + " (" + containerName + ")"
+				collapsed = true
+			}
 		} else {
 			// make the row for the table header line
 			infoSet := *info
@@ -261,11 +329,19 @@ func (b *RowBuilder) walkTreeCreateRow(loop Looper, info *BuilderInformation, pa
 		if err != nil {
 			return [][]Cell{}, err
 		} else {
-			tblOut := b.makeFullRow(&infoSet, value.indent, tblBranch)
+			// a collapsed pod shows the values of its container, the branch totals are still passed up to the parents
+			rowCells := tblBranch
+			if collapsed {
+				rowCells = partOut[0]
+			}
+			tblOut := b.makeFullRow(&infoSet, value.indent, rowCells)
 
 			if b.matchShouldExclude(tblOut) {
 				b.Table.HidePlaceHolderRow(rowid)
 			} else {
+				if b.HideTreeSummary && value.kind == "Pod" && !collapsed {
+					tblOut = b.makeFullRow(&infoSet, value.indent, blankCells(len(tblBranch)))
+				}
 				b.Table.UpdatePlaceHolderRow(rowid, tblOut)
 				if b.CalcFiltered {
 					totals = append(totals, tblBranch)
@@ -279,13 +355,38 @@ func (b *RowBuilder) walkTreeCreateRow(loop Looper, info *BuilderInformation, pa
 		// }
 
 		b.labelNodeValue = ""
+		b.conditionNodeValue = ""
 		b.labelPodValue = ""
 		b.annotationPodValue = ""
+		b.managedByValue = ""
+		b.appliedValue = false
+		b.podUIDValue = ""
+		b.schedulerValue = ""
 	}
 
 	return totals, nil
 }
 
+// blankCells returns count empty text cells, used in place of the pod summary values when HideTreeSummary is set
+func blankCells(count int) []Cell {
+	out := make([]Cell, count)
+	for i := range out {
+		out[i] = NewCellText("")
+	}
+	return out
+}
+
+// addMatchingRow adds the row to the table unless the match filter excludes it, excluded rows are counted so the json
+//
+//	metadata can report how many rows were filtered out
+func (b *RowBuilder) addMatchingRow(row []Cell) {
+	if b.matchShouldExclude(row) {
+		b.Table.filteredRows++
+		return
+	}
+	b.Table.AddRow(row...)
+}
+
 // matchShouldExclude checks the match filter and returns true if the row should be excluded from output
 func (b *RowBuilder) matchShouldExclude(tblOut []Cell) bool {
 	var fValue float64
@@ -336,12 +437,25 @@ func (b *RowBuilder) setValuesAnnotationLabel(pod v1.Pod) {
 	if b.LabelNodeName != "" {
 		b.labelNodeValue = b.annotationLabel["label"]["node"][pod.Spec.NodeName][b.LabelNodeName]
 	}
+	if b.ConditionNodeName != "" {
+		b.conditionNodeValue = b.annotationLabel["condition"]["node"][pod.Spec.NodeName][b.ConditionNodeName]
+	}
 	if b.LabelPodName != "" {
 		b.labelPodValue = b.annotationLabel["label"]["pod"][pod.Name][b.LabelPodName]
 	}
 	if b.AnnotationPodName != "" {
 		b.annotationPodValue = b.annotationLabel["annotation"]["pod"][pod.Name][b.AnnotationPodName]
 	}
+	if b.ShowManagedBy {
+		b.managedByValue = b.annotationLabel["label"]["pod"][pod.Name][managedByLabel]
+		_, b.appliedValue = b.annotationLabel["annotation"]["pod"][pod.Name][lastAppliedAnnotation]
+	}
+	if b.ShowPodUID {
+		b.podUIDValue = string(pod.UID)
+	}
+	if b.ShowScheduler {
+		b.schedulerValue = pod.Spec.SchedulerName
+	}
 
 }
 
@@ -352,6 +466,7 @@ func (b *RowBuilder) populateAnnotationsLabels(podList []v1.Pod) error {
 	b.annotationLabel = make(map[string]map[string]map[string]map[string]string)
 	b.annotationLabel["label"] = make(map[string]map[string]map[string]string)
 	b.annotationLabel["annotation"] = make(map[string]map[string]map[string]string)
+	b.annotationLabel["condition"] = make(map[string]map[string]map[string]string)
 
 	if b.LabelNodeName != "" {
 		log.Debug("b.LabelNodeName", b.LabelNodeName)
@@ -362,7 +477,16 @@ func (b *RowBuilder) populateAnnotationsLabels(podList []v1.Pod) error {
 		b.annotationLabel["label"]["node"] = nodeLabels
 	}
 
-	if b.LabelPodName != "" {
+	if b.ConditionNodeName != "" {
+		log.Debug("b.ConditionNodeName", b.ConditionNodeName)
+		nodeConditions, err := b.Connection.GetNodeConditions(podList)
+		if err != nil {
+			return err
+		}
+		b.annotationLabel["condition"]["node"] = nodeConditions
+	}
+
+	if b.LabelPodName != "" || b.ShowManagedBy {
 		log.Debug("b.LabelPodName", b.LabelPodName)
 		podLabels, err := b.Connection.GetPodLabels(podList)
 		if err != nil {
@@ -371,7 +495,7 @@ func (b *RowBuilder) populateAnnotationsLabels(podList []v1.Pod) error {
 		b.annotationLabel["label"]["pod"] = podLabels
 	}
 
-	if b.AnnotationPodName != "" {
+	if b.AnnotationPodName != "" || b.ShowManagedBy {
 		log.Debug("b.AnnotationPodName", b.AnnotationPodName)
 		podAnnotations, err := b.Connection.GetPodAnnotations(podList)
 		if err != nil {
@@ -423,19 +547,26 @@ func (b *RowBuilder) BuildContainerTable(loop Looper, info *BuilderInformation,
 // LoadHeaders sets the default column headers hiding as needed
 func (b *RowBuilder) LoadHeaders(loop Looper, info *BuilderInformation) error {
 	var tblHead []string
-	var hideColumns []int
 
 	log := logger{location: "RowBuilder:LoadHeaders"}
 	log.Debug("Start")
 
 	tblHead = b.getDefaultHead(info)
 
+	// every default column apart from the type and the container name is the same for each row of a pod
+	b.Table.PodColumns = []string{}
+	for _, title := range tblHead {
+		if title != "T" && title != "CONTAINER" && title != "NAME" {
+			b.Table.PodColumns = append(b.Table.PodColumns, title)
+		}
+	}
+
 	// save the default lengh now as we need to use it in other functions
 	defaultHeaderLen := len(tblHead)
 	log.Debug("len(defaultHeaderLen) =", defaultHeaderLen)
 	b.DefaultHeaderLen = defaultHeaderLen
 
-	hideColumns = loop.HideColumns(*info)
+	hideColumns := loop.HideColumns(*info)
 
 	tblHead = append(tblHead, loop.Headers()...)
 	log.Debug("len(tblHead) =", len(tblHead))
@@ -530,6 +661,14 @@ func (b *RowBuilder) setFilter(filter map[string]matchValue) error {
 				return errors.New("invalid value specified for filter")
 			}
 
+			if convert, ok := b.QuantityColumns[columnName]; ok {
+				var err error
+				value, err = quantityMatchValue(value, convert)
+				if err != nil {
+					return fmt.Errorf("invalid quantity specified for column %s: %w", columnName, err)
+				}
+			}
+
 			b.filter[idx].value = value
 			b.filter[idx].set = true
 		}
@@ -539,6 +678,22 @@ func (b *RowBuilder) setFilter(filter map[string]matchValue) error {
 	return nil
 }
 
+// quantityMatchValue converts a match value written as a kubernetes quantity (eg 512Mi or 250m) into the raw number
+//
+//	stored in the cell using convert, plain whole numbers are already raw values and are returned unchanged
+func quantityMatchValue(value string, convert quantityFunc) (string, error) {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value, nil
+	}
+
+	quantity, err := apires.ParseQuantity(value)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(convert(quantity), 10), nil
+}
+
 // SetVisibleColumns hides default columns based on various flags
 func (b *RowBuilder) setVisibleColumns(info *BuilderInformation) {
 	log := logger{location: "RowBuilder:SetVisibleColumns"}
@@ -594,15 +749,22 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 		}
 		for _, row := range allRows {
 			rowsOut := b.makeFullRow(&info, indentLevel, row)
-			if !b.matchShouldExclude(rowsOut) {
-				b.Table.AddRow(rowsOut...)
-			}
+			b.addMatchingRow(rowsOut)
 		}
 		podRowsOut = append(podRowsOut, allRows...)
 
 		return podRowsOut, nil
 	}
 
+	// work out the start sequence before the status lists are sorted
+	var startOrder map[string]int
+	if b.ShowTimeline && b.LoopStatus {
+		startOrder = containerStartOrder(pod)
+		pod = sortPodByStartTime(pod)
+	} else if len(b.OrderAnnotation) > 0 {
+		pod = sortPodByAnnotation(pod, b.OrderAnnotation)
+	}
+
 	if b.ShowInitContainers {
 		log.Debug("loop init Container")
 		info.ContainerType = TypeIDInitContainer
@@ -612,20 +774,19 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 			for _, container := range pod.Status.InitContainerStatuses {
 				// should the container be processed
 				log.Debug("processing -", container.Name)
-				if skipContainerName(b.CommonFlags, container.Name) {
+				if skipContainer(b.CommonFlags, pod, container.Name) {
 					continue
 				}
 
 				info.Name = container.Name
+				info.StartOrder = startOrder[container.Name]
 				allRows, err := loop.BuildContainerStatus(container, info)
 				if err != nil {
 					return [][]Cell{}, err
 				}
 				for _, row := range allRows {
 					rowsOut := b.makeFullRow(&info, indentLevel, row)
-					if !b.matchShouldExclude(rowsOut) {
-						b.Table.AddRow(rowsOut...)
-					}
+					b.addMatchingRow(rowsOut)
 				}
 				podRowsOut = append(podRowsOut, allRows...)
 			}
@@ -636,7 +797,7 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 			for _, container := range pod.Spec.InitContainers {
 				// should the container be processed
 				log.Debug("processing -", container.Name)
-				if skipContainerName(b.CommonFlags, container.Name) {
+				if skipContainer(b.CommonFlags, pod, container.Name) {
 					continue
 				}
 
@@ -647,9 +808,7 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 				}
 				for _, row := range allRows {
 					rowsOut := b.makeFullRow(&info, indentLevel, row)
-					if !b.matchShouldExclude(rowsOut) {
-						b.Table.AddRow(rowsOut...)
-					}
+					b.addMatchingRow(rowsOut)
 				}
 				podRowsOut = append(podRowsOut, allRows...)
 			}
@@ -664,20 +823,19 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 		log.Debug("processing LoopStatus")
 		for _, container := range pod.Status.ContainerStatuses {
 			// should the container be processed
-			if skipContainerName(b.CommonFlags, container.Name) {
+			if skipContainer(b.CommonFlags, pod, container.Name) {
 				continue
 			}
 			log.Debug("processing -", container.Name)
 			info.Name = container.Name
+			info.StartOrder = startOrder[container.Name]
 			allRows, err := loop.BuildContainerStatus(container, info)
 			if err != nil {
 				return [][]Cell{}, err
 			}
 			for _, row := range allRows {
 				rowsOut := b.makeFullRow(&info, indentLevel, row)
-				if !b.matchShouldExclude(rowsOut) {
-					b.Table.AddRow(rowsOut...)
-				}
+				b.addMatchingRow(rowsOut)
 			}
 			podRowsOut = append(podRowsOut, allRows...)
 		}
@@ -687,7 +845,7 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 		log.Debug("processing LoopSpec")
 		for _, container := range pod.Spec.Containers {
 			// should the container be processed
-			if skipContainerName(b.CommonFlags, container.Name) {
+			if skipContainer(b.CommonFlags, pod, container.Name) {
 				log.Debug("Skipping container:", container.Name)
 				continue
 			}
@@ -699,9 +857,7 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 			}
 			for _, row := range allRows {
 				rowsOut := b.makeFullRow(&info, indentLevel, row)
-				if !b.matchShouldExclude(rowsOut) {
-					b.Table.AddRow(rowsOut...)
-				}
+				b.addMatchingRow(rowsOut)
 			}
 			podRowsOut = append(podRowsOut, allRows...)
 		}
@@ -714,21 +870,20 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 		log.Debug("processing LoopStatus")
 		for _, container := range pod.Status.EphemeralContainerStatuses {
 			// should the container be processed
-			if skipContainerName(b.CommonFlags, container.Name) {
+			if skipContainer(b.CommonFlags, pod, container.Name) {
 				continue
 			}
 			log.Debug("processing -", container.Name)
 
 			info.Name = container.Name
+			info.StartOrder = startOrder[container.Name]
 			allRows, err := loop.BuildContainerStatus(container, info)
 			if err != nil {
 				return [][]Cell{}, err
 			}
 			for _, row := range allRows {
 				rowsOut := b.makeFullRow(&info, indentLevel, row)
-				if !b.matchShouldExclude(rowsOut) {
-					b.Table.AddRow(rowsOut...)
-				}
+				b.addMatchingRow(rowsOut)
 			}
 			podRowsOut = append(podRowsOut, allRows...)
 		}
@@ -738,7 +893,7 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 		log.Debug("processing LoopSpec")
 		for _, container := range pod.Spec.EphemeralContainers {
 			// should the container be processed
-			if skipContainerName(b.CommonFlags, container.Name) {
+			if skipContainer(b.CommonFlags, pod, container.Name) {
 				continue
 			}
 			log.Debug("processing -", container.Name)
@@ -750,17 +905,148 @@ func (b *RowBuilder) podLoop(loop Looper, info BuilderInformation, pod v1.Pod, i
 			}
 			for _, row := range allRows {
 				rowsOut := b.makeFullRow(&info, indentLevel, row)
-				if !b.matchShouldExclude(rowsOut) {
-					b.Table.AddRow(rowsOut...)
-				}
+				b.addMatchingRow(rowsOut)
 			}
 			podRowsOut = append(podRowsOut, allRows...)
 		}
 	}
 
+	if l, ok := loop.(PodSummaryLooper); ok && !info.TreeView {
+		info.ContainerType = TypeIDPod
+		info.TypeName = TypeNamePod
+		info.Name = ""
+		allRows, err := l.BuildPodSummary(pod, info)
+		if err != nil {
+			return [][]Cell{}, err
+		}
+		for _, row := range allRows {
+			rowsOut := b.makeFullRow(&info, indentLevel, row)
+			b.addMatchingRow(rowsOut)
+		}
+	}
+
 	return podRowsOut, nil
 }
 
+// containerStartTime returns the time the container was last started, containers that are waiting return a zero time
+func containerStartTime(container v1.ContainerStatus) time.Time {
+	if container.State.Running != nil {
+		return container.State.Running.StartedAt.Time
+	}
+
+	if container.State.Terminated != nil {
+		return container.State.Terminated.StartedAt.Time
+	}
+
+	return time.Time{}
+}
+
+// containerStartOrder numbers every started container in the pod by the order they started, starting from 1
+func containerStartOrder(pod v1.Pod) map[string]int {
+	var started []v1.ContainerStatus
+
+	allContainers := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	allContainers = append(allContainers, pod.Status.ContainerStatuses...)
+	allContainers = append(allContainers, pod.Status.EphemeralContainerStatuses...)
+
+	for _, container := range allContainers {
+		if !containerStartTime(container).IsZero() {
+			started = append(started, container)
+		}
+	}
+
+	sort.SliceStable(started, func(i, j int) bool {
+		return containerStartTime(started[i]).Before(containerStartTime(started[j]))
+	})
+
+	order := make(map[string]int)
+	for i, container := range started {
+		order[container.Name] = i + 1
+	}
+
+	return order
+}
+
+// sortPodByStartTime returns a copy of the pod with each container status list sorted by start time, containers
+//
+//	that havent started yet are placed at the end
+func sortPodByStartTime(pod v1.Pod) v1.Pod {
+	byStartTime := func(list []v1.ContainerStatus) []v1.ContainerStatus {
+		sorted := append([]v1.ContainerStatus{}, list...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a := containerStartTime(sorted[i])
+			b := containerStartTime(sorted[j])
+			if a.IsZero() || b.IsZero() {
+				return !a.IsZero() && b.IsZero()
+			}
+			return a.Before(b)
+		})
+		return sorted
+	}
+
+	pod.Status.InitContainerStatuses = byStartTime(pod.Status.InitContainerStatuses)
+	pod.Status.ContainerStatuses = byStartTime(pod.Status.ContainerStatuses)
+	pod.Status.EphemeralContainerStatuses = byStartTime(pod.Status.EphemeralContainerStatuses)
+
+	return pod
+}
+
+// sortPodByAnnotation returns a copy of the pod with each container list sorted by the comma separated list of container
+//
+//	names found in the pods annotation, containers not in the list are placed at the end in their original order
+func sortPodByAnnotation(pod v1.Pod, annotation string) v1.Pod {
+	value, ok := pod.Annotations[annotation]
+	if !ok {
+		return pod
+	}
+
+	order := make(map[string]int)
+	for i, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := order[name]; len(name) > 0 && !ok {
+			order[name] = i + 1
+		}
+	}
+
+	// containers that are not listed sort after all the listed containers
+	position := func(name string) int {
+		if pos, ok := order[name]; ok {
+			return pos
+		}
+		return len(order) + 1
+	}
+
+	byStatusName := func(list []v1.ContainerStatus) []v1.ContainerStatus {
+		sorted := append([]v1.ContainerStatus{}, list...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return position(sorted[i].Name) < position(sorted[j].Name)
+		})
+		return sorted
+	}
+
+	byName := func(list []v1.Container) []v1.Container {
+		sorted := append([]v1.Container{}, list...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return position(sorted[i].Name) < position(sorted[j].Name)
+		})
+		return sorted
+	}
+
+	ephemeral := append([]v1.EphemeralContainer{}, pod.Spec.EphemeralContainers...)
+	sort.SliceStable(ephemeral, func(i, j int) bool {
+		return position(ephemeral[i].Name) < position(ephemeral[j].Name)
+	})
+
+	pod.Status.InitContainerStatuses = byStatusName(pod.Status.InitContainerStatuses)
+	pod.Status.ContainerStatuses = byStatusName(pod.Status.ContainerStatuses)
+	pod.Status.EphemeralContainerStatuses = byStatusName(pod.Status.EphemeralContainerStatuses)
+	pod.Spec.InitContainers = byName(pod.Spec.InitContainers)
+	pod.Spec.Containers = byName(pod.Spec.Containers)
+	pod.Spec.EphemeralContainers = ephemeral
+
+	return pod
+}
+
 // makeFullRow adds the listed columns to the default columns, outputs
 //
 //	the complete row as a list of columns
@@ -774,6 +1060,10 @@ func (b *RowBuilder) makeFullRow(info *BuilderInformation, indentLevel int, colu
 		rowList = append(rowList, NewCellText(b.labelNodeValue))
 	}
 
+	if b.ConditionNodeName != "" {
+		rowList = append(rowList, NewCellColourText(nodeConditionColour(b.ConditionNodeName, b.conditionNodeValue), b.conditionNodeValue))
+	}
+
 	if b.LabelPodName != "" {
 		rowList = append(rowList, NewCellText(b.labelPodValue))
 	}
@@ -782,6 +1072,18 @@ func (b *RowBuilder) makeFullRow(info *BuilderInformation, indentLevel int, colu
 		rowList = append(rowList, NewCellText(b.annotationPodValue))
 	}
 
+	if b.ShowManagedBy {
+		rowList = append(rowList, NewCellText(b.managedByValue), NewCellBool(b.appliedValue))
+	}
+
+	if b.ShowPodUID {
+		rowList = append(rowList, NewCellText(b.podUIDValue))
+	}
+
+	if b.ShowScheduler {
+		rowList = append(rowList, NewCellText(b.schedulerValue))
+	}
+
 	if info.TreeView {
 		name := ""
 		// default cells dont have name column, need to add it in tree view
@@ -790,7 +1092,9 @@ func (b *RowBuilder) makeFullRow(info *BuilderInformation, indentLevel int, colu
 		} else {
 			name = info.TypeName + "/" + info.Name
 		}
-		if !b.ShowNodeTree {
+		if b.FlattenTree {
+			rowList = append(rowList, NewCellText(name))
+		} else if !b.ShowNodeTree {
 			rowList = append(rowList, NewCellTextIndent(name, indentLevel-1))
 		} else {
 			rowList = append(rowList, NewCellTextIndent(name, indentLevel))
@@ -806,6 +1110,24 @@ func (b *RowBuilder) makeFullRow(info *BuilderInformation, indentLevel int, colu
 	return rowList
 }
 
+// loadPodList returns the pods named in PodName from the cluster, or every pod in the yaml file or stdin when one
+//
+//	was passed. HasStdinChanged must be called first
+func (b *RowBuilder) loadPodList() ([]v1.Pod, error) {
+	if len(b.InputFilename) > 0 || b.StdinChanged {
+		podList, err := b.loadYaml(b.InputFilename)
+		return sortPodsByName(filterPodNodes(filterPodPhase(podList, b.CommonFlags.podPhase), b.CommonFlags.onNodes)), err
+	}
+
+	podList, err := b.Connection.GetPods(b.PodName)
+	if err == nil && b.CommonFlags.pickPods && len(podList) > 1 && isInteractive() {
+		podList, err = pickPods(podList, os.Stdin, os.Stderr)
+		// the tree view builds its owners from the connectors pod list so it has to match
+		b.Connection.podList = podList
+	}
+	return podList, err
+}
+
 // GetDefaultHead: returns the common headers in order
 func (b *RowBuilder) getDefaultHead(info *BuilderInformation) []string {
 	log := logger{location: "RowBuilder:GetDefaultHead"}
@@ -835,6 +1157,11 @@ func (b *RowBuilder) getDefaultHead(info *BuilderInformation) []string {
 		headList = append(headList, b.LabelNodeName)
 	}
 
+	if b.ConditionNodeName != "" {
+		log.Debug("ConditionNodeName =", b.ConditionNodeName)
+		headList = append(headList, b.ConditionNodeName)
+	}
+
 	if b.LabelPodName != "" {
 		log.Debug("LabelPodName =", b.LabelPodName)
 		headList = append(headList, b.LabelPodName)
@@ -845,6 +1172,18 @@ func (b *RowBuilder) getDefaultHead(info *BuilderInformation) []string {
 		headList = append(headList, b.AnnotationPodName)
 	}
 
+	if b.ShowManagedBy {
+		headList = append(headList, "MANAGED-BY", "APPLIED")
+	}
+
+	if b.ShowPodUID {
+		headList = append(headList, "UID")
+	}
+
+	if b.ShowScheduler {
+		headList = append(headList, "SCHEDULER")
+	}
+
 	if info.TreeView {
 		headList = append(headList, "NAME")
 	}
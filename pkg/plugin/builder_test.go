@@ -0,0 +1,396 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apires "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// *****************
+// sortPodByAnnotation
+// *****************
+type sortPodByAnnotationTest struct {
+	annotation string
+	expected   []string
+}
+
+var sortPodByAnnotationTests = []sortPodByAnnotationTest{
+	{"web,sidecar,proxy", []string{"web", "sidecar", "proxy"}},
+	{"proxy, web", []string{"proxy", "web", "sidecar"}},
+	{"missing,sidecar", []string{"sidecar", "proxy", "web"}},
+	{"", []string{"proxy", "sidecar", "web"}},
+}
+
+func TestSortPodByAnnotation(t *testing.T) {
+
+	for _, test := range sortPodByAnnotationTests {
+		pod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"order": test.annotation}},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "proxy"}, {Name: "sidecar"}, {Name: "web"}},
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{{Name: "proxy"}, {Name: "sidecar"}, {Name: "web"}},
+			},
+		}
+
+		sorted := sortPodByAnnotation(pod, "order")
+
+		var specNames, statusNames []string
+		for _, container := range sorted.Spec.Containers {
+			specNames = append(specNames, container.Name)
+		}
+		for _, container := range sorted.Status.ContainerStatuses {
+			statusNames = append(statusNames, container.Name)
+		}
+
+		if !reflect.DeepEqual(specNames, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", specNames, test.expected)
+		}
+		if !reflect.DeepEqual(statusNames, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", statusNames, test.expected)
+		}
+		// the original pod is left untouched
+		if pod.Spec.Containers[0].Name != "proxy" {
+			t.Errorf("original pod was modified")
+		}
+	}
+
+}
+
+// *****************
+// quantityMatchValue
+// *****************
+type quantityMatchValueTest struct {
+	value       string
+	convert     quantityFunc
+	expected    string
+	expectError bool
+}
+
+var (
+	quantityBytes = func(q apires.Quantity) int64 { return q.Value() }
+	quantityMilli = func(q apires.Quantity) int64 { return q.MilliValue() }
+)
+
+var quantityMatchValueTests = []quantityMatchValueTest{
+	{"512Mi", quantityBytes, "536870912", false},
+	{"1G", quantityBytes, "1000000000", false},
+	{"250m", quantityMilli, "250", false},
+	{"0.5", quantityMilli, "500", false},
+	// whole numbers are already raw values
+	{"300", quantityMilli, "300", false},
+	{"12xx", quantityBytes, "", true},
+}
+
+func TestQuantityMatchValue(t *testing.T) {
+
+	for _, test := range quantityMatchValueTests {
+		output, err := quantityMatchValue(test.value, test.convert)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error for %q", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error %v for %q", err, test.value)
+		} else if output != test.expected {
+			t.Errorf("Output %s not equal to expected %s", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// managed by columns
+// *****************
+func TestManagedByColumns(t *testing.T) {
+	podList := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "helm", Labels: map[string]string{managedByLabel: "Helm"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "applied", Annotations: map[string]string{lastAppliedAnnotation: "{}"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+	}
+	expected := [][]string{{"Helm", "false"}, {"", "true"}, {"", "false"}}
+
+	builder := RowBuilder{ShowManagedBy: true, Connection: &Connector{podList: podList}}
+	info := BuilderInformation{}
+	if head := builder.getDefaultHead(&info); !reflect.DeepEqual(head[len(head)-2:], []string{"MANAGED-BY", "APPLIED"}) {
+		t.Errorf("Headers %v should end with MANAGED-BY and APPLIED", head)
+	}
+
+	if err := builder.populateAnnotationsLabels(podList); err != nil {
+		t.Fatal(err)
+	}
+	for i, pod := range podList {
+		builder.setValuesAnnotationLabel(pod)
+		row := builder.makeFullRow(&info, 0)
+		output := []string{row[len(row)-2].text, row[len(row)-1].text}
+		if !reflect.DeepEqual(output, expected[i]) {
+			t.Errorf("%s: Output %v not equal to expected %v", pod.Name, output, expected[i])
+		}
+	}
+
+}
+
+// *****************
+// node condition column
+// *****************
+type nodeConditionColumnTest struct {
+	condition string
+	node      string
+	expected  string
+	colour    [2]int
+}
+
+var nodeConditionColumnTests = []nodeConditionColumnTest{
+	{"MemoryPressure", "node-ok", "False", colourOk},
+	{"MemoryPressure", "node-full", "True", colourBad},
+	{"Ready", "node-ok", "True", colourOk},
+	{"Ready", "node-full", "Unknown", colourWarn},
+	// the node doesnt report the condition
+	{"PIDPressure", "node-ok", "", [2]int{-1, 0}},
+}
+
+func TestNodeConditionColumn(t *testing.T) {
+	// the nodes are cached in the connector so no api server is needed
+	connect := Connector{nodeList: map[string]v1.Node{
+		"node-ok": {ObjectMeta: metav1.ObjectMeta{Name: "node-ok"}, Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+			{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+			{Type: v1.NodeReady, Status: v1.ConditionTrue},
+		}}},
+		"node-full": {ObjectMeta: metav1.ObjectMeta{Name: "node-full"}, Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+			{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue},
+			{Type: v1.NodeReady, Status: v1.ConditionUnknown},
+		}}},
+	}}
+
+	for _, test := range nodeConditionColumnTests {
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web"}, Spec: v1.PodSpec{NodeName: test.node}}
+		builder := RowBuilder{ConditionNodeName: test.condition, Connection: &connect}
+		info := BuilderInformation{}
+		if head := builder.getDefaultHead(&info); head[len(head)-1] != test.condition {
+			t.Errorf("Headers %v should end with %s", head, test.condition)
+		}
+
+		if err := builder.populateAnnotationsLabels([]v1.Pod{pod}); err != nil {
+			t.Fatal(err)
+		}
+		builder.setValuesAnnotationLabel(pod)
+		row := builder.makeFullRow(&info, 0)
+		if output := row[len(row)-1]; output.text != test.expected || output.colour != test.colour {
+			t.Errorf("%s on %s: Output %q %v not equal to expected %q %v", test.condition, test.node, output.text, output.colour, test.expected, test.colour)
+		}
+	}
+
+}
+
+// *****************
+// no-tree-summary
+// *****************
+type treeSummaryTest struct {
+	hideTreeSummary bool
+	expected        [][]string
+}
+
+var treeSummaryTests = []treeSummaryTest{
+	{false, [][]string{{"Pod/web-1", "4"}, {"Container/web", "3"}, {"Container/proxy", "1"}}},
+	// the pod line only keeps its name, the containers are left alone
+	{true, [][]string{{"Pod/web-1", ""}, {"Container/web", "3"}, {"Container/proxy", "1"}}},
+}
+
+func TestTreeSummary(t *testing.T) {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web"}, {Name: "proxy"}}},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+			{Name: "web", RestartCount: 3},
+			{Name: "proxy", RestartCount: 1},
+		}},
+	}
+	pods := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web-1\n  namespace: default\n"
+
+	for _, test := range treeSummaryTests {
+		// the tree view is built from the pods held by the connector rather than the file
+		builder := RowBuilder{LoopStatus: true, Connection: &Connector{podList: []v1.Pod{pod}}}
+		tbl, _ := buildTestTable(t, builder, &status{}, commonFlags{showTreeView: true, hideTreeSummary: test.hideTreeSummary}, pods)
+
+		names := columnText(tbl, "NAME")
+		restarts := columnText(tbl, "RESTARTS")
+		output := [][]string{}
+		for i := range names {
+			output = append(output, []string{names[i], restarts[i]})
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q (no-tree-summary %t)", output, test.expected, test.hideTreeSummary)
+		}
+	}
+
+}
+
+// *****************
+// show-uid
+// *****************
+type showUIDTest struct {
+	showPodUID bool
+	expected   []string
+}
+
+var showUIDTests = []showUIDTest{
+	{false, []string{}},
+	// the uid is what tells apart pods recreated with the same name
+	{true, []string{"0b6c3a4e-1111-4c2d-9e8f-aaaaaaaaaaaa", "0b6c3a4e-1111-4c2d-9e8f-aaaaaaaaaaaa", "7f2d9b1c-2222-4e3f-8a9b-bbbbbbbbbbbb"}},
+}
+
+func TestShowUIDColumn(t *testing.T) {
+	pods := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+  uid: 0b6c3a4e-1111-4c2d-9e8f-aaaaaaaaaaaa
+spec:
+  containers:
+  - name: web
+  - name: proxy
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: other
+  uid: 7f2d9b1c-2222-4e3f-8a9b-bbbbbbbbbbbb
+spec:
+  containers:
+  - name: web
+`
+
+	for _, test := range showUIDTests {
+		tbl, _ := buildTestTable(t, RowBuilder{LoopSpec: true}, &commands{}, commonFlags{showPodUID: test.showPodUID}, pods)
+		if output := columnText(tbl, "UID"); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v (show-uid %t)", output, test.expected, test.showPodUID)
+		}
+
+		// pods recreated with the same name are kept apart in -o json-nested
+		podColumns := []string{"NAMESPACE", "NODE", "PODNAME"}
+		if test.showPodUID {
+			podColumns = append(podColumns, "UID")
+		}
+		if !reflect.DeepEqual(tbl.PodColumns, podColumns) {
+			t.Errorf("Output PodColumns %v not equal to expected %v", tbl.PodColumns, podColumns)
+		}
+	}
+
+}
+
+// *****************
+// show-scheduler
+// *****************
+type showSchedulerTest struct {
+	showScheduler bool
+	expected      []string
+}
+
+var showSchedulerTests = []showSchedulerTest{
+	{false, []string{}},
+	// a pod read from a file without a schedulerName is shown blank rather than guessing the default
+	{true, []string{"default-scheduler", "volcano", ""}},
+}
+
+func TestShowSchedulerColumn(t *testing.T) {
+	pods := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+spec:
+  schedulerName: default-scheduler
+  containers:
+  - name: web
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-2
+  namespace: default
+spec:
+  schedulerName: volcano
+  containers:
+  - name: web
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-3
+  namespace: default
+spec:
+  containers:
+  - name: web
+`
+
+	for _, test := range showSchedulerTests {
+		tbl, _ := buildTestTable(t, RowBuilder{LoopSpec: true}, &commands{}, commonFlags{showScheduler: test.showScheduler}, pods)
+		if output := columnText(tbl, "SCHEDULER"); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q (show-scheduler %t)", output, test.expected, test.showScheduler)
+		}
+	}
+
+}
+
+// buildTestTable writes the pod yaml to a file and builds the table for loop from it, builder holds the loop settings
+//
+//	of the command being tested
+func buildTestTable(t *testing.T, builder RowBuilder, loop Looper, flags commonFlags, pods string) (Table, RowBuilder) {
+	t.Helper()
+
+	flags.inputFilename = filepath.Join(t.TempDir(), "pods.yaml")
+	if err := os.WriteFile(flags.inputFilename, []byte(pods), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	table := Table{}
+	if builder.Connection == nil {
+		builder.Connection = &Connector{}
+	}
+	builder.Table = &table
+	builder.SetFlagsFrom(flags)
+	if err := builder.Build(loop); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return table, builder
+}
+
+// visibleHeaders returns the titles of the columns that are printed
+func visibleHeaders(tbl Table) []string {
+	headers := []string{}
+	for _, head := range tbl.head {
+		if !head.hidden {
+			headers = append(headers, head.title)
+		}
+	}
+	return headers
+}
+
+// columnText returns the text of the named column from every visible row
+func columnText(tbl Table, title string) []string {
+	col := -1
+	for i, head := range tbl.head {
+		if head.title == title {
+			col = i
+			break
+		}
+	}
+
+	output := []string{}
+	for _, row := range tbl.getVisibleRows() {
+		if col >= 0 {
+			output = append(output, row[col].text)
+		}
+	}
+	return output
+}
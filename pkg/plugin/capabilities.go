@@ -1,7 +1,9 @@
 package plugin
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
@@ -11,6 +13,10 @@ import (
 var capabilitiesShort = "Shows details of configured containers POSIX capabilities"
 
 var capabilitiesDescription = ` View POSIX Capabilities that have been applied to the running containers.
+
+Using --lint checks each container for capabilities that give it control over the node, such as SYS_ADMIN or
+NET_ADMIN, these are also highlighted in the ADD column. Containers that dont drop any capabilities keep the
+default set given by the container runtime, --lint notes this in the WARN column.
 `
 
 var capabilitiesExample = `  # List container capabilities from pods
@@ -34,6 +40,12 @@ var capabilitiesExample = `  # List container capabilities from pods
   # namespace sorted by pod name in ascending order
   %[1]s capabilities -c web-container --sort PODNAME
 
+  # Check every container in the namespace for dangerous capabilities
+  %[1]s capabilities --lint
+
+  # Fail with a non zero exit code when a manifest adds a dangerous capability
+  %[1]s capabilities --lint --fail-on-warn -f manifest.yaml
+
   # List container capabilities info from all pods where label app matches web
   %[1]s capabilities -l app=web
 
@@ -64,6 +76,16 @@ func Capabilities(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags,
 	builder.Connection = &connect
 	builder.SetFlagsFrom(commonFlagList)
 
+	if cmd.Flag("lint").Value.String() == "true" {
+		log.Debug("loopinfo.ShowLint = true")
+		loopinfo.ShowLint = true
+	}
+
+	failOnWarn := cmd.Flag("fail-on-warn").Value.String() == "true"
+	if failOnWarn && !loopinfo.ShowLint {
+		return errors.New("--fail-on-warn can only be used with --lint")
+	}
+
 	table := Table{}
 	table.ColourOutput = commonFlagList.outputAsColour
 	table.CustomColours = commonFlagList.useTheseColours
@@ -79,17 +101,42 @@ func Capabilities(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags,
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	if err := outputTableAs(table, commonFlagList); err != nil {
+		return err
+	}
 
+	if failOnWarn && len(loopinfo.lintFailures) > 0 {
+		return newIceError(ErrLintFailed, fmt.Errorf("capability lint failed:\n  %s", strings.Join(loopinfo.lintFailures, "\n  ")))
+	}
+
+	return nil
 }
 
 type capabilities struct {
+	ShowLint bool // check each container for dangerous capabilities and show the result in the WARN column
+
+	lintFailures []string // namespace/pod/container: warning, for every container that added a dangerous capability
+}
+
+// dangerousCapabilities are the capabilities that let a container escape or take control of the node
+var dangerousCapabilities = []string{
+	"ALL", "BPF", "DAC_READ_SEARCH", "NET_ADMIN", "NET_RAW", "PERFMON", "SYS_ADMIN", "SYS_BOOT", "SYS_MODULE", "SYS_PTRACE", "SYS_RAWIO",
+}
+
+// isDangerousCapability returns true if the capability is in dangerousCapabilities, the CAP_ prefix and case are ignored
+func isDangerousCapability(capability v1.Capability) bool {
+	name := strings.TrimPrefix(strings.ToUpper(string(capability)), "CAP_")
+	for _, dangerous := range dangerousCapabilities {
+		if name == dangerous {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *capabilities) Headers() []string {
 	return []string{
-		"ADD", "DROP",
+		"ADD", "DROP", "WARN",
 	}
 }
 
@@ -98,6 +145,9 @@ func (s *capabilities) BuildContainerStatus(container v1.ContainerStatus, info B
 }
 
 func (s *capabilities) HideColumns(info BuilderInformation) []int {
+	if !s.ShowLint {
+		return []int{2}
+	}
 	return []int{}
 }
 
@@ -105,6 +155,7 @@ func (s *capabilities) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Ce
 	out := []Cell{
 		NewCellText(""),
 		NewCellText(""),
+		NewCellText(""),
 	}
 	return out, nil
 }
@@ -123,9 +174,11 @@ func (s *capabilities) BuildEphemeralContainerSpec(container v1.EphemeralContain
 
 func (s *capabilities) capabilitiesBuildRow(securityContext *v1.SecurityContext, info BuilderInformation) []Cell {
 	var cellList []Cell
+	var dangerous []string
 
 	capAdd := ""
 	capDrop := ""
+	capDropCount := 0
 
 	if securityContext != nil {
 		if securityContext.Capabilities != nil {
@@ -135,6 +188,9 @@ func (s *capabilities) capabilitiesBuildRow(securityContext *v1.SecurityContext,
 					sep = ""
 				}
 				capAdd += sep + fmt.Sprint(v)
+				if isDangerousCapability(v) {
+					dangerous = append(dangerous, fmt.Sprint(v))
+				}
 			}
 
 			for i, v := range securityContext.Capabilities.Drop {
@@ -144,12 +200,31 @@ func (s *capabilities) capabilitiesBuildRow(securityContext *v1.SecurityContext,
 				}
 				capDrop += sep + fmt.Sprint(v)
 			}
+			capDropCount = len(securityContext.Capabilities.Drop)
+		}
+	}
+
+	addColour := [2]int{-1, 0}
+	if len(dangerous) > 0 {
+		addColour = colourBad
+	}
+
+	warnCell := NewCellText("")
+	if s.ShowLint {
+		if len(dangerous) > 0 {
+			warning := "adds " + strings.Join(dangerous, ",")
+			warnCell = NewCellColourText(colourBad, warning)
+			s.lintFailures = append(s.lintFailures, fmt.Sprintf("%s/%s/%s: %s", info.Namespace, info.PodName, info.Name, warning))
+		} else if capDropCount == 0 {
+			// not a failure, but worth knowing the container still has everything the runtime gives it
+			warnCell = NewCellColourText(colourWarn, "nothing dropped, runtime default capabilities apply")
 		}
 	}
 
 	cellList = append(cellList,
-		NewCellText(capAdd),
+		NewCellColourText(addColour, capAdd),
 		NewCellText(capDrop),
+		warnCell,
 	)
 
 	return cellList
@@ -0,0 +1,65 @@
+package plugin
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// *****************
+// isDangerousCapability
+// *****************
+type isDangerousCapabilityTest struct {
+	capability v1.Capability
+	expected   bool
+}
+
+var isDangerousCapabilityTests = []isDangerousCapabilityTest{
+	{"SYS_ADMIN", true},
+	{"CAP_NET_ADMIN", true},
+	{"sys_ptrace", true},
+	{"ALL", true},
+	{"CHOWN", false},
+	{"NET_BIND_SERVICE", false},
+}
+
+func TestIsDangerousCapability(t *testing.T) {
+
+	for _, test := range isDangerousCapabilityTests {
+		if output := isDangerousCapability(test.capability); output != test.expected {
+			t.Errorf("Output %v not equal to expected %v for %s", output, test.expected, test.capability)
+		}
+	}
+
+}
+
+// *****************
+// capabilitiesBuildRow
+// *****************
+type capabilitiesLintTest struct {
+	capabilities *v1.Capabilities
+	warn         string
+	failed       bool
+}
+
+var capabilitiesLintTests = []capabilitiesLintTest{
+	{&v1.Capabilities{Add: []v1.Capability{"NET_ADMIN", "CHOWN"}, Drop: []v1.Capability{"ALL"}}, "adds NET_ADMIN", true},
+	{&v1.Capabilities{Drop: []v1.Capability{"ALL"}}, "", false},
+	{nil, "nothing dropped, runtime default capabilities apply", false},
+}
+
+func TestCapabilitiesLint(t *testing.T) {
+
+	for _, test := range capabilitiesLintTests {
+		s := capabilities{ShowLint: true}
+		row := s.capabilitiesBuildRow(&v1.SecurityContext{Capabilities: test.capabilities}, BuilderInformation{})
+
+		if row[2].text != test.warn {
+			t.Errorf("Output %q not equal to expected %q", row[2].text, test.warn)
+		}
+		if (len(s.lintFailures) > 0) != test.failed {
+			t.Errorf("Output lint failures %v, expected failure %v", s.lintFailures, test.failed)
+		}
+	}
+
+}
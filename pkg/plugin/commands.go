@@ -12,7 +12,8 @@ var commandsShort = "Retrieves the command line and any arguments specified at t
 
 var commandsDescription = ` Prints command and arguments used to start each container (if specified), single pods and 
 containers can be selected by name.  If no name is specified the container commands of all pods
-in the current namespace are shown.
+in the current namespace are shown. The working directory and tty/stdin settings can also be shown
+using the --details flag.
 
 The T column in the table output denotes S for Standard and I for init containers`
 
@@ -25,6 +26,9 @@ var commandsExample = `  # List containers command info from pods
   # List container command info from a single pod
   %[1]s command my-pod-4jh36
 
+  # List container command info along with the working directory and tty/stdin settings
+  %[1]s command --details
+
   # List command info for all containers named web-container searching all 
   # pods in the current namespace
   %[1]s command -c web-container
@@ -44,8 +48,12 @@ var commandsExample = `  # List containers command info from pods
   %[1]s command -l "app in (web,mail)"`
 
 type commandLine struct {
-	cmd  []string
-	args []string
+	cmd        []string
+	args       []string
+	workingDir string
+	tty        bool
+	stdin      bool
+	stdinOnce  bool
 }
 
 func Commands(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []string) error {
@@ -72,6 +80,11 @@ func Commands(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args
 	builder.Connection = &connect
 	builder.SetFlagsFrom(commonFlagList)
 
+	if cmd.Flag("details").Value.String() == "true" {
+		log.Debug("loopinfo.ShowDetails = true")
+		loopinfo.ShowDetails = true
+	}
+
 	table := Table{}
 	table.ColourOutput = commonFlagList.outputAsColour
 	table.CustomColours = commonFlagList.useTheseColours
@@ -87,17 +100,17 @@ func Commands(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	return outputTableAs(table, commonFlagList)
 
 }
 
 type commands struct {
+	ShowDetails bool // show the working directory and tty/stdin settings
 }
 
 func (s *commands) Headers() []string {
 	return []string{
-		"COMMAND", "ARGUMENTS",
+		"COMMAND", "ARGUMENTS", "WORKINGDIR", "TTY", "STDIN", "STDIN-ONCE",
 	}
 }
 
@@ -106,11 +119,18 @@ func (s *commands) BuildContainerStatus(container v1.ContainerStatus, info Build
 }
 
 func (s *commands) HideColumns(info BuilderInformation) []int {
+	if !s.ShowDetails && !info.Wide {
+		return []int{2, 3, 4, 5}
+	}
 	return []int{}
 }
 
 func (s *commands) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
 	out := []Cell{
+		NewCellText(""),
+		NewCellText(""),
+		NewCellText(""),
+		NewCellText(""),
 		NewCellText(""),
 		NewCellText("")}
 	return out, nil
@@ -118,8 +138,12 @@ func (s *commands) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell,
 
 func (s *commands) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
 	cmdLine := commandLine{
-		cmd:  container.Command,
-		args: container.Args,
+		cmd:        container.Command,
+		args:       container.Args,
+		workingDir: container.WorkingDir,
+		tty:        container.TTY,
+		stdin:      container.Stdin,
+		stdinOnce:  container.StdinOnce,
 	}
 	out := make([][]Cell, 1)
 	out[0] = s.commandsBuildRow(cmdLine, info)
@@ -128,8 +152,12 @@ func (s *commands) BuildContainerSpec(container v1.Container, info BuilderInform
 
 func (s *commands) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
 	cmdLine := commandLine{
-		cmd:  container.Command,
-		args: container.Args,
+		cmd:        container.Command,
+		args:       container.Args,
+		workingDir: container.WorkingDir,
+		tty:        container.TTY,
+		stdin:      container.Stdin,
+		stdinOnce:  container.StdinOnce,
 	}
 	out := make([][]Cell, 1)
 	out[0] = s.commandsBuildRow(cmdLine, info)
@@ -142,6 +170,10 @@ func (s *commands) commandsBuildRow(cmdLine commandLine, info BuilderInformation
 	cellList = append(cellList,
 		NewCellText(strings.Join(cmdLine.cmd, " ")),
 		NewCellText(strings.Join(cmdLine.args, " ")),
+		NewCellText(cmdLine.workingDir),
+		NewCellBool(cmdLine.tty),
+		NewCellBool(cmdLine.stdin),
+		NewCellBool(cmdLine.stdinOnce),
 	)
 
 	return cellList
@@ -0,0 +1,64 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+)
+
+// *****************
+// commands details
+// *****************
+type commandsDetailsTest struct {
+	loop     commands
+	wide     bool
+	headers  []string
+	expected [][]string
+}
+
+var commandsDetailsTests = []commandsDetailsTest{
+	{commands{}, false, []string{"PODNAME", "CONTAINER", "COMMAND", "ARGUMENTS"}, [][]string{{"sh -c", "sleep 1d"}, {"", ""}}},
+	{commands{ShowDetails: true}, false, []string{"PODNAME", "CONTAINER", "COMMAND", "ARGUMENTS", "WORKINGDIR", "TTY", "STDIN", "STDIN-ONCE"},
+		[][]string{{"sh -c", "sleep 1d", "/work", "true", "true", "false"}, {"", "", "", "false", "false", "false"}}},
+	// --wide shows the details without asking for them
+	{commands{}, true, []string{"NODE", "PODNAME", "CONTAINER", "COMMAND", "ARGUMENTS", "WORKINGDIR", "TTY", "STDIN", "STDIN-ONCE"},
+		[][]string{{"sh -c", "sleep 1d", "/work", "true", "true", "false"}, {"", "", "", "false", "false", "false"}}},
+}
+
+func TestCommandsDetails(t *testing.T) {
+	pods := `apiVersion: v1
+kind: Pod
+metadata:
+  name: shell-1
+  namespace: default
+spec:
+  containers:
+  - name: shell
+    command: ["sh", "-c"]
+    args: ["sleep 1d"]
+    workingDir: /work
+    tty: true
+    stdin: true
+  - name: web
+`
+
+	for _, test := range commandsDetailsTests {
+		loop := test.loop
+		tbl, _ := buildTestTable(t, RowBuilder{LoopSpec: true}, &loop, commonFlags{showWide: test.wide, showNodeName: test.wide}, pods)
+		if output := visibleHeaders(tbl); !reflect.DeepEqual(output, test.headers) {
+			t.Errorf("Headers %v not equal to expected %v", output, test.headers)
+		}
+
+		output := [][]string{}
+		for _, row := range tbl.getVisibleRows() {
+			cells := []string{}
+			for _, cell := range row[len(row)-6 : len(row)-6+len(test.expected[0])] {
+				cells = append(cells, tbl.boolText(cell))
+			}
+			output = append(output, cells)
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", output, test.expected)
+		}
+	}
+
+}
@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var containersPerPodShort = "Show how many pods have each number of containers"
+
+var containersPerPodDescription = ` Prints a histogram of the number of containers in each pod, CONTAINER-COUNT is the number of containers
+and POD-COUNT is how many pods have that many, useful for seeing how many sidecars are being added to pods
+across a namespace. Init and ephemeral containers are not counted. If no name is specified all pods in the
+current namespace are counted.`
+
+var containersPerPodExample = `  # Show how many pods have each number of containers in the current namespace
+  %[1]s containers-per-pod
+
+  # Show how many pods have each number of containers across all namespaces output in JSON format
+  %[1]s containers-per-pod -A -o json
+
+  # Show the container counts of all pods where label app matches web
+  %[1]s containers-per-pod -l app=web
+
+  # Show the container counts with the most common count first
+  %[1]s containers-per-pod --sort '!POD-COUNT'`
+
+func ContainersPerPod(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []string) error {
+	log := logger{location: "ContainersPerPod"}
+	log.Debug("Start")
+
+	builder := RowBuilder{}
+	builder.PodName = args
+
+	connect := Connector{}
+	if err := connect.LoadConfig(kubeFlags); err != nil {
+		return err
+	}
+
+	commonFlagList, err := processCommonFlags(cmd)
+	if err != nil {
+		return err
+	}
+	connect.Flags = commonFlagList
+	builder.Connection = &connect
+	builder.SetFlagsFrom(commonFlagList)
+
+	builder.StdinChanged, err = builder.HasStdinChanged()
+	if err != nil {
+		return err
+	}
+
+	podList, err := builder.loadPodList()
+	if err != nil {
+		return err
+	}
+
+	table := Table{}
+	table.ColourOutput = commonFlagList.outputAsColour
+	table.CustomColours = commonFlagList.useTheseColours
+	table.SetHeader("CONTAINER-COUNT", "POD-COUNT")
+
+	for _, row := range containersPerPod(podList) {
+		table.AddRow(
+			NewCellInt(fmt.Sprintf("%d", row[0]), int64(row[0])),
+			NewCellInt(fmt.Sprintf("%d", row[1]), int64(row[1])),
+		)
+	}
+
+	if err := table.SortByNames(commonFlagList.sortList...); err != nil {
+		return err
+	}
+
+	return outputTableAs(table, commonFlagList)
+}
+
+// containersPerPod returns the number of containers and the number of pods with that many containers, ordered by the
+//
+//	number of containers. only the counts that at least one pod has are returned
+func containersPerPod(podList []v1.Pod) [][2]int {
+	podCount := make(map[int]int)
+	for _, pod := range podList {
+		podCount[len(pod.Spec.Containers)]++
+	}
+
+	var out [][2]int
+	for count, pods := range podCount {
+		out = append(out, [2]int{count, pods})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+
+	return out
+}
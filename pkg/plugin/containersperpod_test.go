@@ -0,0 +1,42 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// *****************
+// containersPerPod
+// *****************
+func podWithContainers(count int, initCount int) v1.Pod {
+	pod := v1.Pod{}
+	for i := 0; i < count; i++ {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{})
+	}
+	for i := 0; i < initCount; i++ {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, v1.Container{})
+	}
+	return pod
+}
+
+func TestContainersPerPod(t *testing.T) {
+	tests := []struct {
+		podList  []v1.Pod
+		expected [][2]int
+	}{
+		{[]v1.Pod{}, nil},
+		{[]v1.Pod{podWithContainers(3, 0), podWithContainers(1, 0), podWithContainers(1, 0)}, [][2]int{{1, 2}, {3, 1}}},
+		// init containers dont count
+		{[]v1.Pod{podWithContainers(2, 1), podWithContainers(2, 0)}, [][2]int{{2, 2}}},
+	}
+
+	for _, test := range tests {
+		output := containersPerPod(test.podList)
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", output, test.expected)
+		}
+	}
+
+}
@@ -0,0 +1,204 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// contextResults collects the rows from each kubeconfig context when --contexts is used, the rows are printed
+//
+//	together once every context has been queried
+type contextResults struct {
+	current string      // name of the context being queried
+	titles  []string    // column titles of the first table, every context has to match
+	table   *Table      // rows from every context with a CONTEXT column, nil until a table is collected
+	flags   commonFlags // flags from the first context, used to print the merged table
+	added   bool        // true once the current context has added its rows
+}
+
+// contextResultsKey stores the contextResults in the commands context while it is run once for each context
+type contextResultsKey struct{}
+
+// contextResultsFrom returns the contextResults stored on cmd by addContextsLoop, nil is returned when the command
+//
+//	isnt being run for each context
+func contextResultsFrom(cmd *cobra.Command) *contextResults {
+	if cmd.Context() == nil {
+		return nil
+	}
+	results, _ := cmd.Context().Value(contextResultsKey{}).(*contextResults)
+	return results
+}
+
+// addContextsLoop wraps the commands RunE so it is run once for each context passed to --contexts, --show-context runs
+//
+//	the current context the same way so the CONTEXT column is added. without either flag the command is run as normal
+func addContextsLoop(cmdObj *cobra.Command, kubeFlags *genericclioptions.ConfigFlags) {
+	run := cmdObj.RunE
+
+	cmdObj.RunE = func(cmd *cobra.Command, args []string) error {
+		contextList, err := getNameListFlag(cmd, "contexts")
+		if err != nil {
+			return err
+		}
+		if len(contextList) > 0 {
+			if cmd.Flag("context").Changed {
+				return errors.New("--context and --contexts can not be used together")
+			}
+		} else if cmd.Flag("show-context").Value.String() == "true" {
+			contextName, err := currentContextName(kubeFlags)
+			if err != nil {
+				return err
+			}
+			contextList = []string{contextName}
+		} else {
+			return run(cmd, args)
+		}
+
+		if len(cmd.Flag("filename").Value.String()) > 0 {
+			return errors.New("--contexts and --show-context can only be used with live pod data, they can not be combined with a file")
+		}
+
+		// processCommonFlags reads the results from the context so outputTableAs adds each table to them
+		results := &contextResults{}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		cmd.SetContext(context.WithValue(ctx, contextResultsKey{}, results))
+		defer cmd.SetContext(ctx)
+
+		return runForContexts(contextList, kubeFlags, results, func() error {
+			return run(cmd, args)
+		})
+	}
+}
+
+// runForContexts calls run once for each context and prints the rows together with a CONTEXT column, a context that
+//
+//	cant be reached is reported as a warning and the rest are still shown. errors returned after a context has added
+//	its rows (eg. --fail-on-warn) are returned once the merged table has been printed. the rows are sorted again
+//	once they are merged as each context only sorts its own rows
+func runForContexts(contextList []string, kubeFlags *genericclioptions.ConfigFlags, results *contextResults, run func() error) error {
+	log := logger{location: "runForContexts"}
+	log.Debug("Start")
+
+	// put back once every context has been queried so the flags still point at the context the user chose
+	origContext := kubeFlags.Context
+	defer func() { kubeFlags.Context = origContext }()
+
+	var lastErr error
+	var resultErr error
+	for _, name := range contextList {
+		contextName := name
+		kubeFlags.Context = &contextName
+		results.current = name
+		results.added = false
+
+		err := run()
+		if err == nil {
+			continue
+		}
+		if results.added {
+			if resultErr == nil {
+				resultErr = err
+			}
+			continue
+		}
+
+		// with only one context there is nothing else to show so the error is returned as it is
+		if len(contextList) == 1 {
+			return err
+		}
+
+		lastErr = err
+		if errors.Is(err, ErrNoPods) || errors.Is(err, ErrNoMatch) {
+			log.Debug("context", name, err)
+			continue
+		}
+		log.Tell(fmt.Sprintf("context %s: %v", name, err))
+	}
+
+	if results.table == nil {
+		// the failures have already been shown as warnings, only a context with no pods passes its error on so the
+		//  exit code still says nothing was found
+		if lastErr == nil || errors.Is(lastErr, ErrNoPods) || errors.Is(lastErr, ErrNoMatch) {
+			return lastErr
+		}
+		return fmt.Errorf("no results from any of the contexts %s: %w", strings.Join(contextList, ","), lastErr)
+	}
+
+	if err := results.table.SortByNames(results.flags.sortList...); err != nil {
+		return err
+	}
+
+	// cleared so the merged table is printed rather than added to itself
+	flags := results.flags
+	flags.contextResults = nil
+	if err := outputTableAs(*results.table, flags); err != nil {
+		return err
+	}
+	return resultErr
+}
+
+// add appends the visible rows of t to the merged table with the current context name after the container type,
+//
+//	the type stays in column 0 as the count and tree code expect
+func (r *contextResults) add(t Table, flags commonFlags) error {
+	titles := make([]string, len(t.head))
+	for i, h := range t.head {
+		titles[i] = h.title
+	}
+
+	if r.table == nil {
+		// the column order is shared with t so it is cleared before the headers are set, then rebuilt from t
+		merged := t
+		merged.columnOrder = nil
+		merged.SetHeader(append([]string{titles[0], "CONTEXT"}, titles[1:]...)...)
+		merged.columnOrder = []int{}
+		for _, idx := range t.columnOrder {
+			if idx == 0 {
+				merged.columnOrder = append(merged.columnOrder, 0, 1)
+			} else {
+				merged.columnOrder = append(merged.columnOrder, idx+1)
+			}
+		}
+		for i, h := range t.head {
+			col := i
+			if i > 0 {
+				col++
+			}
+			merged.head[col].hidden = h.hidden
+			merged.head[col].columnType = h.columnType
+		}
+		merged.data = [][]Cell{}
+		merged.rowOrder = []int{}
+		merged.hideRow = []bool{}
+		merged.placeHolder = map[int][]Cell{}
+		merged.placeHolderID = 0
+		merged.currentRow = 0
+		merged.filteredRows = 0
+		merged.PodColumns = append([]string{"CONTEXT"}, t.PodColumns...)
+
+		r.table = &merged
+		r.titles = titles
+		r.flags = flags
+	} else if !reflect.DeepEqual(titles, r.titles) {
+		return fmt.Errorf("the columns from context %s dont match the columns of the other contexts", r.current)
+	}
+
+	for _, row := range t.getVisibleRows() {
+		cells := append([]Cell{row[0], NewCellText(r.current)}, row[1:]...)
+		r.table.AddRow(cells...)
+	}
+	r.table.filteredRows += t.filteredRows
+	r.added = true
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// *****************
+// contextResults add
+// *****************
+func TestContextResultsAdd(t *testing.T) {
+	results := contextResults{}
+
+	for _, name := range []string{"prod-us", "prod-eu"} {
+		tbl := Table{}
+		tbl.SetHeader("T", "PODNAME", "RESTARTS")
+		tbl.AddRow(NewCellText("C"), NewCellText("web-1"), NewCellInt("3", 3))
+		tbl.AddRow(NewCellText("C"), NewCellText("web-2"), NewCellInt("0", 0))
+		tbl.HideRows([]int{1})
+
+		results.current = name
+		if err := results.add(tbl, commonFlags{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var output [][]string
+	for _, row := range results.table.getVisibleRows() {
+		var line []string
+		for _, cell := range row {
+			line = append(line, cell.text)
+		}
+		output = append(output, line)
+	}
+	expected := [][]string{
+		{"C", "prod-us", "web-1", "3"},
+		{"C", "prod-eu", "web-1", "3"},
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+
+	// a context with different columns cant be shown in the same table
+	tbl := Table{}
+	tbl.SetHeader("T", "PODNAME")
+	results.current = "dev"
+	if err := results.add(tbl, commonFlags{}); err == nil {
+		t.Errorf("expected an error when the columns dont match")
+	}
+
+}
+
+// *****************
+// runForContexts
+// *****************
+func TestRunForContextsSort(t *testing.T) {
+	out := bytes.Buffer{}
+	results := &contextResults{}
+	restarts := map[string][]int64{"prod-us": {5, 1}, "prod-eu": {3}}
+	kubeFlags := genericclioptions.NewConfigFlags(false)
+
+	err := runForContexts([]string{"prod-us", "prod-eu"}, kubeFlags, results, func() error {
+		tbl := Table{Out: &out}
+		tbl.SetHeader("T", "PODNAME", "RESTARTS")
+		for i, count := range restarts[*kubeFlags.Context] {
+			tbl.AddRow(NewCellText("C"), NewCellText(fmt.Sprintf("web-%d", i)), NewCellInt(fmt.Sprintf("%d", count), count))
+		}
+		// the flags are passed in the same way processCommonFlags reads them from the command
+		return outputTableAs(tbl, commonFlags{outputAs: "csv", sortList: []string{"RESTARTS"}, contextResults: results})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// each context is sorted on its own so the merged rows have to be sorted again
+	expected := "\"T\", \"CONTEXT\", \"PODNAME\", \"RESTARTS\"\n" +
+		"\"C\", \"prod-us\", \"web-1\", \"1\"\n" +
+		"\"C\", \"prod-eu\", \"web-0\", \"3\"\n" +
+		"\"C\", \"prod-us\", \"web-0\", \"5\"\n"
+	if out.String() != expected {
+		t.Errorf("Output %q not equal to expected %q", out.String(), expected)
+	}
+
+}
+
+// *****************
+// runForContexts json-nested
+// *****************
+func TestRunForContextsJsonNested(t *testing.T) {
+	out := bytes.Buffer{}
+	results := &contextResults{}
+	kubeFlags := genericclioptions.NewConfigFlags(false)
+
+	err := runForContexts([]string{"prod-us", "prod-eu"}, kubeFlags, results, func() error {
+		// the same pod name in the same namespace of both clusters
+		tbl := Table{Out: &out, PodColumns: []string{"NAMESPACE", "PODNAME"}}
+		tbl.SetHeader("T", "NAMESPACE", "PODNAME", "CONTAINER")
+		tbl.AddRow(NewCellText("C"), NewCellText("default"), NewCellText("web-1"), NewCellText("web"))
+		return outputTableAs(tbl, commonFlags{outputAs: "json-nested", contextResults: results})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	output := bytes.Buffer{}
+	if err := json.Compact(&output, out.Bytes()); err != nil {
+		t.Fatalf("invalid json %v: %s", err, out.String())
+	}
+	expected := `{"data":[` +
+		`{"CONTEXT":"prod-us","NAMESPACE":"default","PODNAME":"web-1","containers":[{"T":"C","CONTAINER":"web"}]},` +
+		`{"CONTEXT":"prod-eu","NAMESPACE":"default","PODNAME":"web-1","containers":[{"T":"C","CONTAINER":"web"}]}]}`
+	if output.String() != expected {
+		t.Errorf("Output %s not equal to expected %s", output.String(), expected)
+	}
+
+}
+
+// *****************
+// runForContexts errors
+// *****************
+func TestRunForContextsErrors(t *testing.T) {
+	results := &contextResults{}
+	kubeFlags := genericclioptions.NewConfigFlags(false)
+	current := "admin"
+	kubeFlags.Context = &current
+
+	err := runForContexts([]string{"prod-us", "prod-eu"}, kubeFlags, results, func() error {
+		return newIceError(ErrConfig, fmt.Errorf("context %s does not exist", *kubeFlags.Context))
+	})
+
+	// the kind of the last failure is kept so the exit code still says what went wrong
+	if ExitCode(err) != ExitConfig {
+		t.Errorf("Output %d not equal to expected %d (%v)", ExitCode(err), ExitConfig, err)
+	}
+	if kubeFlags.Context != &current {
+		t.Errorf("Output %v not equal to expected %v", *kubeFlags.Context, current)
+	}
+
+}
+
+// *****************
+// currentContextName
+// *****************
+func TestCurrentContextName(t *testing.T) {
+	useTestServer(t, "http://127.0.0.1:1")
+
+	configFlags := genericclioptions.NewConfigFlags(false)
+	if name, err := currentContextName(configFlags); err != nil || name != "dev" {
+		t.Errorf("Output %q (%v) not equal to expected dev", name, err)
+	}
+
+	// --context overrides the kubeconfig
+	override := "prod-eu"
+	configFlags.Context = &override
+	if name, err := currentContextName(configFlags); err != nil || name != "prod-eu" {
+		t.Errorf("Output %q (%v) not equal to expected prod-eu", name, err)
+	}
+
+}
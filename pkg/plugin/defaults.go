@@ -0,0 +1,294 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// flagDefaultsEnv holds default flags in the same form as the command line, eg. "--sort '!RESTARTS' --color mix"
+const flagDefaultsEnv = "KUBECTL_ICE_FLAGS"
+
+// flagDefaultsFileEnv overrides the location of the config file
+const flagDefaultsFileEnv = "KUBECTL_ICE_CONFIG"
+
+// flagDefaultConflicts lists the pairs of flags that cant be used together, a default is skipped when the other flag of
+//
+//	its pair was passed on the command line so a default never stops a command from running
+var flagDefaultConflicts = [][2]string{
+	{"sort", "tree"},
+	{"sort", "compact-tree"},
+	{"sort", "node-tree"},
+	{"context", "contexts"},
+	{"selector", "selector-file"},
+	{"split-output-dir", "output-file"},
+	{"count-only", "output-file"},
+	{"count-only", "template-file"},
+	{"count-only", "split-output-dir"},
+	{"compact", "json-pretty"},
+	{"phase", "include-terminated-pods"},
+	{"phase", "completed"},
+	{"phase", "hide-completed"},
+	{"include-terminated-pods", "completed"},
+	{"include-terminated-pods", "hide-completed"},
+	{"completed", "hide-completed"},
+	{"follow", "wait-ready"},
+	{"only-ephemeral", "init-problems"},
+}
+
+// flagDefault is a default value for a flag along with where it was read from, used in the error messages
+type flagDefault struct {
+	value  string
+	source string
+}
+
+// addFlagDefaults wraps the commands RunE so the defaults from the config file and KUBECTL_ICE_FLAGS are set on the
+//
+//	flags that werent passed on the command line, before any of the flags are read
+func addFlagDefaults(cmdObj *cobra.Command) {
+	run := cmdObj.RunE
+
+	cmdObj.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyFlagDefaults(cmd); err != nil {
+			return err
+		}
+		return run(cmd, args)
+	}
+}
+
+// applyFlagDefaults sets the default flags on cmd, KUBECTL_ICE_FLAGS overrides the config file and the command line
+//
+//	overrides both. flags the command doesnt have are ignored so the same defaults can be shared by every command
+func applyFlagDefaults(cmd *cobra.Command) error {
+	log := logger{location: "applyFlagDefaults"}
+	log.Debug("Start")
+
+	defaults, err := readFlagDefaultsFile(cmd.Name())
+	if err != nil {
+		return err
+	}
+
+	envDefaults, err := parseFlagDefaultsEnv(cmd, os.Getenv(flagDefaultsEnv))
+	if err != nil {
+		return err
+	}
+	for name, value := range envDefaults {
+		defaults[name] = value
+	}
+
+	// sorted so the first bad default is always the one reported
+	names := []string{}
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// checked before any defaults are set as the defaults are also marked as changed
+	passed := make(map[string]bool)
+	for _, pair := range flagDefaultConflicts {
+		for _, name := range pair {
+			if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+				passed[name] = true
+			}
+		}
+	}
+
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			log.Debug("skipping default for unknown flag", name)
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		if other := conflictingFlag(name, passed); len(other) > 0 {
+			log.Debug("skipping default for", name, "as it can not be used with", other)
+			continue
+		}
+		if err := cmd.Flags().Set(name, defaults[name].value); err != nil {
+			return fmt.Errorf("invalid default for --%s in %s: %w", name, defaults[name].source, err)
+		}
+	}
+
+	return nil
+}
+
+// conflictingFlag returns the name of a flag in passed that can not be used with name, or an empty string when there
+//
+//	isnt one
+func conflictingFlag(name string, passed map[string]bool) string {
+	for _, pair := range flagDefaultConflicts {
+		if pair[0] == name && passed[pair[1]] {
+			return pair[1]
+		}
+		if pair[1] == name && passed[pair[0]] {
+			return pair[0]
+		}
+	}
+	return ""
+}
+
+// flagDefaultsFile returns the path of the config file, $HOME/.config/kubectl-ice/config.yaml unless
+//
+//	KUBECTL_ICE_CONFIG is set
+func flagDefaultsFile() string {
+	if path := os.Getenv(flagDefaultsFileEnv); len(path) > 0 {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "kubectl-ice", "config.yaml")
+}
+
+// readFlagDefaultsFile reads the flag defaults from the config file, the top level keys are flag names used by every
+//
+//	command and a key named after a command holds the flags only used by that command, which win over the top level.
+//	a missing file is not an error
+func readFlagDefaultsFile(commandName string) (map[string]flagDefault, error) {
+	defaults := make(map[string]flagDefault)
+
+	path := flagDefaultsFile()
+	if len(path) == 0 {
+		return defaults, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return defaults, nil
+		}
+		return defaults, fmt.Errorf("unable to read config file: %w", err)
+	}
+
+	config := make(map[string]interface{})
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return defaults, fmt.Errorf("unable to read config file %s: %w", path, err)
+	}
+
+	for name, value := range config {
+		if _, ok := value.(map[string]interface{}); ok {
+			continue
+		}
+		defaults[name] = flagDefault{value: flagDefaultValue(value), source: path}
+	}
+
+	if section, ok := config[commandName].(map[string]interface{}); ok {
+		for name, value := range section {
+			defaults[name] = flagDefault{value: flagDefaultValue(value), source: path}
+		}
+	}
+
+	return defaults, nil
+}
+
+// flagDefaultValue returns the yaml value as the text that would be passed on the command line, lists are joined
+//
+//	with a comma
+func flagDefaultValue(value interface{}) string {
+	if list, ok := value.([]interface{}); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// parseFlagDefaultsEnv splits the KUBECTL_ICE_FLAGS text into flag values, flags can be written as --name=value,
+//
+//	--name value or the shorthand -n value. boolean flags dont need a value. single and double quotes can be used
+//	around values that hold spaces
+func parseFlagDefaultsEnv(cmd *cobra.Command, text string) (map[string]flagDefault, error) {
+	defaults := make(map[string]flagDefault)
+
+	words, err := splitFlagWords(text)
+	if err != nil {
+		return defaults, fmt.Errorf("unable to read %s: %w", flagDefaultsEnv, err)
+	}
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if !strings.HasPrefix(word, "-") {
+			return defaults, fmt.Errorf("unable to read %s: expected a flag but found %q", flagDefaultsEnv, word)
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(word, "-"), "=")
+		if !strings.HasPrefix(word, "--") {
+			// shorthand flags are stored under their full name so they can be matched against the command line
+			if flag := cmd.Flags().ShorthandLookup(name); flag != nil {
+				name = flag.Name
+			}
+		}
+
+		flag := cmd.Flags().Lookup(name)
+		if !hasValue {
+			switch {
+			case flag != nil && flag.NoOptDefVal != "":
+				value = flag.NoOptDefVal
+			case i+1 < len(words) && !strings.HasPrefix(words[i+1], "-"):
+				i++
+				value = words[i]
+			case flag == nil:
+				// unknown to this command, it wont be used so the value doesnt matter
+				value = ""
+			default:
+				return defaults, fmt.Errorf("unable to read %s: flag --%s needs a value", flagDefaultsEnv, name)
+			}
+		}
+
+		defaults[name] = flagDefault{value: value, source: flagDefaultsEnv}
+	}
+
+	return defaults, nil
+}
+
+// splitFlagWords splits text on spaces, leaving the spaces inside single or double quotes and removing the quotes
+func splitFlagWords(text string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	var quote rune
+	inWord := false
+
+	for _, char := range text {
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			} else {
+				word.WriteRune(char)
+			}
+		case char == '\'' || char == '"':
+			quote = char
+			inWord = true
+		case char == ' ' || char == '\t' || char == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(char)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return words, errors.New("missing closing quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
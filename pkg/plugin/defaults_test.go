@@ -0,0 +1,230 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// *****************
+// applyFlagDefaults
+// *****************
+type applyFlagDefaultsTest struct {
+	args      []string
+	env       string
+	sort      string
+	showNode  string
+	container []string
+}
+
+var applyFlagDefaultsTests = []applyFlagDefaultsTest{
+	// the command section wins over the top level of the config file
+	{[]string{}, "", "!RESTARTS", "true", []string{"web", "proxy"}},
+	{[]string{"--sort", "PODNAME"}, "", "PODNAME", "true", []string{"web", "proxy"}},
+	{[]string{}, "--sort=CONTAINER --show-node=false", "CONTAINER", "false", []string{"web", "proxy"}},
+	{[]string{}, "-c 'db' --unknown value", "!RESTARTS", "true", []string{"db"}},
+	// the command line wins over the environment
+	{[]string{"-c", "api"}, "-c db --sort CONTAINER", "CONTAINER", "true", []string{"api"}},
+	{[]string{"--sort", "PODNAME"}, "-c db --sort CONTAINER", "PODNAME", "true", []string{"db"}},
+	// the command line wins over the config file
+	{[]string{"-c", "api"}, "", "!RESTARTS", "true", []string{"api"}},
+	{[]string{"-c", "api,db", "--sort", "PODNAME"}, "", "PODNAME", "true", []string{"api", "db"}},
+}
+
+func TestApplyFlagDefaults(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	content := "sort: PODNAME\nshow-node: true\ncontainer: [all]\nrestarts:\n  sort: '!RESTARTS'\n  container: [web, proxy]\n"
+	if err := os.WriteFile(config, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(flagDefaultsFileEnv, config)
+
+	for _, test := range applyFlagDefaultsTests {
+		t.Setenv(flagDefaultsEnv, test.env)
+
+		cmd := &cobra.Command{Use: "restarts"}
+		cmd.Flags().StringP("sort", "", "", "")
+		cmd.Flags().BoolP("show-node", "", false, "")
+		cmd.Flags().StringSliceP("container", "c", []string{}, "")
+		if err := cmd.ParseFlags(test.args); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := applyFlagDefaults(cmd); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		container, _ := cmd.Flags().GetStringSlice("container")
+		output := []interface{}{cmd.Flag("sort").Value.String(), cmd.Flag("show-node").Value.String(), container}
+		expected := []interface{}{test.sort, test.showNode, test.container}
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("Output %v not equal to expected %v (args %v env %q)", output, expected, test.args, test.env)
+		}
+	}
+
+}
+
+type readFlagDefaultsFileTest struct {
+	command  string
+	expected map[string]string
+}
+
+var readFlagDefaultsFileTests = []readFlagDefaultsFileTest{
+	{"restarts", map[string]string{"sort": "!RESTARTS", "show-node": "true", "container": "web,proxy"}},
+	{"status", map[string]string{"sort": "PODNAME", "show-node": "true", "container": "all"}},
+}
+
+func TestReadFlagDefaultsFile(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	content := "sort: PODNAME\nshow-node: true\ncontainer: [all]\nrestarts:\n  sort: '!RESTARTS'\n  container: [web, proxy]\n"
+	if err := os.WriteFile(config, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(flagDefaultsFileEnv, config)
+
+	for _, test := range readFlagDefaultsFileTests {
+		defaults, err := readFlagDefaultsFile(test.command)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		output := make(map[string]string)
+		for name, value := range defaults {
+			output[name] = value.value
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v for %s", output, test.expected, test.command)
+		}
+	}
+
+	t.Setenv(flagDefaultsFileEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+	if defaults, err := readFlagDefaultsFile("status"); err != nil || len(defaults) != 0 {
+		t.Errorf("Output %v %v not equal to expected empty defaults for a missing file", defaults, err)
+	}
+
+}
+
+type flagDefaultConflictsTest struct {
+	args    []string
+	env     string
+	sort    string
+	context string
+}
+
+var flagDefaultConflictsTests = []flagDefaultConflictsTest{
+	{[]string{}, "", "PODNAME", "prod"},
+	// defaults that cant be used with a flag from the command line are skipped
+	{[]string{"-t"}, "", "", "prod"},
+	{[]string{"-t"}, "--sort CONTAINER", "", "prod"},
+	{[]string{"--contexts", "dev,test"}, "", "PODNAME", ""},
+	{[]string{"--contexts", "dev,test"}, "--context staging", "PODNAME", ""},
+}
+
+func TestApplyFlagDefaultsConflicts(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(config, []byte("sort: PODNAME\ncontext: prod\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(flagDefaultsFileEnv, config)
+
+	for _, test := range flagDefaultConflictsTests {
+		t.Setenv(flagDefaultsEnv, test.env)
+
+		cmd := &cobra.Command{Use: "status"}
+		cmd.Flags().StringP("sort", "", "", "")
+		cmd.Flags().BoolP("tree", "t", false, "")
+		cmd.Flags().StringP("context", "", "", "")
+		cmd.Flags().StringSliceP("contexts", "", []string{}, "")
+		if err := cmd.ParseFlags(test.args); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := applyFlagDefaults(cmd); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		output := []string{cmd.Flag("sort").Value.String(), cmd.Flag("context").Value.String()}
+		expected := []string{test.sort, test.context}
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("Output %v not equal to expected %v (args %v env %q)", output, expected, test.args, test.env)
+		}
+	}
+
+	// the status flags that are checked against each other when the command runs
+	config = filepath.Join(t.TempDir(), "status.yaml")
+	if err := os.WriteFile(config, []byte("phase: Running\nhide-completed: true\nfollow: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(flagDefaultsFileEnv, config)
+	t.Setenv(flagDefaultsEnv, "")
+
+	for _, test := range statusDefaultConflictsTests {
+		cmd := &cobra.Command{Use: "status"}
+		cmd.Flags().StringSliceP("phase", "", []string{}, "")
+		cmd.Flags().BoolP("completed", "", false, "")
+		cmd.Flags().BoolP("hide-completed", "", false, "")
+		cmd.Flags().BoolP("follow", "", false, "")
+		cmd.Flags().BoolP("wait-ready", "", false, "")
+		if err := cmd.ParseFlags(test.args); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := applyFlagDefaults(cmd); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		output := []string{cmd.Flag("phase").Value.String(), cmd.Flag("hide-completed").Value.String(), cmd.Flag("follow").Value.String()}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v (args %v)", output, test.expected, test.args)
+		}
+	}
+
+}
+
+type statusDefaultConflictsTest struct {
+	args     []string
+	expected []string
+}
+
+var statusDefaultConflictsTests = []statusDefaultConflictsTest{
+	{[]string{}, []string{"[Running]", "true", "true"}},
+	{[]string{"--completed"}, []string{"[]", "false", "true"}},
+	{[]string{"--hide-completed=false"}, []string{"[]", "false", "true"}},
+	{[]string{"--wait-ready"}, []string{"[Running]", "true", "false"}},
+}
+
+// *****************
+// splitFlagWords
+// *****************
+type splitFlagWordsTest struct {
+	text     string
+	expected []string
+}
+
+var splitFlagWordsTests = []splitFlagWordsTest{
+	{"", nil},
+	{"--sort PODNAME  -A", []string{"--sort", "PODNAME", "-A"}},
+	{"--sort '!RESTARTS' --match \"READY==false\"", []string{"--sort", "!RESTARTS", "--match", "READY==false"}},
+	{"-m 'STATE==Waiting, REASON!=x'", []string{"-m", "STATE==Waiting, REASON!=x"}},
+}
+
+func TestSplitFlagWords(t *testing.T) {
+
+	for _, test := range splitFlagWordsTests {
+		output, err := splitFlagWords(test.text)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+	if _, err := splitFlagWords("--sort 'PODNAME"); err == nil {
+		t.Errorf("expected an error for the missing quote")
+	}
+
+}
@@ -10,7 +10,9 @@ var environmentShort = "List the env name and value for each container"
 
 var environmentDescription = ` Print the the environment variables used in running containers in a pod, single pods
 and containers can be selected by name. If no name is specified the environment details of all pods in
-the current namespace are shown.
+the current namespace are shown. Variables imported in bulk using envFrom are listed as the prefix added to
+the variable names followed by a *, use --details to show the type and name of the configmap or secret they
+come from and the prefix in their own columns.
 
 The T column in the table output denotes S for Standard and I for init containers`
 
@@ -20,6 +22,10 @@ var environmentExample = `  # List containers env info from pods
   # List container env info from pods output in JSON format
   %[1]s env -o json
 
+  # List container env info from pods along with the source type, source name and prefix of the variables
+  # imported with envFrom
+  %[1]s env --details
+
   # List container env info from a single pod
   %[1]s env my-pod-4jh36
 
@@ -72,6 +78,11 @@ func Environment(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, a
 		loopinfo.TranslateConfigMap = true
 	}
 
+	if cmd.Flag("details").Value.String() == "true" {
+		log.Debug("loopinfo.ShowDetails = true")
+		loopinfo.ShowDetails = true
+	}
+
 	table := Table{}
 	table.ColourOutput = commonFlagList.outputAsColour
 	table.CustomColours = commonFlagList.useTheseColours
@@ -86,19 +97,19 @@ func Environment(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, a
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	return outputTableAs(table, commonFlagList)
 
 }
 
 type environment struct {
 	Connection         *Connector
 	TranslateConfigMap bool
+	ShowDetails        bool // show the source type, source name and prefix of the envFrom imports in their own columns
 }
 
 func (s *environment) Headers() []string {
 	return []string{
-		"NAME", "VALUE",
+		"NAME", "VALUE", "SOURCE-TYPE", "SOURCE-NAME", "PREFIX",
 	}
 }
 
@@ -111,6 +122,9 @@ func (s *environment) BuildEphemeralContainerStatus(container v1.ContainerStatus
 }
 
 func (s *environment) HideColumns(info BuilderInformation) []int {
+	if !s.ShowDetails {
+		return []int{2, 3, 4}
+	}
 	return []int{}
 }
 
@@ -118,12 +132,18 @@ func (s *environment) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cel
 	out := []Cell{
 		NewCellText(""),
 		NewCellText(""),
+		NewCellText(""),
+		NewCellText(""),
+		NewCellText(""),
 	}
 	return out, nil
 }
 
 func (s *environment) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
 	out := [][]Cell{}
+	for _, envFrom := range container.EnvFrom {
+		out = append(out, s.envFromBuildRow(envFrom))
+	}
 	allRows := s.buildEnvFromContainer(container)
 	for _, envRow := range allRows {
 		out = append(out, s.envBuildRow(info, envRow, s.Connection, s.TranslateConfigMap))
@@ -133,6 +153,9 @@ func (s *environment) BuildContainerSpec(container v1.Container, info BuilderInf
 
 func (s *environment) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
 	out := [][]Cell{}
+	for _, envFrom := range container.EnvFrom {
+		out = append(out, s.envFromBuildRow(envFrom))
+	}
 	allRows := s.buildEnvFromEphemeral(container)
 	for _, envRow := range allRows {
 		out = append(out, s.envBuildRow(info, envRow, s.Connection, s.TranslateConfigMap))
@@ -185,6 +208,37 @@ func (s *environment) envBuildRow(info BuilderInformation, env v1.EnvVar, connec
 	return []Cell{
 		NewCellText(envKey),
 		NewCellText(envValue),
+		NewCellText(""),
+		NewCellText(""),
+		NewCellText(""),
+	}
+}
+
+// envFromBuildRow shows where a bulk import of variables comes from, only the source name is shown so theres nothing
+//
+//	to mask or translate. the NAME and VALUE columns hold the prefix and source in the same form as a single
+//	variable so the row still makes sense when the detail columns are hidden
+func (s *environment) envFromBuildRow(envFrom v1.EnvFromSource) []Cell {
+	var sourceType, sourceName, envValue string
+
+	if envFrom.ConfigMapRef != nil {
+		sourceType = "configMap"
+		sourceName = envFrom.ConfigMapRef.Name
+		envValue = "CONFIGMAP:" + sourceName
+	}
+
+	if envFrom.SecretRef != nil {
+		sourceType = "secret"
+		sourceName = envFrom.SecretRef.Name
+		envValue = "SECRETMAP:" + sourceName
+	}
+
+	return []Cell{
+		NewCellText(envFrom.Prefix + "*"),
+		NewCellText(envValue),
+		NewCellText(sourceType),
+		NewCellText(sourceName),
+		NewCellText(envFrom.Prefix),
 	}
 }
 
@@ -0,0 +1,72 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// *****************
+// envFromBuildRow
+// *****************
+type envFromBuildRowTest struct {
+	envFrom  v1.EnvFromSource
+	expected []string
+}
+
+var envFromBuildRowTests = []envFromBuildRowTest{
+	{
+		v1.EnvFromSource{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}}},
+		[]string{"*", "CONFIGMAP:app-config", "configMap", "app-config", ""},
+	},
+	{
+		v1.EnvFromSource{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "db-creds"}}},
+		[]string{"*", "SECRETMAP:db-creds", "secret", "db-creds", ""},
+	},
+	{
+		v1.EnvFromSource{Prefix: "DB_", SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "db-creds"}}},
+		[]string{"DB_*", "SECRETMAP:db-creds", "secret", "db-creds", "DB_"},
+	},
+}
+
+func TestEnvFromBuildRow(t *testing.T) {
+	s := environment{}
+
+	for _, test := range envFromBuildRowTests {
+		output := []string{}
+		for _, cell := range s.envFromBuildRow(test.envFrom) {
+			output = append(output, cell.text)
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// envFrom detail columns
+// *****************
+type environmentDetailsTest struct {
+	details  bool
+	expected []string
+}
+
+var environmentDetailsTests = []environmentDetailsTest{
+	{false, []string{"PODNAME", "CONTAINER", "NAME", "VALUE"}},
+	{true, []string{"PODNAME", "CONTAINER", "NAME", "VALUE", "SOURCE-TYPE", "SOURCE-NAME", "PREFIX"}},
+}
+
+func TestEnvironmentDetailsColumns(t *testing.T) {
+	pods := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web-1\n  namespace: default\nspec:\n  containers:\n  - name: web\n    envFrom:\n    - configMapRef:\n        name: app-config\n      prefix: APP_\n"
+
+	for _, test := range environmentDetailsTests {
+		loop := environment{ShowDetails: test.details}
+		tbl, _ := buildTestTable(t, RowBuilder{LoopSpec: true}, &loop, commonFlags{}, pods)
+		if output := visibleHeaders(tbl); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v (details %t)", output, test.expected, test.details)
+		}
+	}
+
+}
@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"errors"
+)
+
+// typed errors, used to work out the exit code so scripts can tell the different failures apart
+var (
+	ErrConfig             = errors.New("configuration error")
+	ErrNoPods             = errors.New("no pods found")
+	ErrNoMatch            = errors.New("no matching rows found")
+	ErrMetricsUnavailable = errors.New("metrics unavailable")
+	ErrLintFailed         = errors.New("lint checks failed")
+	ErrTimeout            = errors.New("timed out")
+)
+
+// exit codes returned by the process, 1 is used for all other errors
+const (
+	ExitOK                 = 0
+	ExitError              = 1
+	ExitConfig             = 2
+	ExitNoPods             = 3
+	ExitNoMatch            = 4
+	ExitMetricsUnavailable = 5
+	ExitLintFailed         = 6
+	ExitTimeout            = 7
+)
+
+// iceError keeps the original error message while allowing errors.Is to match against the error kind
+type iceError struct {
+	kind error
+	err  error
+}
+
+func (e iceError) Error() string {
+	return e.err.Error()
+}
+
+func (e iceError) Unwrap() error {
+	return e.err
+}
+
+func (e iceError) Is(target error) bool {
+	return target == e.kind
+}
+
+// newIceError marks err as being of the passed kind, the message shown to the user is unchanged
+func newIceError(kind error, err error) error {
+	if err == nil {
+		return nil
+	}
+	return iceError{kind: kind, err: err}
+}
+
+// ExitCode returns the process exit code that matches the passed error
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrConfig):
+		return ExitConfig
+	case errors.Is(err, ErrNoPods):
+		return ExitNoPods
+	case errors.Is(err, ErrNoMatch):
+		return ExitNoMatch
+	case errors.Is(err, ErrMetricsUnavailable):
+		return ExitMetricsUnavailable
+	case errors.Is(err, ErrLintFailed):
+		return ExitLintFailed
+	case errors.Is(err, ErrTimeout):
+		return ExitTimeout
+	}
+
+	return ExitError
+}
@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// *****************
+// ExitCode
+// *****************
+type exitCodeTest struct {
+	arg1     error
+	expected int
+}
+
+var exitCodeTests = []exitCodeTest{
+	{nil, ExitOK},
+	{errors.New("some error"), ExitError},
+	{ErrNoMatch, ExitNoMatch},
+	{newIceError(ErrConfig, errors.New("failed to read kubeconfig")), ExitConfig},
+	{newIceError(ErrNoPods, errors.New("no pods found in default namespace")), ExitNoPods},
+	{newIceError(ErrMetricsUnavailable, errors.New("no metric info found")), ExitMetricsUnavailable},
+	{newIceError(ErrLintFailed, errors.New("probe lint failed")), ExitLintFailed},
+	{newIceError(ErrTimeout, errors.New("timed out after 2m0s")), ExitTimeout},
+	{fmt.Errorf("wrapped: %w", newIceError(ErrNoPods, errors.New("no pods"))), ExitNoPods},
+}
+
+func TestExitCode(t *testing.T) {
+
+	for _, test := range exitCodeTests {
+		if output := ExitCode(test.arg1); output != test.expected {
+			t.Errorf("Output %d not equal to expected %d", output, test.expected)
+		}
+	}
+
+}
+
+func TestIceErrorMessage(t *testing.T) {
+	err := newIceError(ErrNoPods, errors.New("no pods found in default namespace"))
+	if err.Error() != "no pods found in default namespace" {
+		t.Errorf("Output %s not equal to expected %s", err.Error(), "no pods found in default namespace")
+	}
+}
+
+// *****************
+// outputTableAs
+// *****************
+func TestOutputTableAsEmpty(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "pods.yaml")
+	content := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web-1\n  namespace: default\nspec:\n  containers:\n  - name: web\n"
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// pods read from a file have no owners so the tree view only has the header line
+	treeTable := Table{}
+	builder := RowBuilder{LoopStatus: true, Connection: &Connector{}, Table: &treeTable}
+	builder.SetFlagsFrom(commonFlags{inputFilename: filename, showTreeView: true})
+	if err := builder.Build(&status{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// status --diff of two identical pods has no rows to print
+	diffTable := Table{}
+	diffTable.SetHeader("CONTAINER", "COLUMN", "web-1", "web-2")
+
+	for name, tbl := range map[string]Table{"tree": treeTable, "diff": diffTable} {
+		flags := commonFlags{outputAs: "csv", noHeaders: true, quiet: true}
+		if err := outputTableAs(tbl, flags); err != nil {
+			t.Errorf("unexpected error %v for the %s table", err, name)
+		}
+
+		flags.errorOnEmpty = true
+		if err := outputTableAs(tbl, flags); !errors.Is(err, ErrNoMatch) {
+			t.Errorf("Error %v not equal to expected %v for the %s table with errorOnEmpty", err, ErrNoMatch, name)
+		}
+	}
+
+}
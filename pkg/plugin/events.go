@@ -0,0 +1,281 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	duration "k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var eventsShort = "List the recent events of each pod along with the container they relate to"
+
+var eventsDescription = ` Prints the events recorded against each pod, oldest first, in the same way as the events
+section of kubectl describe but for every selected pod in a single table. Events that relate to a single
+container (eg a failed image pull or a failing probe) show the container name. Events are only kept by
+the cluster for a short time, usually one hour, so older problems may no longer be listed. If no name
+is specified the events of all pods in the current namespace are shown.`
+
+var eventsExample = `  # List events from pods
+  %[1]s events
+
+  # List events from pods output in JSON format
+  %[1]s events -o json
+
+  # List events from a single pod
+  %[1]s events my-pod-4jh36
+
+  # List only the warning events from all pods in all namespaces
+  %[1]s events -A --only-warnings
+
+  # List the warning events from the last 15 minutes, to see what just went wrong
+  %[1]s events --only-warnings --since 15m
+
+  # List events from all pods where label app matches web
+  %[1]s events -l app=web
+
+  # List events from all pods where the pod label app is either web or mail
+  %[1]s events -l "app in (web,mail)"`
+
+func Events(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []string) error {
+	log := logger{location: "Events"}
+	log.Debug("Start")
+
+	loopinfo := events{}
+	builder := RowBuilder{}
+	builder.DontListContainers = true
+	builder.PodName = args
+
+	connect := Connector{}
+	if err := connect.LoadConfig(kubeFlags); err != nil {
+		return err
+	}
+
+	commonFlagList, err := processCommonFlags(cmd)
+	if err != nil {
+		return err
+	}
+	connect.Flags = commonFlagList
+	builder.Connection = &connect
+	builder.SetFlagsFrom(commonFlagList)
+
+	stdinChanged, err := builder.HasStdinChanged()
+	if err != nil {
+		return err
+	}
+	if len(commonFlagList.inputFilename) > 0 || stdinChanged {
+		return errors.New("events are only kept by the cluster, they can not be read from a file or stdin")
+	}
+
+	// we need the connection to look up the events of each pod
+	loopinfo.Connection = &connect
+
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		return err
+	}
+	if since < 0 {
+		return errors.New("--since must be a positive duration")
+	}
+	loopinfo.Since = since
+
+	if cmd.Flag("only-warnings").Value.String() == "true" {
+		log.Debug("loopinfo.OnlyWarnings = true")
+		loopinfo.OnlyWarnings = true
+	}
+
+	table := Table{}
+	table.ColourOutput = commonFlagList.outputAsColour
+	table.CustomColours = commonFlagList.useTheseColours
+
+	builder.Table = &table
+	builder.ShowTreeView = commonFlagList.showTreeView
+
+	if err := builder.Build(&loopinfo); err != nil {
+		return err
+	}
+
+	if err := table.SortByNames(commonFlagList.sortList...); err != nil {
+		return err
+	}
+
+	return outputTableAs(table, commonFlagList)
+
+}
+
+type events struct {
+	Connection   *Connector
+	OnlyWarnings bool          // only show events with the type Warning
+	Since        time.Duration // only show events last seen within this long ago, zero shows every event
+}
+
+func (s *events) Headers() []string {
+	return []string{
+		"CONTAINER", "TYPE", "REASON", "MESSAGE", "COUNT", "LAST-SEEN",
+	}
+}
+
+func (s *events) BuildContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *events) BuildEphemeralContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *events) HideColumns(info BuilderInformation) []int {
+	return []int{}
+}
+
+func (s *events) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
+	var count int64
+
+	for _, r := range rows {
+		count += r[4].number
+	}
+
+	out := []Cell{
+		NewCellText(""),
+		NewCellText(""),
+		NewCellText(""),
+		NewCellText(""),
+		NewCellInt(fmt.Sprintf("%d", count), count),
+		NewCellText(""),
+	}
+	return out, nil
+}
+
+func (s *events) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *events) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *events) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
+	out := [][]Cell{}
+
+	eventList, err := s.Connection.GetEvents(pod.Namespace)
+	if err != nil {
+		return [][]Cell{}, err
+	}
+
+	var cutoff time.Time
+	if s.Since > 0 {
+		cutoff = time.Now().Add(-s.Since)
+	}
+
+	podEvents := []v1.Event{}
+	for _, event := range eventList {
+		if event.InvolvedObject.Kind != "Pod" || event.InvolvedObject.Name != pod.Name {
+			continue
+		}
+		// a pod that was deleted and recreated with the same name keeps the old events until they expire
+		if len(event.InvolvedObject.UID) > 0 && len(pod.UID) > 0 && event.InvolvedObject.UID != pod.UID {
+			continue
+		}
+		if s.OnlyWarnings && event.Type != v1.EventTypeWarning {
+			continue
+		}
+		if !cutoff.IsZero() && eventLastSeen(event).Before(cutoff) {
+			continue
+		}
+		podEvents = append(podEvents, event)
+	}
+
+	sort.SliceStable(podEvents, func(i, j int) bool {
+		return eventLastSeen(podEvents[i]).Before(eventLastSeen(podEvents[j]))
+	})
+
+	for _, event := range podEvents {
+		out = append(out, s.eventsBuildRow(event))
+	}
+
+	return out, nil
+}
+
+func (s *events) eventsBuildRow(event v1.Event) []Cell {
+	typeColour := colourOk
+	if event.Type == v1.EventTypeWarning {
+		typeColour = colourWarn
+	}
+
+	count := int64(event.Count)
+	if event.Series != nil {
+		count = int64(event.Series.Count)
+	}
+	if count == 0 {
+		count = 1
+	}
+
+	lastSeen := ""
+	var rawLastSeen int64
+	if seen := eventLastSeen(event); !seen.IsZero() {
+		age := time.Since(seen)
+		lastSeen = duration.HumanDuration(age)
+		rawLastSeen = int64(age.Seconds())
+	}
+
+	return []Cell{
+		NewCellText(eventContainerName(event.InvolvedObject.FieldPath)),
+		NewCellColourText(typeColour, event.Type),
+		NewCellText(event.Reason),
+		NewCellText(strings.TrimSpace(event.Message)),
+		NewCellInt(fmt.Sprintf("%d", count), count),
+		NewCellInt(lastSeen, rawLastSeen),
+	}
+}
+
+// containerEvents returns the events from eventList that are about the named container of pod
+func containerEvents(eventList []v1.Event, pod v1.Pod, containerName string) []v1.Event {
+	var out []v1.Event
+
+	for _, event := range eventList {
+		if event.InvolvedObject.Kind != "Pod" || event.InvolvedObject.Name != pod.Name {
+			continue
+		}
+		// a pod that was deleted and recreated with the same name keeps the old events until they expire
+		if len(event.InvolvedObject.UID) > 0 && len(pod.UID) > 0 && event.InvolvedObject.UID != pod.UID {
+			continue
+		}
+		if eventContainerName(event.InvolvedObject.FieldPath) != containerName {
+			continue
+		}
+		out = append(out, event)
+	}
+
+	return out
+}
+
+// eventLastSeen returns the last time the event happened, newer events only set EventTime or the series time
+func eventLastSeen(event v1.Event) time.Time {
+	if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
+		return event.Series.LastObservedTime.Time
+	}
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
+
+// eventContainerName returns the container name from an events field path, eg spec.containers{web} returns web. events
+//
+//	about the whole pod have no field path and return an empty string
+func eventContainerName(fieldPath string) string {
+	start := strings.Index(fieldPath, "{")
+	end := strings.Index(fieldPath, "}")
+	if start == -1 || end <= start {
+		return ""
+	}
+
+	return fieldPath[start+1 : end]
+}
@@ -0,0 +1,98 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// *****************
+// eventContainerName
+// *****************
+type eventContainerNameTest struct {
+	fieldPath string
+	expected  string
+}
+
+var eventContainerNameTests = []eventContainerNameTest{
+	{"spec.containers{web}", "web"},
+	{"spec.initContainers{init-db}", "init-db"},
+	{"spec.ephemeralContainers{debugger-x7k2}", "debugger-x7k2"},
+	{"", ""},
+	{"spec.containers", ""},
+}
+
+func TestEventContainerName(t *testing.T) {
+
+	for _, test := range eventContainerNameTests {
+		if output := eventContainerName(test.fieldPath); output != test.expected {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// BuildPodRow
+// *****************
+type eventsBuildPodRowTest struct {
+	onlyWarnings bool
+	since        time.Duration
+	expected     []string
+}
+
+var eventsBuildPodRowTests = []eventsBuildPodRowTest{
+	// oldest first, events from other pods and the old pod with the same name are left out
+	{false, 0, []string{"Scheduled", "Pulled", "BackOff"}},
+	{true, 0, []string{"BackOff"}},
+	{false, 45 * time.Minute, []string{"Pulled", "BackOff"}},
+	{true, 45 * time.Minute, []string{"BackOff"}},
+	{false, 10 * time.Second, nil},
+}
+
+func TestEventsBuildPodRow(t *testing.T) {
+	now := time.Now()
+	event := func(podName string, uid string, eventType string, reason string, age time.Duration) v1.Event {
+		return v1.Event{
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: podName, UID: types.UID(uid), FieldPath: "spec.containers{web}"},
+			Type:           eventType,
+			Reason:         reason,
+			LastTimestamp:  metav1.NewTime(now.Add(-age)),
+		}
+	}
+
+	connect := Connector{eventList: map[string][]v1.Event{
+		"default": {
+			event("web-1", "uid-1", v1.EventTypeWarning, "BackOff", time.Minute),
+			event("web-1", "uid-1", v1.EventTypeNormal, "Scheduled", time.Hour),
+			event("web-2", "uid-2", v1.EventTypeWarning, "Failed", time.Minute),
+			event("web-1", "uid-old", v1.EventTypeWarning, "Killing", 2*time.Hour),
+			event("web-1", "uid-1", v1.EventTypeNormal, "Pulled", 30*time.Minute),
+		},
+	}}
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "uid-1"}}
+
+	for _, test := range eventsBuildPodRowTests {
+		s := events{Connection: &connect, OnlyWarnings: test.onlyWarnings, Since: test.since}
+		rows, err := s.BuildPodRow(pod, BuilderInformation{})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		var reasons []string
+		for _, row := range rows {
+			reasons = append(reasons, row[2].text)
+			if row[0].text != "web" {
+				t.Errorf("Output %q not equal to expected container web", row[0].text)
+			}
+		}
+		if !reflect.DeepEqual(reasons, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", reasons, test.expected)
+		}
+	}
+
+}
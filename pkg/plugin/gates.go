@@ -0,0 +1,155 @@
+package plugin
+
+import (
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var gatesShort = "List the readiness gates of each pod along with their current status"
+
+var gatesDescription = ` Prints the readiness gates configured on each pod along with the current status of the matching
+pod condition. Gates are usually set by service meshes and custom controllers, a gate that has no
+matching condition is shown as False as the pod can not become ready until the condition is set.
+If no name is specified the readiness gates of all pods in the current namespace are shown.`
+
+var gatesExample = `  # List readiness gates from pods
+  %[1]s gates
+
+  # List readiness gates from pods output in JSON format
+  %[1]s gates -o json
+
+  # List readiness gates from a single pod
+  %[1]s gates my-pod-4jh36
+
+  # List only the readiness gates that are stopping pods from becoming ready
+  %[1]s gates --not-ready
+
+  # List readiness gates from all pods where label app matches web
+  %[1]s gates -l app=web
+
+  # List readiness gates from all pods where the pod label app is either web or mail
+  %[1]s gates -l "app in (web,mail)"`
+
+func Gates(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []string) error {
+	log := logger{location: "Gates"}
+	log.Debug("Start")
+
+	loopinfo := gates{}
+	builder := RowBuilder{}
+	builder.DontListContainers = true
+	builder.PodName = args
+
+	connect := Connector{}
+	if err := connect.LoadConfig(kubeFlags); err != nil {
+		return err
+	}
+
+	commonFlagList, err := processCommonFlags(cmd)
+	if err != nil {
+		return err
+	}
+	connect.Flags = commonFlagList
+	builder.Connection = &connect
+	builder.SetFlagsFrom(commonFlagList)
+
+	if cmd.Flag("not-ready").Value.String() == "true" {
+		log.Debug("loopinfo.NotReadyOnly = true")
+		loopinfo.NotReadyOnly = true
+	}
+
+	table := Table{}
+	table.ColourOutput = commonFlagList.outputAsColour
+	table.CustomColours = commonFlagList.useTheseColours
+
+	builder.Table = &table
+	builder.ShowTreeView = commonFlagList.showTreeView
+
+	if err := builder.Build(&loopinfo); err != nil {
+		return err
+	}
+
+	if err := table.SortByNames(commonFlagList.sortList...); err != nil {
+		return err
+	}
+
+	return outputTableAs(table, commonFlagList)
+
+}
+
+type gates struct {
+	NotReadyOnly bool // only show the gates that are not reporting True
+}
+
+func (s *gates) Headers() []string {
+	return []string{
+		"GATE", "STATUS",
+	}
+}
+
+func (s *gates) BuildContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *gates) BuildEphemeralContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *gates) HideColumns(info BuilderInformation) []int {
+	return []int{}
+}
+
+func (s *gates) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
+	out := []Cell{
+		NewCellText(""),
+		NewCellText(""),
+	}
+	return out, nil
+}
+
+func (s *gates) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *gates) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *gates) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
+	out := [][]Cell{}
+
+	for _, gate := range pod.Spec.ReadinessGates {
+		// a gate without a matching condition has not been set by its controller yet, so
+		//  as far as the pod is concerned its not ready
+		status := v1.ConditionFalse
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == gate.ConditionType {
+				status = condition.Status
+				break
+			}
+		}
+
+		if s.NotReadyOnly && status == v1.ConditionTrue {
+			continue
+		}
+
+		out = append(out, s.gatesBuildRow(string(gate.ConditionType), status))
+	}
+
+	return out, nil
+}
+
+func (s *gates) gatesBuildRow(gateName string, status v1.ConditionStatus) []Cell {
+	colour := colourWarn
+	switch status {
+	case v1.ConditionTrue:
+		colour = colourOk
+	case v1.ConditionFalse:
+		colour = colourBad
+	}
+
+	return []Cell{
+		NewCellText(gateName),
+		NewCellColourText(colour, string(status)),
+	}
+}
@@ -0,0 +1,54 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// *****************
+// gates
+// *****************
+type gatesBuildPodRowTest struct {
+	notReadyOnly bool
+	expected     [][]string
+}
+
+var gatesBuildPodRowTests = []gatesBuildPodRowTest{
+	{false, [][]string{{"mesh/ready", "True"}, {"lb/registered", "False"}, {"custom/synced", "False"}}},
+	// the gate without a condition hasnt been set by its controller yet so it is listed as not ready
+	{true, [][]string{{"lb/registered", "False"}, {"custom/synced", "False"}}},
+}
+
+func TestGatesBuildPodRow(t *testing.T) {
+	pod := v1.Pod{
+		Spec: v1.PodSpec{ReadinessGates: []v1.PodReadinessGate{
+			{ConditionType: "mesh/ready"},
+			{ConditionType: "lb/registered"},
+			{ConditionType: "custom/synced"},
+		}},
+		Status: v1.PodStatus{Conditions: []v1.PodCondition{
+			{Type: v1.PodReady, Status: v1.ConditionFalse},
+			{Type: "mesh/ready", Status: v1.ConditionTrue},
+			{Type: "lb/registered", Status: v1.ConditionFalse},
+		}},
+	}
+
+	for _, test := range gatesBuildPodRowTests {
+		loop := gates{NotReadyOnly: test.notReadyOnly}
+		rows, err := loop.BuildPodRow(pod, BuilderInformation{})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		output := [][]string{}
+		for _, row := range rows {
+			output = append(output, []string{row[0].text, row[1].text})
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v (not-ready %t)", output, test.expected, test.notReadyOnly)
+		}
+	}
+
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -14,6 +15,11 @@ var imageDescription = ` Print the the image used for running containers in a po
 and containers can be selected by name. If no name is specified the image details of all pods in
 the current namespace are shown.
 
+The --image-drift flag compares the image each container is running (the IMAGEID) across the pods of the same
+workload, a container running a different build to most of the other pods is marked in the DRIFT column. This
+is usually a rollout that only got part way or a tag like latest that was pushed again while the pods were
+starting. When the pods are split evenly there is no majority so every build is marked.
+
 The T column in the table output denotes S for Standard and I for init containers`
 
 var imageExample = `  # List containers image info from pods
@@ -22,9 +28,21 @@ var imageExample = `  # List containers image info from pods
   # List container image info from pods output in JSON format
   %[1]s image -o json
 
+  # List container image info along with the size of each image as reported by the node
+  %[1]s image --image-size
+
   # List container image info from a single pod
   %[1]s image my-pod-4jh36
 
+  # List only the containers running a different build to the rest of their workload
+  %[1]s image --image-drift -m 'DRIFT==true'
+
+  # List the containers still running the old tag of the nginx image during a rollout
+  %[1]s image --image-filter nginx --image-tag 1.21
+
+  # List the containers with an image tag from the 1.2x releases
+  %[1]s image --image-tag '^1\.2[0-9]' --image-regex
+
   # List image info for all containers named web-container searching all 
   # pods in the current namespace
   %[1]s image -c web-container
@@ -70,6 +88,17 @@ func Image(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []
 		loopinfo.ShowID = true
 	}
 
+	if cmd.Flag("image-drift").Value.String() == "true" {
+		log.Debug("loopinfo.ShowDrift = true")
+		loopinfo.ShowDrift = true
+	}
+
+	if cmd.Flag("image-size").Value.String() == "true" {
+		log.Debug("loopinfo.ShowSize = true")
+		loopinfo.ShowSize = true
+		loopinfo.connect = &connect
+	}
+
 	table := Table{}
 	table.ColourOutput = commonFlagList.outputAsColour
 	table.CustomColours = commonFlagList.useTheseColours
@@ -86,18 +115,31 @@ func Image(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	return outputTableAs(table, commonFlagList)
 
 }
 
 type image struct {
-	ShowID bool
+	ShowID    bool
+	ShowSize  bool // show the size of the image as reported by the node the pod is running on
+	ShowDrift bool // mark the containers running a different image to the rest of their workload
+
+	connect    *Connector                  // used to look up the nodes when ShowSize is set
+	nodeImages map[string]map[string]int64 // image sizes keyed by node name then image name or digest
+	nodeWarned bool                        // set once a node couldnt be read so the warning is only printed once
+	drift      map[string]bool             // worked out for every container by SetPodList, keyed by namespace/podname/container
+}
+
+// SetPodList compares the image of each container with the same container in the rest of its workload
+func (s *image) SetPodList(podList []v1.Pod) {
+	if s.ShowDrift {
+		s.drift = imageDrift(podList)
+	}
 }
 
 func (s *image) Headers() []string {
 	return []string{
-		"PULL", "IMAGEID", "CONTAINERID", "IMAGE", "TAG",
+		"PULL", "IMAGEID", "CONTAINERID", "IMAGE", "TAG", "SIZE", "DRIFT",
 	}
 }
 
@@ -113,7 +155,20 @@ func (s *image) HideColumns(info BuilderInformation) []int {
 	var hideColumns []int
 
 	if !s.ShowID {
-		hideColumns = append(hideColumns, 1, 2)
+		// the drift is worked out from the image id so its shown along with the DRIFT column
+		if s.ShowDrift {
+			hideColumns = append(hideColumns, 2)
+		} else {
+			hideColumns = append(hideColumns, 1, 2)
+		}
+	}
+
+	if !s.ShowSize {
+		hideColumns = append(hideColumns, 5)
+	}
+
+	if !s.ShowDrift {
+		hideColumns = append(hideColumns, 6)
 	}
 
 	return hideColumns
@@ -126,47 +181,26 @@ func (s *image) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, err
 }
 
 func (s *image) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
+	var err error
 	out := make([][]Cell, 1)
-	out[0] = s.imageBuildRow(info, container.Image, string(container.ImagePullPolicy))
-	return out, nil
+	out[0], err = s.imageBuildRow(info, container.Image, string(container.ImagePullPolicy))
+	return out, err
 }
 
 func (s *image) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
+	var err error
 	out := make([][]Cell, 1)
-	out[0] = s.imageBuildRow(info, container.Image, string(container.ImagePullPolicy))
-	return out, nil
+	out[0], err = s.imageBuildRow(info, container.Image, string(container.ImagePullPolicy))
+	return out, err
 }
 
-func (s *image) imageBuildRow(info BuilderInformation, imageName string, pullPolicy string) []Cell {
+func (s *image) imageBuildRow(info BuilderInformation, imageName string, pullPolicy string) ([]Cell, error) {
 	var imageID string
 	var containerID string
 	var cellList []Cell
+	var sizeCell Cell
 
-	name := imageName
-	tag := ""
-
-	if strings.Contains(imageName, "/") {
-		arrPath := strings.Split(imageName, "/")
-		if c := len(arrPath); c > 0 {
-			tmp := strings.Split(arrPath[c-1], ":")
-			if len(tmp) > 0 {
-				tag = strings.Join(tmp[1:], ":")
-				//calculate the uri length
-				namelen := len(imageName) - len(tag)
-				if len(tag) > 0 {
-					// check a tag was supplied so we dont cut off the last char of the image name
-					namelen--
-				}
-				name = imageName[0:namelen]
-			}
-		}
-	} else {
-		arrImage := strings.Split(imageName, ":")
-		if c := len(arrImage); c > 0 {
-			tag = arrImage[c-1]
-			name = strings.Join(arrImage[:c-1], ":")
-		}
-	}
+	name, tag := splitImageName(imageName)
 
 	for _, status := range info.Data.pod.Status.InitContainerStatuses {
 		if status.Image == imageName {
@@ -187,19 +221,163 @@ func (s *image) imageBuildRow(info BuilderInformation, imageName string, pullPol
 		}
 	}
 
+	if s.ShowSize {
+		size := s.imageSize(info.Data.pod.Spec.NodeName, imageID, imageName)
+		if size > 0 {
+			sizeCell = NewCellInt(memoryHumanReadable(size, "MB"), size)
+		} else {
+			// the node doesnt report the size of every image
+			sizeCell = NewCellInt("", 0)
+		}
+	} else {
+		sizeCell = NewCellInt("", 0)
+	}
+
 	if val := strings.Split(imageID, "@"); len(val) == 2 {
 		imageID = val[1]
 	}
 
+	driftCell := NewCellText("")
+	if s.ShowDrift {
+		if s.drift[info.Namespace+"/"+info.PodName+"/"+info.Name] {
+			driftCell = NewCellColourBool(colourBad, true)
+		} else {
+			driftCell = NewCellBool(false)
+		}
+	}
+
 	cellList = append(cellList,
 		NewCellText(pullPolicy),
 		NewCellText(imageID),
 		NewCellText(containerID),
 		NewCellText(name),
 		NewCellText(tag),
+		sizeCell,
+		driftCell,
 	)
 
-	return cellList
+	return cellList, nil
+}
+
+// imageSize returns the size in bytes of the image as reported by the node, the image is matched on the digest from
+//
+//	the image id first and then on the image name, 0 is returned when the node doesnt list the image. a node that
+//	cant be read (no access to nodes or a pod read from a file) is warned about once and its sizes are left empty
+func (s *image) imageSize(nodeName string, imageID string, imageName string) int64 {
+	log := logger{location: "Image:imageSize"}
+
+	// pods that havent been scheduled yet dont have a node
+	if len(nodeName) == 0 {
+		return 0
+	}
+
+	if s.nodeImages == nil {
+		s.nodeImages = make(map[string]map[string]int64)
+	}
+
+	images, ok := s.nodeImages[nodeName]
+	if !ok {
+		node, err := s.connect.GetNode(nodeName)
+		if err != nil {
+			if !s.nodeWarned {
+				s.nodeWarned = true
+				log.Tell(fmt.Sprintf("unable to show the image sizes from node %s: %v", nodeName, err))
+			} else {
+				log.Debug("unable to read node", nodeName, err)
+			}
+		}
+		// a failed node is stored empty so it isnt requested again for every container
+		images = nodeImageSizes(node)
+		s.nodeImages[nodeName] = images
+	}
+
+	if val := strings.Split(imageID, "@"); len(val) == 2 {
+		if size, ok := images[val[1]]; ok {
+			return size
+		}
+	}
+
+	return images[imageName]
+}
+
+// nodeImageSizes returns the size of each image cached on the node keyed by every name the image is known by, names
+//
+//	that include a digest are also keyed by the digest on its own
+func nodeImageSizes(node v1.Node) map[string]int64 {
+	images := make(map[string]int64)
+
+	for _, image := range node.Status.Images {
+		for _, name := range image.Names {
+			images[name] = image.SizeBytes
+			if val := strings.Split(name, "@"); len(val) == 2 {
+				images[val[1]] = image.SizeBytes
+			}
+		}
+	}
+
+	return images
+}
+
+// imageDrift groups the containers by namespace, podNamePrefix and container name then counts the pods running each
+//
+//	image id. containers running an image used by fewer pods than the most common one are returned as true, when
+//	the most common images are tied every image in the group is returned. containers that havent started have no
+//	image id and are left out. the result is keyed by namespace/podname/container
+func imageDrift(podList []v1.Pod) map[string]bool {
+	out := make(map[string]bool)
+	counts := make(map[string]map[string]int)
+
+	for _, pod := range podList {
+		for _, status := range podStatusList(pod) {
+			if len(status.ImageID) == 0 {
+				continue
+			}
+			group := pod.Namespace + "/" + podNamePrefix(pod) + "/" + status.Name
+			if counts[group] == nil {
+				counts[group] = make(map[string]int)
+			}
+			counts[group][status.ImageID]++
+		}
+	}
+
+	// the images used by the most pods in each group, more than one when they are tied
+	common := make(map[string]map[string]bool)
+	for group, idCount := range counts {
+		if len(idCount) < 2 {
+			continue
+		}
+		best := 0
+		for _, count := range idCount {
+			if count > best {
+				best = count
+			}
+		}
+		common[group] = make(map[string]bool)
+		for id, count := range idCount {
+			if count == best {
+				common[group][id] = true
+			}
+		}
+	}
+
+	for _, pod := range podList {
+		for _, status := range podStatusList(pod) {
+			best, ok := common[pod.Namespace+"/"+podNamePrefix(pod)+"/"+status.Name]
+			if !ok || len(status.ImageID) == 0 {
+				continue
+			}
+			out[pod.Namespace+"/"+pod.Name+"/"+status.Name] = !best[status.ImageID] || len(best) > 1
+		}
+	}
+
+	return out
+}
+
+// podStatusList returns the status of every init, standard and ephemeral container in the pod
+func podStatusList(pod v1.Pod) []v1.ContainerStatus {
+	statusList := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statusList = append(statusList, pod.Status.ContainerStatuses...)
+	return append(statusList, pod.Status.EphemeralContainerStatuses...)
 }
 
 func (s *image) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
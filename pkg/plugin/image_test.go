@@ -0,0 +1,131 @@
+package plugin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// *****************
+// imageSize
+// *****************
+type imageSizeTest struct {
+	nodeName  string
+	imageID   string
+	imageName string
+	expected  int64
+}
+
+var imageSizeNode = v1.Node{
+	Status: v1.NodeStatus{
+		Images: []v1.ContainerImage{
+			{Names: []string{"docker.io/library/nginx@sha256:aaa", "docker.io/library/nginx:1.21"}, SizeBytes: 1000},
+			{Names: []string{"docker.io/library/busybox:1.36"}, SizeBytes: 2000},
+		},
+	},
+}
+
+var imageSizeTests = []imageSizeTest{
+	{"node-a", "docker.io/library/nginx@sha256:aaa", "nginx:1.21", 1000},
+	{"node-a", "docker-pullable://nginx@sha256:aaa", "nginx:1.21", 1000},
+	{"node-a", "", "docker.io/library/nginx:1.21", 1000},
+	{"node-a", "", "docker.io/library/busybox:1.36", 2000},
+	{"node-a", "docker.io/library/redis@sha256:bbb", "redis:7", 0},
+	{"", "docker.io/library/nginx@sha256:aaa", "nginx:1.21", 0},
+}
+
+func TestImageSize(t *testing.T) {
+	s := image{
+		nodeImages: map[string]map[string]int64{"node-a": nodeImageSizes(imageSizeNode)},
+	}
+
+	for _, test := range imageSizeTests {
+		if output := s.imageSize(test.nodeName, test.imageID, test.imageName); output != test.expected {
+			t.Errorf("Output %d not equal to expected %d", output, test.expected)
+		}
+	}
+
+}
+
+func TestImageSizeNodeForbidden(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(metav1.Status{TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}, Status: metav1.StatusFailure, Reason: metav1.StatusReasonForbidden, Code: http.StatusForbidden})
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	connect := Connector{}
+	if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// the size is left empty and the node is only requested once
+	s := image{connect: &connect}
+	for i := 0; i < 3; i++ {
+		if output := s.imageSize("node-a", "docker.io/library/nginx@sha256:aaa", "nginx:1.21"); output != 0 {
+			t.Errorf("Output %d not equal to expected 0", output)
+		}
+	}
+	if requests != 1 || !s.nodeWarned {
+		t.Errorf("Output %d requests (warned %t) not equal to expected 1 request with a warning", requests, s.nodeWarned)
+	}
+
+}
+
+// *****************
+// imageDrift
+// *****************
+func driftPod(name string, generateName string, hash string, imageIDs ...string) v1.Pod {
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:         name,
+		Namespace:    "default",
+		GenerateName: generateName,
+		Labels:       map[string]string{"pod-template-hash": hash},
+	}}
+	names := []string{"web", "proxy"}
+	for i, id := range imageIDs {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{Name: names[i], ImageID: id})
+	}
+	return pod
+}
+
+func TestImageDrift(t *testing.T) {
+	podList := []v1.Pod{
+		// two replicasets of the same deployment, one pod is still on the old build of web
+		driftPod("web-5d8f-a", "web-5d8f-", "5d8f", "nginx@sha256:new", "proxy@sha256:one"),
+		driftPod("web-5d8f-b", "web-5d8f-", "5d8f", "nginx@sha256:new", "proxy@sha256:one"),
+		driftPod("web-77c1-c", "web-77c1-", "77c1", "nginx@sha256:old", "proxy@sha256:one"),
+		// split evenly so there is no majority
+		driftPod("api-9a-a", "api-9a-", "9a", "api@sha256:aaa"),
+		driftPod("api-9a-b", "api-9a-", "9a", "api@sha256:bbb"),
+		// a container that hasnt started yet has no image id
+		driftPod("cache-1b-a", "cache-1b-", "1b", "redis@sha256:aaa"),
+		driftPod("cache-1b-b", "cache-1b-", "1b", ""),
+	}
+
+	// only the containers in a group with more than one image are listed
+	expected := map[string]bool{
+		"default/web-5d8f-a/web": false,
+		"default/web-5d8f-b/web": false,
+		"default/web-77c1-c/web": true,
+		"default/api-9a-a/web":   true,
+		"default/api-9a-b/web":   true,
+	}
+
+	if output := imageDrift(podList); !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+
+}
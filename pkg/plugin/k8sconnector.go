@@ -2,18 +2,25 @@ package plugin
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 
 	a1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
 	v1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
@@ -53,12 +60,16 @@ type Connector struct {
 	configMapArray map[string]map[string]string
 	setNameSpace   string
 	podList        []v1.Pod                     // List of Pods
+	nodeList       map[string]v1.Node           // cache of nodes retrieved from the server, keyed by name
 	replicaList    map[string][]a1.ReplicaSet   // list of ReplicaSets
 	daemonList     map[string][]a1.DaemonSet    // list of DaemonSets
 	statefulList   map[string][]a1.StatefulSet  // list of StatefulSet
 	deploymentList map[string][]a1.Deployment   // list of Deployments
 	jobList        map[string][]batchv1.Job     // list of k8s Jobs
 	cronJobList    map[string][]batchv1.CronJob // list of k8s CronJobs
+	eventList      map[string][]v1.Event        // cache of events retrieved from the server, keyed by namespace
+	kubeletList    map[string]map[string]v1.Pod // pods as seen by each nodes kubelet, keyed by node then namespace/name
+	kubeletErrors  map[string]error             // nodes whose kubelet could not be reached, so they are only tried once
 }
 
 type ParentData struct {
@@ -106,37 +117,88 @@ func (n *LeafNode) getChild(name string) *LeafNode {
 	return &child
 }
 
+// getRESTConfig builds the rest config from the kubeconfig and command line flags, the same as kubectl this includes
+//
+//	the impersonation flags (--as, --as-group and --as-uid) and the tls flags (--insecure-skip-tls-verify and
+//	--certificate-authority). a proxy-url set in the kubeconfig is used when present, otherwise the HTTPS_PROXY,
+//	HTTP_PROXY and NO_PROXY environment variables are checked
+func getRESTConfig(configFlags *genericclioptions.ConfigFlags) (*rest.Config, error) {
+	config, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, newIceError(ErrConfig, fmt.Errorf("failed to read kubeconfig: %w", err))
+	}
+
+	if config.Proxy == nil {
+		config.Proxy = http.ProxyFromEnvironment
+	}
+
+	return config, nil
+}
+
+// logAPICall logs each call made to the api server along with the number of items returned, shown when -v is 2 or higher
+func logAPICall(verb string, resource string, namespace string, target string, count int, err error) {
+	if !klog.V(2).Enabled() {
+		return
+	}
+
+	if err != nil {
+		klog.InfofDepth(1, "%s %s namespace=%q target=%q failed: %v", verb, resource, namespace, target, err)
+		return
+	}
+	klog.InfofDepth(1, "%s %s namespace=%q target=%q returned %d", verb, resource, namespace, target, count)
+}
+
 // load config for the k8s endpoint
 func (c *Connector) LoadConfig(configFlags *genericclioptions.ConfigFlags) error {
 	c.clientSet = kubernetes.Clientset{}
 	c.configFlags = configFlags
-	config, err := configFlags.ToRESTConfig()
+	config, err := getRESTConfig(configFlags)
 
 	if err != nil {
-		return fmt.Errorf("failed to read kubeconfig: %w", err)
+		return err
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to create clientset: %w", err)
+		return newIceError(ErrConfig, fmt.Errorf("failed to create clientset: %w", err))
 	}
 	c.clientSet = *clientset
+
+	if klog.V(1).Enabled() {
+		contextName, _ := currentContextName(configFlags)
+		klog.Infof("using context %q with server %s", contextName, config.Host)
+	}
 	return nil
 }
 
+// currentContextName returns the name of the context set by --context, falling back to the current context of the
+//
+//	kubeconfig file
+func currentContextName(configFlags *genericclioptions.ConfigFlags) (string, error) {
+	if configFlags.Context != nil && len(*configFlags.Context) > 0 {
+		return *configFlags.Context, nil
+	}
+
+	rawConfig, err := configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return "", newIceError(ErrConfig, fmt.Errorf("failed to read kubeconfig: %w", err))
+	}
+	return rawConfig.CurrentContext, nil
+}
+
 // load config for the metrics endpoint
 func (c *Connector) LoadMetricConfig(configFlags *genericclioptions.ConfigFlags) error {
 	c.metricSet = metricsclientset.Clientset{}
 	c.metricFlags = configFlags
-	config, err := configFlags.ToRESTConfig()
+	config, err := getRESTConfig(configFlags)
 
 	if err != nil {
-		return fmt.Errorf("failed to read kubeconfig: %w", err)
+		return err
 	}
 
 	metricset, err := metricsclientset.NewForConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to create clientset for metrics: %w", err)
+		return newIceError(ErrConfig, fmt.Errorf("failed to create clientset for metrics: %w", err))
 	}
 
 	c.metricSet = *metricset
@@ -187,31 +249,168 @@ func (c *Connector) GetPodLabels(podList []v1.Pod) (map[string]map[string]string
 
 func (c *Connector) GetNodeLabels(podList []v1.Pod) (map[string]map[string]string, error) {
 	//
+	labelMap := make(map[string]map[string]string)
+
+	nodeList, err := c.getPodNodes(podList)
+	if err != nil {
+		return map[string]map[string]string{}, err
+	}
+
+	for _, node := range nodeList {
+		name := node.Name
+		labels := node.Labels
+		labelMap[name] = labels
+	}
+
+	return labelMap, nil
+}
+
+// GetNodeConditions returns the status of each condition (MemoryPressure, DiskPressure, Ready etc) for the
+//
+//	nodes the pods are running on, keyed by node name and then condition type
+func (c *Connector) GetNodeConditions(podList []v1.Pod) (map[string]map[string]string, error) {
+	conditionMap := make(map[string]map[string]string)
+
+	nodeList, err := c.getPodNodes(podList)
+	if err != nil {
+		return map[string]map[string]string{}, err
+	}
+
+	for _, node := range nodeList {
+		conditions := make(map[string]string)
+		for _, condition := range node.Status.Conditions {
+			conditions[string(condition.Type)] = string(condition.Status)
+		}
+		conditionMap[node.Name] = conditions
+	}
+
+	return conditionMap, nil
+}
+
+// getPodNodes returns the unique list of nodes that the pods are scheduled on
+func (c *Connector) getPodNodes(podList []v1.Pod) ([]v1.Node, error) {
 	var nameList []string
 
-	labelMap := make(map[string]map[string]string)
 	nodeNames := make(map[string]int)
 
-	for _, pod := range c.podList {
+	for _, pod := range podList {
 		nodeName := pod.Spec.NodeName
+		// pods that havent been scheduled yet dont have a node
+		if len(nodeName) == 0 {
+			continue
+		}
 		if _, ok := nodeNames[nodeName]; !ok {
 			nodeNames[nodeName] = 1
 			nameList = append(nameList, nodeName)
 		}
 	}
 
-	nodeList, err := c.GetNodes(nameList)
+	if len(nameList) == 0 {
+		return []v1.Node{}, nil
+	}
+
+	return c.GetNodes(nameList)
+}
+
+// GetNode returns the named node, nodes are cached so multiple calls only hit the api server once
+func (c *Connector) GetNode(nodeName string) (v1.Node, error) {
+	if node, ok := c.nodeList[nodeName]; ok {
+		return node, nil
+	}
+
+	node, err := c.clientSet.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	logAPICall("get", "nodes", "", nodeName, 1, err)
 	if err != nil {
-		return map[string]map[string]string{}, err
+		return v1.Node{}, fmt.Errorf("failed to retrieve node from server: %w", err)
 	}
 
-	for _, node := range nodeList {
-		name := node.Name
-		labels := node.Labels
-		labelMap[name] = labels
+	if c.nodeList == nil {
+		c.nodeList = make(map[string]v1.Node)
 	}
+	c.nodeList[nodeName] = *node
 
-	return labelMap, nil
+	return *node, nil
+}
+
+// GetEvents returns every event in the namespace, events are cached per namespace so looking up the events of many pods
+//
+//	only hits the api server once for each namespace
+func (c *Connector) GetEvents(namespace string) ([]v1.Event, error) {
+	if eventList, ok := c.eventList[namespace]; ok {
+		return eventList, nil
+	}
+
+	events, err := c.clientSet.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{})
+	logAPICall("list", "events", namespace, "", len(events.Items), err)
+	if err != nil {
+		return []v1.Event{}, fmt.Errorf("failed to retrieve event list from server: %w", err)
+	}
+
+	if c.eventList == nil {
+		c.eventList = make(map[string][]v1.Event)
+	}
+	c.eventList[namespace] = events.Items
+
+	return events.Items, nil
+}
+
+// GetKubeletPod returns the pod as the kubelet on nodeName currently sees it, read from the kubelets /pods endpoint
+//
+//	through the api servers node proxy so no direct access to the node is needed. the kubelet updates the api
+//	server in batches so its view can be ahead of the pod object. each node is only asked once, a node that
+//	could not be reached returns the same error for every pod on it
+func (c *Connector) GetKubeletPod(nodeName string, namespace string, podName string) (v1.Pod, bool, error) {
+	if err, ok := c.kubeletErrors[nodeName]; ok {
+		return v1.Pod{}, false, err
+	}
+
+	podMap, ok := c.kubeletList[nodeName]
+	if !ok {
+		var err error
+		podMap, err = c.loadKubeletPods(nodeName)
+		if err != nil {
+			if c.kubeletErrors == nil {
+				c.kubeletErrors = make(map[string]error)
+			}
+			c.kubeletErrors[nodeName] = err
+			return v1.Pod{}, false, err
+		}
+
+		if c.kubeletList == nil {
+			c.kubeletList = make(map[string]map[string]v1.Pod)
+		}
+		c.kubeletList[nodeName] = podMap
+	}
+
+	pod, found := podMap[namespace+"/"+podName]
+	return pod, found, nil
+}
+
+// loadKubeletPods reads every pod from the kubelet on nodeName using the nodes/proxy subresource
+func (c *Connector) loadKubeletPods(nodeName string) (map[string]v1.Pod, error) {
+	if len(nodeName) == 0 {
+		return nil, errors.New("pod has not been scheduled to a node")
+	}
+
+	raw, err := c.clientSet.CoreV1().RESTClient().Get().
+		Resource("nodes").Name(nodeName).SubResource("proxy").Suffix("pods").
+		DoRaw(context.TODO())
+
+	podList := v1.PodList{}
+	if err == nil {
+		err = json.Unmarshal(raw, &podList)
+	}
+	logAPICall("get", "nodes/proxy/pods", "", nodeName, len(podList.Items), err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pods from the kubelet on node %s: %w", nodeName, err)
+	}
+
+	podMap := make(map[string]v1.Pod, len(podList.Items))
+	for _, pod := range podList.Items {
+		podMap[pod.Namespace+"/"+pod.Name] = pod
+	}
+
+	return podMap, nil
 }
 
 // returns a list of nodes
@@ -220,18 +419,13 @@ func (c *Connector) GetNodes(nodeNameList []string) ([]v1.Node, error) {
 	selector := metav1.ListOptions{}
 
 	if len(nodeNameList) > 0 {
-		if len(c.Flags.labels) > 0 {
-			return []v1.Node{}, fmt.Errorf("error: you cannot specify a node name and a selector together")
-		}
-
 		// single node
 		for _, nodename := range nodeNameList {
-			node, err := c.clientSet.CoreV1().Nodes().Get(context.TODO(), nodename, metav1.GetOptions{})
-			if err == nil {
-				nodeList = append(nodeList, []v1.Node{*node}...)
-			} else {
-				return []v1.Node{}, fmt.Errorf("failed to retrieve node from server: %w", err)
+			node, err := c.GetNode(nodename)
+			if err != nil {
+				return []v1.Node{}, err
 			}
+			nodeList = append(nodeList, node)
 		}
 
 		return nodeList, nil
@@ -243,6 +437,7 @@ func (c *Connector) GetNodes(nodeNameList []string) ([]v1.Node, error) {
 	}
 
 	nodes, err := c.clientSet.CoreV1().Nodes().List(context.TODO(), selector)
+	logAPICall("list", "nodes", "", selector.LabelSelector, len(nodes.Items), err)
 	if err == nil {
 		if len(nodes.Items) == 0 {
 			return []v1.Node{}, errors.New("no nodes found in default namespace")
@@ -251,6 +446,13 @@ func (c *Connector) GetNodes(nodeNameList []string) ([]v1.Node, error) {
 		return []v1.Node{}, fmt.Errorf("failed to retrieve node list from server: %w", err)
 	}
 
+	if c.nodeList == nil {
+		c.nodeList = make(map[string]v1.Node)
+	}
+	for _, node := range nodes.Items {
+		c.nodeList[node.Name] = node
+	}
+
 	return nodes.Items, nil
 }
 
@@ -331,10 +533,11 @@ func (c *Connector) GetMetricPods(podNameList []string) ([]v1beta1.PodMetrics, e
 
 			// single pod
 			pod, err := c.metricSet.MetricsV1beta1().PodMetricses(namespace).Get(context.TODO(), podname, metav1.GetOptions{})
+			logAPICall("get", "podmetricses", namespace, podname, 1, err)
 			if err == nil {
 				podList = append(podList, []v1beta1.PodMetrics{*pod}...)
 			} else {
-				return []v1beta1.PodMetrics{}, fmt.Errorf("failed to retrieve pod from metrics: %w", err)
+				return []v1beta1.PodMetrics{}, newIceError(ErrMetricsUnavailable, fmt.Errorf("failed to retrieve pod from metrics: %w", err))
 			}
 		}
 
@@ -345,14 +548,15 @@ func (c *Connector) GetMetricPods(podNameList []string) ([]v1beta1.PodMetrics, e
 		}
 
 		podList, err := c.metricSet.MetricsV1beta1().PodMetricses(namespace).List(context.TODO(), selector)
+		logAPICall("list", "podmetricses", namespace, selector.LabelSelector, len(podList.Items), err)
 		if err == nil {
 			if len(podList.Items) == 0 {
-				return []v1beta1.PodMetrics{}, errors.New("no metric info found for pods in namespace")
+				return []v1beta1.PodMetrics{}, newIceError(ErrMetricsUnavailable, errors.New("no metric info found for pods in namespace"))
 			} else {
 				return podList.Items, nil
 			}
 		} else {
-			return []v1beta1.PodMetrics{}, fmt.Errorf("failed to retrieve pod list from metrics: %w", err)
+			return []v1beta1.PodMetrics{}, newIceError(ErrMetricsUnavailable, fmt.Errorf("failed to retrieve pod list from metrics: %w", err))
 		}
 	}
 }
@@ -366,6 +570,7 @@ func (c *Connector) GetConfigMaps(configMapName string) (v1.ConfigMap, error) {
 	}
 
 	cm, err := c.clientSet.CoreV1().ConfigMaps(namespace).Get(context.TODO(), configMapName, metav1.GetOptions{})
+	logAPICall("get", "configmaps", namespace, configMapName, 1, err)
 	if err == nil {
 		return *cm, nil
 	}
@@ -402,9 +607,6 @@ func (c *Connector) GetConfigMapValue(configMap string, key string) string {
 
 // GetNamespace retrieves the namespace that is currently set as default
 func (c *Connector) GetNamespace(allNamespaces bool) string {
-	namespace := ""
-	ctx := ""
-
 	if len(c.setNameSpace) >= 1 {
 		return c.setNameSpace
 	}
@@ -419,25 +621,14 @@ func (c *Connector) GetNamespace(allNamespaces bool) string {
 		return *c.configFlags.Namespace
 	}
 
-	// now try to load the current namespace for our context
-	clientCfg, _ := clientcmd.NewDefaultClientConfigLoadingRules().Load()
-	// if context was suppiled on cmd line use that
-	if len(*c.configFlags.Context) > 0 {
-		ctx = *c.configFlags.Context
-	} else {
-		ctx = clientCfg.CurrentContext
-	}
-
-	if clientCfg.Contexts[ctx] == nil {
+	// now try to load the current namespace for our context, the raw loader uses the same rules as kubectl so
+	//  each file in a KUBECONFIG path list is merged in order and --kubeconfig and --context are honoured
+	namespace, _, err := c.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil || len(namespace) == 0 {
 		return "default"
 	}
 
-	namespace = clientCfg.Contexts[ctx].Namespace
-	if len(namespace) > 0 {
-		return namespace
-	}
-
-	return "default"
+	return namespace
 }
 
 // SetNamespace sets the namespace to use when searching for pods
@@ -476,25 +667,51 @@ func (c *Connector) LoadPods(podNameList []string) error {
 	selector := metav1.ListOptions{}
 
 	namespace := c.GetNamespace(c.Flags.allNamespaces)
+	if klog.V(1).Enabled() {
+		if len(namespace) == 0 {
+			klog.Infof("loading pods from all namespaces, pod names %v, selector %q", podNameList, c.Flags.labels)
+		} else {
+			klog.Infof("loading pods from namespace %q, pod names %v, selector %q", namespace, podNameList, c.Flags.labels)
+		}
+	}
 
 	if len(podNameList) > 0 {
-		if len(c.Flags.labels) > 0 {
+		if len(c.Flags.labels) > 0 || len(c.Flags.labelsList) > 0 {
 			c.podList = []v1.Pod{}
 			return fmt.Errorf("error: you cannot specify a pod name and a selector together")
 		}
 
 		// single pod
 		for _, podname := range podNameList {
-			pod, err := c.clientSet.CoreV1().Pods(namespace).Get(context.TODO(), podname, metav1.GetOptions{})
+			if len(namespace) == 0 {
+				// with -A the pod is looked up by name in every namespace, the same name can be used in more than one
+				found, err := c.listPods(namespace, metav1.ListOptions{FieldSelector: "metadata.name=" + podname})
+				if err != nil {
+					c.podList = []v1.Pod{}
+					return fmt.Errorf("failed to retrieve pod from server: %w", err)
+				}
+				if len(found) == 0 {
+					c.podList = []v1.Pod{}
+					return newIceError(ErrNoPods, fmt.Errorf("failed to retrieve pod from server: pod %q not found in any namespace", podname))
+				}
+				podList = append(podList, found...)
+				continue
+			}
+
+			pod, err := c.clientSet.CoreV1().Pods(namespace).Get(context.TODO(), podname, metav1.GetOptions{ResourceVersion: c.podResourceVersion()})
+			logAPICall("get", "pods", namespace, podname, 1, err)
 			if err == nil {
 				podList = append(podList, []v1.Pod{*pod}...)
 			} else {
 				c.podList = []v1.Pod{}
+				if apierrors.IsNotFound(err) {
+					return newIceError(ErrNoPods, fmt.Errorf("failed to retrieve pod from server: %w", err))
+				}
 				return fmt.Errorf("failed to retrieve pod from server: %w", err)
 			}
 		}
 
-		c.podList = podList
+		c.podList = sortPodsByName(filterPodNodes(filterPodPhase(podList, c.Flags.podPhase), c.Flags.onNodes))
 		return nil
 	}
 
@@ -503,17 +720,24 @@ func (c *Connector) LoadPods(podNameList []string) error {
 		selector.LabelSelector = c.Flags.labels
 	}
 
-	pods, err := c.clientSet.CoreV1().Pods(namespace).List(context.TODO(), selector)
+	var pods []v1.Pod
+	var err error
+	if len(c.Flags.labelsList) > 0 {
+		pods, err = c.listPodsBySelectors(namespace, c.Flags.labelsList)
+	} else {
+		pods, err = c.listPods(namespace, selector)
+	}
 	if err == nil {
-		if len(pods.Items) == 0 {
+		if len(pods) == 0 {
 			c.podList = []v1.Pod{}
-			return errors.New("no pods found in default namespace")
+			return newIceError(ErrNoPods, errors.New("no pods found in default namespace"))
 		} else {
+			podList = sortPodsByName(filterPodNodes(filterPodPhase(pods, c.Flags.podPhase), c.Flags.onNodes))
 			if len(c.Flags.matchSpecList) > 0 {
-				c.podList, err = c.SelectMatchinghPodSpec(pods.Items)
+				c.podList, err = c.SelectMatchinghPodSpec(podList)
 				return err
 			} else {
-				c.podList = pods.Items
+				c.podList = podList
 				return nil
 			}
 		}
@@ -523,6 +747,203 @@ func (c *Connector) LoadPods(podNameList []string) error {
 	}
 }
 
+// podResourceVersion returns the resource version to read pods at, an empty version asks the api server for a consistent
+//
+//	read of the latest pods from etcd while 0 lets it answer from its watch cache. The cache is cheaper on large
+//	clusters but can be slightly behind so a restart that has just happened may not be counted yet
+func (c *Connector) podResourceVersion() string {
+	if c.Flags.cachedRead {
+		return "0"
+	}
+	return ""
+}
+
+// listPods lists the pods in namespace that match selector, an empty namespace lists the pods from every namespace
+func (c *Connector) listPods(namespace string, selector metav1.ListOptions) ([]v1.Pod, error) {
+	selector.ResourceVersion = c.podResourceVersion()
+
+	// a single node is filtered by the api server, field selectors cant match one of several values so --on-node
+	//  with more than one node is only filtered on our side by filterPodNodes
+	if len(c.Flags.onNodes) == 1 {
+		if len(selector.FieldSelector) > 0 {
+			selector.FieldSelector += ","
+		}
+		selector.FieldSelector += "spec.nodeName=" + c.Flags.onNodes[0]
+	}
+
+	if len(namespace) == 0 && c.Flags.concurrency > 0 {
+		return c.listPodsByNamespace(selector, c.Flags.concurrency)
+	}
+
+	podItems, err := c.clientSet.CoreV1().Pods(namespace).List(context.TODO(), selector)
+	logAPICall("list", "pods", namespace, selector.LabelSelector, len(podItems.Items), err)
+	pods := podItems.Items
+	if len(namespace) == 0 && apierrors.IsForbidden(err) && !c.Flags.strict {
+		// not allowed to list pods across the whole cluster, so try each namespace we can see instead
+		klog.V(1).Infof("cluster wide pod list is forbidden, listing each namespace instead")
+		forbiddenErr := err
+		pods, err = c.listPodsByNamespace(selector, defaultConcurrency)
+		if err != nil {
+			// the original error is kept as it says why we had to fall back, eg. when namespaces cant be listed either
+			return []v1.Pod{}, fmt.Errorf("%w (listing each namespace instead also failed: %v)", forbiddenErr, err)
+		}
+	}
+
+	return pods, err
+}
+
+// listPodsBySelectors lists the pods matching each of the label selectors in turn, a pod that matches more than one
+//
+//	selector is only returned once
+func (c *Connector) listPodsBySelectors(namespace string, labelsList []string) ([]v1.Pod, error) {
+	var pods []v1.Pod
+	seen := make(map[string]bool)
+
+	for _, labels := range labelsList {
+		found, err := c.listPods(namespace, metav1.ListOptions{LabelSelector: labels})
+		if err != nil {
+			return []v1.Pod{}, err
+		}
+
+		for _, pod := range found {
+			key := string(pod.UID)
+			if len(key) == 0 {
+				key = pod.Namespace + "/" + pod.Name
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods, nil
+}
+
+// defaultConcurrency is the number of namespaces listed at the same time when we fall back to listing each namespace
+const defaultConcurrency = 4
+
+// listPodsByNamespace lists the pods one namespace at a time using up to concurrency workers, namespaces that we are not
+//
+//	allowed to list pods from are skipped with a warning so users with per namespace access still see every pod they
+//	can, unless --strict is set. the caller is expected to sort the pods as they are returned in the order each
+//	namespace finished
+func (c *Connector) listPodsByNamespace(selector metav1.ListOptions, concurrency int) ([]v1.Pod, error) {
+	namespaces, err := c.clientSet.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	logAPICall("list", "namespaces", "", "", len(namespaces.Items), err)
+	if err != nil {
+		return []v1.Pod{}, fmt.Errorf("failed to retrieve namespace list from server: %w", err)
+	}
+
+	type namespacePods struct {
+		pods []v1.Pod
+		err  error
+	}
+
+	jobs := make(chan string)
+	results := make(chan namespacePods)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < concurrency && i < len(namespaces.Items); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for namespace := range jobs {
+				pods, err := c.clientSet.CoreV1().Pods(namespace).List(context.TODO(), selector)
+				logAPICall("list", "pods", namespace, selector.LabelSelector, len(pods.Items), err)
+				if err != nil {
+					if apierrors.IsForbidden(err) && !c.Flags.strict {
+						fmt.Fprintln(os.Stderr, "WARNING: skipping namespace", namespace+",", "listing pods is forbidden")
+						continue
+					}
+					results <- namespacePods{err: fmt.Errorf("namespace %s: %w", namespace, err)}
+					continue
+				}
+				results <- namespacePods{pods: pods.Items}
+			}
+		}()
+	}
+
+	go func() {
+		for _, namespace := range namespaces.Items {
+			jobs <- namespace.Name
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	podList := []v1.Pod{}
+	var firstErr error
+	for result := range results {
+		if result.err != nil {
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			continue
+		}
+		podList = append(podList, result.pods...)
+	}
+
+	if firstErr != nil {
+		return []v1.Pod{}, firstErr
+	}
+	return podList, nil
+}
+
+// sortPodsByName returns the pods sorted by namespace and then pod name, this gives the rows a default order that is the
+//
+//	same on every run no matter what order the pods were returned in. the containers keep their order within each pod
+func sortPodsByName(pods []v1.Pod) []v1.Pod {
+	sorted := append([]v1.Pod{}, pods...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// filterPodNodes returns only the pods scheduled to one of the listed nodes, all pods are returned when nodeList is empty
+func filterPodNodes(pods []v1.Pod, nodeList []string) []v1.Pod {
+	if len(nodeList) == 0 {
+		return pods
+	}
+
+	podList := []v1.Pod{}
+	for _, pod := range pods {
+		for _, node := range nodeList {
+			if pod.Spec.NodeName == node {
+				podList = append(podList, pod)
+				break
+			}
+		}
+	}
+
+	return podList
+}
+
+// filterPodPhase returns only the pods that are in one of the listed phases, all pods are returned when phaseList is empty
+func filterPodPhase(pods []v1.Pod, phaseList []string) []v1.Pod {
+	if len(phaseList) == 0 {
+		return pods
+	}
+
+	podList := []v1.Pod{}
+	for _, pod := range pods {
+		for _, phase := range phaseList {
+			if string(pod.Status.Phase) == phase {
+				podList = append(podList, pod)
+				break
+			}
+		}
+	}
+
+	return podList
+}
+
 // GetOwnersList calls GetOwnerReference for each pod and returns a unique list of owner types as the key with an array of pods as the value
 func (c *Connector) GetOwnersList() (map[string][]v1.Pod, map[string]string) {
 	parentList := map[string][]v1.Pod{}
@@ -577,6 +998,7 @@ func (c *Connector) LoadReplicaSet(replicaNameList []string, namespace string) e
 		// single pod
 		for _, replicaName := range replicaNameList {
 			rs, err := c.clientSet.AppsV1().ReplicaSets(namespace).Get(context.TODO(), replicaName, metav1.GetOptions{})
+			logAPICall("get", "replicasets", namespace, replicaName, 1, err)
 			if err == nil {
 				list := append(c.replicaList[namespace], *rs)
 				c.replicaList[namespace] = list
@@ -594,6 +1016,7 @@ func (c *Connector) LoadReplicaSet(replicaNameList []string, namespace string) e
 	}
 
 	rs, err := c.clientSet.AppsV1().ReplicaSets(namespace).List(context.TODO(), selector)
+	logAPICall("list", "replicasets", namespace, selector.LabelSelector, len(rs.Items), err)
 	if err == nil {
 		if len(rs.Items) == 0 {
 			return errors.New("no ReplicaSet found in default namespace")
@@ -643,6 +1066,7 @@ func (c *Connector) LoadDeployment(deploymentNameList []string, namespace string
 		// single pod
 		for _, name := range deploymentNameList {
 			d, err := c.clientSet.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			logAPICall("get", "deployments", namespace, name, 1, err)
 			if err == nil {
 				list := append(c.deploymentList[namespace], *d)
 				c.deploymentList[namespace] = list
@@ -660,6 +1084,7 @@ func (c *Connector) LoadDeployment(deploymentNameList []string, namespace string
 	}
 
 	d, err := c.clientSet.AppsV1().Deployments(namespace).List(context.TODO(), selector)
+	logAPICall("list", "deployments", namespace, selector.LabelSelector, len(d.Items), err)
 
 	if err == nil {
 		if len(d.Items) == 0 {
@@ -710,6 +1135,7 @@ func (c *Connector) LoadDaemonSet(daemonNameList []string, namespace string) err
 		// single pod
 		for _, name := range daemonNameList {
 			d, err := c.clientSet.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			logAPICall("get", "daemonsets", namespace, name, 1, err)
 			if err == nil {
 				list := append(c.daemonList[namespace], *d)
 				c.daemonList[namespace] = list
@@ -727,6 +1153,7 @@ func (c *Connector) LoadDaemonSet(daemonNameList []string, namespace string) err
 	}
 
 	d, err := c.clientSet.AppsV1().DaemonSets(namespace).List(context.TODO(), selector)
+	logAPICall("list", "daemonsets", namespace, selector.LabelSelector, len(d.Items), err)
 
 	if err == nil {
 		if len(d.Items) == 0 {
@@ -777,6 +1204,7 @@ func (c *Connector) LoadStatefulSet(statefulNameList []string, namespace string)
 		// single pod
 		for _, replicaName := range statefulNameList {
 			s, err := c.clientSet.AppsV1().StatefulSets(namespace).Get(context.TODO(), replicaName, metav1.GetOptions{})
+			logAPICall("get", "statefulsets", namespace, replicaName, 1, err)
 			if err == nil {
 				list := append(c.statefulList[namespace], *s)
 				c.statefulList[namespace] = list
@@ -794,6 +1222,7 @@ func (c *Connector) LoadStatefulSet(statefulNameList []string, namespace string)
 	}
 
 	s, err := c.clientSet.AppsV1().StatefulSets(namespace).List(context.TODO(), selector)
+	logAPICall("list", "statefulsets", namespace, selector.LabelSelector, len(s.Items), err)
 
 	if err == nil {
 		if len(s.Items) == 0 {
@@ -844,6 +1273,7 @@ func (c *Connector) LoadJob(jobNameList []string, namespace string) error {
 		// single pod
 		for _, name := range jobNameList {
 			j, err := c.clientSet.BatchV1().Jobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			logAPICall("get", "jobs", namespace, name, 1, err)
 			if err == nil {
 				list := append(c.jobList[namespace], *j)
 				c.jobList[namespace] = list
@@ -861,6 +1291,7 @@ func (c *Connector) LoadJob(jobNameList []string, namespace string) error {
 	}
 
 	j, err := c.clientSet.BatchV1().Jobs(namespace).List(context.TODO(), selector)
+	logAPICall("list", "jobs", namespace, selector.LabelSelector, len(j.Items), err)
 
 	if err == nil {
 		if len(j.Items) == 0 {
@@ -911,6 +1342,7 @@ func (c *Connector) LoadCronJob(jobNameList []string, namespace string) error {
 		// single pod
 		for _, name := range jobNameList {
 			j, err := c.clientSet.BatchV1().CronJobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			logAPICall("get", "cronjobs", namespace, name, 1, err)
 			if err == nil {
 				list := append(c.cronJobList[namespace], *j)
 				c.cronJobList[namespace] = list
@@ -928,6 +1360,7 @@ func (c *Connector) LoadCronJob(jobNameList []string, namespace string) error {
 	}
 
 	j, err := c.clientSet.BatchV1().CronJobs(namespace).List(context.TODO(), selector)
+	logAPICall("list", "cronjobs", namespace, selector.LabelSelector, len(j.Items), err)
 
 	if err == nil {
 		if len(j.Items) == 0 {
@@ -0,0 +1,715 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/klog/v2"
+)
+
+// *****************
+// GetNamespace
+// *****************
+type getNamespaceTest struct {
+	kubeconfig []string // files joined to make the KUBECONFIG path list
+	flagFile   string   // file passed using --kubeconfig, overrides KUBECONFIG
+	context    string
+	expected   string
+}
+
+var getNamespaceTests = []getNamespaceTest{
+	{[]string{"kubeconfig-a.yaml"}, "", "", "ns-a"},
+	{[]string{"kubeconfig-b.yaml"}, "", "", "ns-b"},
+	// the first file to set current-context wins
+	{[]string{"kubeconfig-a.yaml", "kubeconfig-b.yaml"}, "", "", "ns-a"},
+	{[]string{"kubeconfig-b.yaml", "kubeconfig-a.yaml"}, "", "", "ns-b"},
+	// contexts from every file are merged so either can be selected
+	{[]string{"kubeconfig-a.yaml", "kubeconfig-b.yaml"}, "", "ctx-b", "ns-b"},
+	{[]string{"kubeconfig-a.yaml"}, "", "ctx-missing", "default"},
+	// --kubeconfig replaces the KUBECONFIG path list
+	{[]string{"kubeconfig-a.yaml"}, "kubeconfig-b.yaml", "", "ns-b"},
+}
+
+func TestGetNamespace(t *testing.T) {
+
+	for _, test := range getNamespaceTests {
+		pathList := []string{}
+		for _, name := range test.kubeconfig {
+			pathList = append(pathList, filepath.Join("testdata", name))
+		}
+		t.Setenv("KUBECONFIG", strings.Join(pathList, string(os.PathListSeparator)))
+
+		configFlags := genericclioptions.NewConfigFlags(false)
+		*configFlags.Context = test.context
+		if len(test.flagFile) > 0 {
+			*configFlags.KubeConfig = filepath.Join("testdata", test.flagFile)
+		}
+
+		connect := Connector{configFlags: configFlags}
+		if output := connect.GetNamespace(false); output != test.expected {
+			t.Errorf("Output %s not equal to expected %s (KUBECONFIG=%s context=%s)", output, test.expected, os.Getenv("KUBECONFIG"), test.context)
+		}
+	}
+
+}
+
+// *****************
+// getRESTConfig
+// *****************
+type getRESTConfigTest struct {
+	user     string
+	groups   []string
+	expected string
+}
+
+var getRESTConfigTests = []getRESTConfigTest{
+	{"", []string{}, ""},
+	{"system:serviceaccount:ns:sa", []string{}, "system:serviceaccount:ns:sa"},
+	{"jane", []string{"developers", "testers"}, "jane"},
+}
+
+func TestGetRESTConfigImpersonate(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join("testdata", "kubeconfig-a.yaml"))
+
+	for _, test := range getRESTConfigTests {
+		configFlags := genericclioptions.NewConfigFlags(false)
+		*configFlags.Impersonate = test.user
+		*configFlags.ImpersonateGroup = test.groups
+
+		config, err := getRESTConfig(configFlags)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if config.Impersonate.UserName != test.expected {
+			t.Errorf("Output %s not equal to expected %s", config.Impersonate.UserName, test.expected)
+		}
+		if len(test.groups) > 0 && !reflect.DeepEqual(config.Impersonate.Groups, test.groups) {
+			t.Errorf("Output %v not equal to expected %v", config.Impersonate.Groups, test.groups)
+		}
+	}
+
+}
+
+// *****************
+// LoadConfig tls flags
+// *****************
+type loadConfigTLSTest struct {
+	insecure    bool
+	caData      string // certificate-authority-data set in the kubeconfig
+	expectError bool
+}
+
+var loadConfigTLSTests = []loadConfigTLSTest{
+	// the self signed certificate is rejected unless tls verification is skipped
+	{false, "", true},
+	{true, "", false},
+	// --insecure-skip-tls-verify replaces the certificate authority from the kubeconfig, the same as kubectl
+	{true, "Zm9v", false},
+}
+
+func TestLoadConfigInsecureSkipTLSVerify(t *testing.T) {
+	// a self signed dev cluster that only knows how to list pods
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		podList := v1.PodList{
+			TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+			Items:    []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(podList)
+	}))
+	// the rejected handshake is expected, so dont log it
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	for _, test := range loadConfigTLSTests {
+		kubeconfig := `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: ` + server.URL + `
+`
+		if len(test.caData) > 0 {
+			kubeconfig += "    certificate-authority-data: " + test.caData + "\n"
+		}
+		kubeconfig += `contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+    namespace: default
+users:
+- name: dev
+  user:
+    token: dev
+`
+		filename := filepath.Join(t.TempDir(), "kubeconfig")
+		if err := os.WriteFile(filename, []byte(kubeconfig), 0600); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("KUBECONFIG", filename)
+
+		configFlags := genericclioptions.NewConfigFlags(false)
+		*configFlags.Insecure = test.insecure
+
+		connect := Connector{}
+		if err := connect.LoadConfig(configFlags); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		err := connect.LoadPods([]string{})
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error when insecure is %v", test.insecure)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error %v when insecure is %v", err, test.insecure)
+		} else if len(connect.podList) != 1 {
+			t.Errorf("Output %d pods not equal to expected 1", len(connect.podList))
+		}
+	}
+
+}
+
+// *****************
+// sortPodsByName
+// *****************
+func TestSortPodsByName(t *testing.T) {
+	pod := func(namespace string, name string) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	shuffledA := []v1.Pod{pod("prod", "web-2"), pod("dev", "web-1"), pod("prod", "api-1"), pod("prod", "web-1")}
+	shuffledB := []v1.Pod{pod("prod", "web-1"), pod("prod", "api-1"), pod("prod", "web-2"), pod("dev", "web-1")}
+	expected := []string{"dev/web-1", "prod/api-1", "prod/web-1", "prod/web-2"}
+
+	for _, podList := range [][]v1.Pod{shuffledA, shuffledB} {
+		var output []string
+		for _, p := range sortPodsByName(podList) {
+			output = append(output, p.Namespace+"/"+p.Name)
+		}
+
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("Output %v not equal to expected %v", output, expected)
+		}
+	}
+
+}
+
+// *****************
+// filterPodPhase
+// *****************
+type filterPodPhaseTest struct {
+	args     []string
+	expected []string
+}
+
+var filterPodPhaseTests = []filterPodPhaseTest{
+	{[]string{}, []string{"cron-1", "cron-2", "web-1", "web-2"}},
+	{[]string{"--phase", "Running"}, []string{"web-1"}},
+	// the phase names arent case sensitive
+	{[]string{"--phase", "running,pending"}, []string{"web-1", "web-2"}},
+	{[]string{"--phase", "Succeeded,Failed"}, []string{"cron-1", "cron-2"}},
+}
+
+func TestFilterPodPhase(t *testing.T) {
+	pod := func(name string, phase v1.PodPhase) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: v1.PodStatus{Phase: phase}}
+	}
+	podList := []v1.Pod{pod("cron-1", v1.PodSucceeded), pod("cron-2", v1.PodFailed), pod("web-1", v1.PodRunning), pod("web-2", v1.PodPending)}
+
+	for _, test := range filterPodPhaseTests {
+		cmd := &cobra.Command{Use: "status"}
+		addCommonFlags(cmd)
+		if err := cmd.ParseFlags(test.args); err != nil {
+			t.Fatalf("%v: unexpected error %v", test.args, err)
+		}
+		flags, err := processCommonFlags(cmd)
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", test.args, err)
+		}
+
+		output := []string{}
+		for _, p := range filterPodPhase(podList, flags.podPhase) {
+			output = append(output, p.Name)
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("%v: Output %v not equal to expected %v", test.args, output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// LoadPods forbidden namespaces
+// *****************
+type loadPodsForbiddenTest struct {
+	concurrency int
+	strict      bool
+	expectError bool
+}
+
+var loadPodsForbiddenTests = []loadPodsForbiddenTest{
+	// the cluster wide list is forbidden so each namespace is listed instead
+	{0, false, false},
+	{2, false, false},
+	{0, true, true},
+	{2, true, true},
+}
+
+func TestLoadPodsForbiddenNamespace(t *testing.T) {
+	// a cluster where the user can only list pods in namespace team-a
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces":
+			json.NewEncoder(w).Encode(v1.NamespaceList{
+				TypeMeta: metav1.TypeMeta{Kind: "NamespaceList", APIVersion: "v1"},
+				Items: []v1.Namespace{
+					{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+				},
+			})
+		case "/api/v1/namespaces/team-a/pods":
+			json.NewEncoder(w).Encode(v1.PodList{
+				TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+				Items:    []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "team-a"}}},
+			})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(metav1.Status{
+				TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+				Status:   metav1.StatusFailure,
+				Reason:   metav1.StatusReasonForbidden,
+				Code:     http.StatusForbidden,
+			})
+		}
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	for _, test := range loadPodsForbiddenTests {
+		connect := Connector{}
+		if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		connect.Flags = commonFlags{allNamespaces: true, concurrency: test.concurrency, strict: test.strict}
+
+		err := connect.LoadPods([]string{})
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error when strict is set (concurrency %d)", test.concurrency)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error %v (concurrency %d)", err, test.concurrency)
+		} else if len(connect.podList) != 1 || connect.podList[0].Name != "web-1" {
+			t.Errorf("Output %d pods not equal to expected 1 (concurrency %d)", len(connect.podList), test.concurrency)
+		}
+	}
+
+}
+
+// *****************
+// LoadPods forbidden namespace list
+// *****************
+func TestLoadPodsForbiddenNamespaceList(t *testing.T) {
+	// a user that can neither list pods across the cluster nor list the namespaces
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(metav1.Status{
+			TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+			Status:   metav1.StatusFailure,
+			Message:  `forbidden: cannot list resource "` + path.Base(r.URL.Path) + `"`,
+			Reason:   metav1.StatusReasonForbidden,
+			Code:     http.StatusForbidden,
+		})
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	connect := Connector{}
+	if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	connect.Flags = commonFlags{allNamespaces: true}
+
+	// the pod list error is returned rather than only the error from the fallback
+	err := connect.LoadPods([]string{})
+	if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), `cannot list resource "pods"`) {
+		t.Errorf("expected the forbidden pod list error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `cannot list resource "namespaces"`) {
+		t.Errorf("expected the namespace list error to be included, got %v", err)
+	}
+
+}
+
+// useTestServer points KUBECONFIG at a config file that connects to serverURL for the rest of the test
+func useTestServer(t *testing.T, serverURL string) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: ` + serverURL + `
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+users:
+- name: dev
+  user:
+    token: dev
+`
+	filename := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(filename, []byte(kubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", filename)
+}
+
+// *****************
+// LoadPods resourceVersion
+// *****************
+type loadPodsResourceVersionTest struct {
+	cachedRead bool
+	podNames   []string
+	expected   string
+}
+
+var loadPodsResourceVersionTests = []loadPodsResourceVersionTest{
+	{false, []string{}, ""},
+	{true, []string{}, "0"},
+	{false, []string{"web-1"}, ""},
+	{true, []string{"web-1"}, "0"},
+}
+
+func TestLoadPodsResourceVersion(t *testing.T) {
+	var resourceVersion string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceVersion = r.URL.Query().Get("resourceVersion")
+		pod := v1.Pod{
+			TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/pods/web-1") {
+			json.NewEncoder(w).Encode(pod)
+			return
+		}
+		json.NewEncoder(w).Encode(v1.PodList{
+			TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+			Items:    []v1.Pod{pod},
+		})
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	for _, test := range loadPodsResourceVersionTests {
+		connect := Connector{}
+		if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		connect.Flags = commonFlags{cachedRead: test.cachedRead}
+
+		resourceVersion = "unset"
+		if err := connect.LoadPods(test.podNames); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if resourceVersion != test.expected {
+			t.Errorf("Output resourceVersion %q not equal to expected %q (pod names %v)", resourceVersion, test.expected, test.podNames)
+		}
+	}
+
+}
+
+// *****************
+// GetKubeletPod
+// *****************
+type getKubeletPodTest struct {
+	node        string
+	pod         string
+	expectFound bool
+	expectError bool
+}
+
+var getKubeletPodTests = []getKubeletPodTest{
+	{"node-a", "web-1", true, false},
+	{"node-a", "web-2", false, false},
+	{"node-b", "web-3", false, true},
+	{"node-b", "web-4", false, true},
+	{"", "web-5", false, true},
+}
+
+func TestGetKubeletPod(t *testing.T) {
+	calls := map[string]int{}
+
+	// node-a has a reachable kubelet, node-b is refused by the api server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/nodes/node-a/proxy/pods" {
+			json.NewEncoder(w).Encode(v1.PodList{
+				TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+				Items:    []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}}},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	connect := Connector{}
+	if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, test := range getKubeletPodTests {
+		pod, found, err := connect.GetKubeletPod(test.node, "default", test.pod)
+		if (err != nil) != test.expectError {
+			t.Errorf("Output error %v not expected (node %q pod %s)", err, test.node, test.pod)
+		}
+		if found != test.expectFound || (found && pod.Name != test.pod) {
+			t.Errorf("Output found %v not equal to expected %v (node %q pod %s)", found, test.expectFound, test.node, test.pod)
+		}
+	}
+
+	// each kubelet is only asked once, even when it could not be reached
+	for _, path := range []string{"/api/v1/nodes/node-a/proxy/pods", "/api/v1/nodes/node-b/proxy/pods"} {
+		if calls[path] != 1 {
+			t.Errorf("Output %d calls to %s not equal to expected 1", calls[path], path)
+		}
+	}
+
+}
+
+// *****************
+// LoadPods on node
+// *****************
+type loadPodsOnNodeTest struct {
+	onNodes       []string
+	fieldSelector string
+	expected      []string
+}
+
+var loadPodsOnNodeTests = []loadPodsOnNodeTest{
+	{[]string{}, "", []string{"web-1", "web-2", "web-3"}},
+	{[]string{"node-a"}, "spec.nodeName=node-a", []string{"web-1", "web-3"}},
+	{[]string{"node-a", "node-b"}, "", []string{"web-1", "web-2", "web-3"}},
+	{[]string{"node-c"}, "spec.nodeName=node-c", []string{}},
+}
+
+func TestLoadPodsOnNode(t *testing.T) {
+	var fieldSelector string
+
+	// the server ignores the field selector so the pods are always filtered on our side as well
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fieldSelector = r.URL.Query().Get("fieldSelector")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v1.PodList{
+			TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+			Items: []v1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-a"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-b"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "web-3", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-a"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	for _, test := range loadPodsOnNodeTests {
+		connect := Connector{}
+		if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		connect.Flags = commonFlags{onNodes: test.onNodes}
+
+		if err := connect.LoadPods([]string{}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if fieldSelector != test.fieldSelector {
+			t.Errorf("Output fieldSelector %q not equal to expected %q (nodes %v)", fieldSelector, test.fieldSelector, test.onNodes)
+		}
+		output := []string{}
+		for _, pod := range connect.podList {
+			output = append(output, pod.Name)
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v (nodes %v)", output, test.expected, test.onNodes)
+		}
+	}
+
+}
+
+// *****************
+// verbose logging
+// *****************
+type verboseLoggingTest struct {
+	verbosity string
+	expected  []string
+	missing   []string
+}
+
+var verboseLoggingTests = []verboseLoggingTest{
+	{"0", []string{}, []string{"using context", "loading pods", "list pods"}},
+	{"1", []string{`using context "dev"`, `loading pods from namespace "default"`}, []string{"list pods"}},
+	// -v 2 adds each api call along with the number of items returned
+	{"2", []string{`using context "dev"`, `list pods namespace="default" target="" returned 2`}, []string{}},
+}
+
+func TestVerboseLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v1.PodList{
+			TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+			Items: []v1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	klogFlags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(klogFlags)
+	klogFlags.Set("logtostderr", "false")
+	defer func() {
+		klogFlags.Set("v", "0")
+		klogFlags.Set("logtostderr", "true")
+		klog.SetOutput(os.Stderr)
+	}()
+
+	for _, test := range verboseLoggingTests {
+		out := bytes.Buffer{}
+		klog.SetOutput(&out)
+		klogFlags.Set("v", test.verbosity)
+
+		connect := Connector{}
+		if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err := connect.LoadPods([]string{}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		klog.Flush()
+
+		for _, text := range test.expected {
+			if !strings.Contains(out.String(), text) {
+				t.Errorf("-v %s: Output %q should contain %q", test.verbosity, out.String(), text)
+			}
+		}
+		for _, text := range test.missing {
+			if strings.Contains(out.String(), text) {
+				t.Errorf("-v %s: Output %q should not contain %q", test.verbosity, out.String(), text)
+			}
+		}
+	}
+
+}
+
+// *****************
+// LoadPods concurrency
+// *****************
+func TestLoadPodsConcurrency(t *testing.T) {
+	namespacePods := map[string][]string{
+		"team-c": {"web-2", "api-1"},
+		"team-a": {"web-1"},
+		"team-b": {"db-1", "cache-1"},
+	}
+
+	var mu sync.Mutex
+	clusterWide := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/namespaces" {
+			list := v1.NamespaceList{TypeMeta: metav1.TypeMeta{Kind: "NamespaceList", APIVersion: "v1"}}
+			for _, namespace := range []string{"team-c", "team-a", "team-b"} {
+				list.Items = append(list.Items, v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+			}
+			json.NewEncoder(w).Encode(list)
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/")
+		if len(parts) != 3 || parts[0] != "namespaces" || parts[2] != "pods" {
+			mu.Lock()
+			clusterWide = true
+			mu.Unlock()
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		list := v1.PodList{TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"}}
+		for _, name := range namespacePods[parts[1]] {
+			list.Items = append(list.Items, v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: parts[1]}})
+		}
+		json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	expected := []string{"team-a/web-1", "team-b/cache-1", "team-b/db-1", "team-c/api-1", "team-c/web-2"}
+	for _, concurrency := range []int{2, 3, 8} {
+		connect := Connector{}
+		if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		connect.Flags = commonFlags{allNamespaces: true, concurrency: concurrency}
+
+		if err := connect.LoadPods([]string{}); err != nil {
+			t.Fatalf("unexpected error %v (concurrency %d)", err, concurrency)
+		}
+
+		var output []string
+		for _, pod := range connect.podList {
+			output = append(output, pod.Namespace+"/"+pod.Name)
+		}
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("Output %v not equal to expected %v (concurrency %d)", output, expected, concurrency)
+		}
+	}
+
+	if clusterWide {
+		t.Errorf("expected the pods to be listed one namespace at a time")
+	}
+
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -13,6 +14,9 @@ var lifecycleShort = "Show lifecycle actions for each container in a named pod"
 var lifecycleDescription = ` Prints lifecycle actions for individual containers. If no name is specified the
 configured actions of all pods in the current namespace are shown.
 
+The GRACE-PERIOD column is the terminationGracePeriodSeconds of the pod, the time the preStop hooks and the
+containers have to stop before they are killed.
+
 The T column in the table output denotes S for Standard, I for init and E for Ephemerial containers`
 
 var lifecycleExample = `  # List individual container lifecycle events from pods
@@ -36,6 +40,9 @@ var lifecycleExample = `  # List individual container lifecycle events from pods
   # namespace sorted by pod name in ascending order
   %[1]s lifecycle -c web-container --sort PODNAME
 
+  # List the preStop hooks with the longest grace period first, to find the pods that are slow to delete
+  %[1]s lifecycle --sort '!GRACE-PERIOD'
+
   # List container lifecycle events from all pods where label app equals web
   %[1]s lifecycle -l app=web
 
@@ -86,8 +93,7 @@ func Lifecycle(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, arg
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	return outputTableAs(table, commonFlagList)
 
 }
 
@@ -96,7 +102,7 @@ type lifecycle struct {
 
 func (s *lifecycle) Headers() []string {
 	return []string{
-		"LIFECYCLE", "HANDLER", "ACTION",
+		"LIFECYCLE", "HANDLER", "ACTION", "GRACE-PERIOD",
 	}
 }
 
@@ -117,6 +123,11 @@ func (s *lifecycle) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell,
 		NewCellText(""),
 		NewCellText(""),
 		NewCellText(""),
+		NewCellInt("", 0),
+	}
+
+	if info.TypeName == TypeNamePod {
+		out[3] = gracePeriodCell(info.Data.pod)
 	}
 	return out, nil
 }
@@ -142,9 +153,23 @@ func (s *lifecycle) lifecycleBuildRow(info BuilderInformation, handlerName strin
 		NewCellText(handlerName),
 		NewCellText(lifecycles.actionName),
 		NewCellText(lifecycles.action),
+		gracePeriodCell(info.Data.pod),
 	}
 }
 
+// gracePeriodCell returns the number of seconds the pod is given to stop after the preStop hooks are started, this
+//
+//	is pod level so its the same on every row of the pod. the api server always sets it so its only empty when the
+//	pod is read from a file that leaves it out
+func gracePeriodCell(pod v1.Pod) Cell {
+	if pod.Spec.TerminationGracePeriodSeconds == nil {
+		return NewCellInt("", 0)
+	}
+
+	seconds := *pod.Spec.TerminationGracePeriodSeconds
+	return NewCellInt(fmt.Sprintf("%d", seconds), seconds)
+}
+
 // check each type of probe and return a list
 func (s *lifecycle) buildLifecycleList(lifecycle *v1.Lifecycle) map[string]lifecycleAction {
 	lifeCycleList := make(map[string]lifecycleAction)
@@ -0,0 +1,35 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+)
+
+// *****************
+// grace period
+// *****************
+type gracePeriodTest struct {
+	gracePeriod string
+	expected    []string
+}
+
+var gracePeriodTests = []gracePeriodTest{
+	{"  terminationGracePeriodSeconds: 30\n", []string{"30"}},
+	{"  terminationGracePeriodSeconds: 0\n", []string{"0"}},
+	// the api server always sets it, only a pod from a file can leave it out
+	{"", []string{""}},
+}
+
+func TestLifecycleGracePeriod(t *testing.T) {
+
+	for _, test := range gracePeriodTests {
+		pods := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web-1\n  namespace: default\nspec:\n" + test.gracePeriod +
+			"  containers:\n  - name: web\n    lifecycle:\n      preStop:\n        exec:\n          command: [\"sleep\", \"5\"]\n  - name: proxy\n"
+		tbl, _ := buildTestTable(t, RowBuilder{LoopSpec: true}, &lifecycle{}, commonFlags{}, pods)
+
+		if output := columnText(tbl, "GRACE-PERIOD"); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// isInteractive returns true when both stdin and stdout are attached to a terminal, the picker is skipped when either
+//
+//	is redirected so scripts and pipes are never left waiting for an answer
+func isInteractive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		fileinfo, err := f.Stat()
+		if err != nil {
+			return false
+		}
+		if (fileinfo.Mode() & os.ModeCharDevice) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pickPods lists podList as a numbered menu on out and reads the pods to keep from in. Typing text narrows the menu to
+//
+//	the pods whose namespace/name fuzzy matches it, numbers and ranges (eg 1,3-5) pick from the menu shown and an
+//	empty line picks every pod in the menu
+func pickPods(podList []v1.Pod, in io.Reader, out io.Writer) ([]v1.Pod, error) {
+	shown := podList
+	reader := bufio.NewReader(in)
+
+	for {
+		for i, pod := range shown {
+			fmt.Fprintf(out, "%3d) %s/%s\t%s\n", i+1, pod.Namespace, pod.Name, pod.Status.Phase)
+		}
+		fmt.Fprint(out, "pick pods by number (eg 1,3-5), type to filter or press enter to use all shown: ")
+
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			fmt.Fprintln(out)
+			return []v1.Pod{}, errors.New("no pods were picked")
+		}
+
+		if len(line) == 0 {
+			return shown, nil
+		}
+
+		if picked, ok := parsePickSelection(line, len(shown)); ok {
+			selected := make([]v1.Pod, 0, len(picked))
+			for _, idx := range picked {
+				selected = append(selected, shown[idx])
+			}
+			return selected, nil
+		}
+
+		var matched []v1.Pod
+		for _, pod := range podList {
+			if fuzzyMatch(pod.Namespace+"/"+pod.Name, line) {
+				matched = append(matched, pod)
+			}
+		}
+		if len(matched) == 0 {
+			fmt.Fprintf(out, "no pods match %q\n", line)
+			shown = podList
+			continue
+		}
+		shown = matched
+	}
+}
+
+// parsePickSelection turns a comma seperated list of numbers and ranges into zero based indexes, false is returned
+//
+//	when the text isnt a selection or picks a number outside of 1 to count
+func parsePickSelection(text string, count int) ([]int, bool) {
+	var picked []int
+	seen := make(map[int]bool)
+
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil {
+			return []int{}, false
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(last))
+			if err != nil {
+				return []int{}, false
+			}
+		}
+		if start < 1 || end > count || start > end {
+			return []int{}, false
+		}
+
+		for i := start; i <= end; i++ {
+			if !seen[i] {
+				seen[i] = true
+				picked = append(picked, i-1)
+			}
+		}
+	}
+
+	return picked, len(picked) > 0
+}
+
+// fuzzyMatch returns true when every character of pattern appears in text in the same order, ignoring case
+func fuzzyMatch(text string, pattern string) bool {
+	text = strings.ToLower(text)
+	for _, r := range strings.ToLower(pattern) {
+		idx := strings.IndexRune(text, r)
+		if idx == -1 {
+			return false
+		}
+		text = text[idx+len(string(r)):]
+	}
+	return true
+}
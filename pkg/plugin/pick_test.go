@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// *****************
+// parsePickSelection
+// *****************
+type parsePickSelectionTest struct {
+	text     string
+	expected []int
+	ok       bool
+}
+
+var parsePickSelectionTests = []parsePickSelectionTest{
+	{"1", []int{0}, true},
+	{"1,3", []int{0, 2}, true},
+	{"2-4", []int{1, 2, 3}, true},
+	{" 4 , 1-2, 2 ", []int{3, 0, 1}, true},
+	{"5", []int{}, false},
+	{"0", []int{}, false},
+	{"3-2", []int{}, false},
+	{"web", []int{}, false},
+	{"1,web", []int{}, false},
+	{",", []int{}, false},
+}
+
+func TestParsePickSelection(t *testing.T) {
+
+	for _, test := range parsePickSelectionTests {
+		output, ok := parsePickSelection(test.text, 4)
+		if ok != test.ok || (ok && !reflect.DeepEqual(output, test.expected)) {
+			t.Errorf("Output %v %t not equal to expected %v %t for %q", output, ok, test.expected, test.ok, test.text)
+		}
+	}
+
+}
+
+// *****************
+// fuzzyMatch
+// *****************
+type fuzzyMatchTest struct {
+	text     string
+	pattern  string
+	expected bool
+}
+
+var fuzzyMatchTests = []fuzzyMatchTest{
+	{"default/web-7d9f-x2k", "web", true},
+	{"default/web-7d9f-x2k", "dwx", true},
+	{"default/web-7d9f-x2k", "WEB", true},
+	{"default/web-7d9f-x2k", "bew", false},
+	{"default/web-7d9f-x2k", "mail", false},
+	{"default/web-7d9f-x2k", "", true},
+}
+
+func TestFuzzyMatch(t *testing.T) {
+
+	for _, test := range fuzzyMatchTests {
+		if output := fuzzyMatch(test.text, test.pattern); output != test.expected {
+			t.Errorf("Output %t not equal to expected %t for %q in %q", output, test.expected, test.pattern, test.text)
+		}
+	}
+
+}
+
+// *****************
+// pickPods
+// *****************
+type pickPodsTest struct {
+	input       string
+	expected    []string
+	expectError bool
+}
+
+var pickPodsTests = []pickPodsTest{
+	{"\n", []string{"web-1", "web-2", "mail-1"}, false},
+	{"2\n", []string{"web-2"}, false},
+	{"3,1\n", []string{"mail-1", "web-1"}, false},
+	// the numbers pick from the filtered menu
+	{"web\n2\n", []string{"web-2"}, false},
+	{"ml\n\n", []string{"mail-1"}, false},
+	{"nothing\n1\n", []string{"web-1"}, false},
+	{"2", []string{"web-2"}, false},
+	{"", nil, true},
+	{"web\n", nil, true},
+}
+
+func TestPickPods(t *testing.T) {
+	var podList []v1.Pod
+	for _, name := range []string{"web-1", "web-2", "mail-1"} {
+		podList = append(podList, v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+	}
+
+	for _, test := range pickPodsTests {
+		var out bytes.Buffer
+		picked, err := pickPods(podList, strings.NewReader(test.input), &out)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error for input %q", test.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error %v for input %q", err, test.input)
+			continue
+		}
+
+		var names []string
+		for _, pod := range picked {
+			names = append(names, pod.Name)
+		}
+		if !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("Output %v not equal to expected %v for input %q", names, test.expected, test.input)
+		}
+	}
+
+}
@@ -4,24 +4,36 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 type commonFlags struct {
 	allNamespaces      bool                  // should we search all namespaces
-	container          string                // name of the container to search for
+	container          []string              // names of the containers to search for
+	excludeContainer   []string              // names of the containers to always leave out of the output
 	filterList         map[string]matchValue // used to filter out rows form the table during Print function
 	labels             string                // k8s pod labels
+	labelsList         []string              // label selectors read from --selector-file, pods matching any of them are listed
 	showInitContainers bool                  // currently only for mem and cpu sub commands, placed here incase its needed in the future for others
 	showOddities       bool                  // this isnt really common but it does show up across 3+ commands and im lazy
+	explainOddities    bool                  // write the range used by showOddities and the value of each outlier to stderr
 	showNamespaceName  bool                  // shows the namespace name of each pod
 	showNodeName       bool                  // do we need to show the node name in the output
 	showTreeView       bool                  // show the table in a tree like view
 	showNodeTree       bool                  // show the tree rooted at the node level, forces showTreeView to true
+	flattenTree        bool                  // build the tree view without indenting the names so it can be sorted, forces showTreeView to true
+	compactTree        bool                  // show single container pods on one line in the tree view, forces showTreeView to true
+	hideTreeSummary    bool                  // leave the summary values off the pod line when showing the tree view
+	orderAnnotation    string                // pod annotation listing the order to show each pods containers in
 	showContainerType  bool                  // show container type column
 	byteSize           string                // sets the bytes conversion for the output size
 	outputAs           string                // how to output the table, currently only accepts json
@@ -30,13 +42,62 @@ type commonFlags struct {
 	calcMatchOnly      bool                  // should we calculate up only the rows that match
 	inputFilename      string                // filename to read pod information from, rather than the k8s api
 	labelNodeName      string
+	conditionNodeName  string // node condition type to show as a column eg MemoryPressure
 	labelPodName       string
 	annotationPodName  string
+	showManagedBy      bool   // add columns for the managed-by label and if the pod was created with kubectl apply
+	showPodUID         bool   // add a column with the uid of the pod
+	showScheduler      bool   // add a column with the scheduler name of the pod
 	showColumnByName   string // list of column names to show, overrides other hidden columns
 	outputAsColour     int    // which coloring type do we use when displaying columns
 	useTheseColours    [][2]int
+	showWide           bool               // show all columns including the ones that are hidden by default
+	outputVersion      string             // layout version of the json and yaml output
+	countOnly          bool               // print the number of matching containers and pods instead of the table
+	errorOnEmpty       bool               // return ErrNoMatch when no rows are left to print
+	tableStyle         int                // border and padding style used when printing the table
+	renameColumns      map[string]string  // header names to print in place of the column names
+	podPhase           []string           // only include pods in these phases
+	onNodes            []string           // only include pods scheduled to one of these nodes
+	imageFilter        string             // only include containers whose spec image contains this text
+	imageTag           string             // only include containers whose spec image has this tag
+	imageRegex         bool               // match imageFilter and imageTag as regular expressions
+	imageFilterRegex   *regexp.Regexp     // compiled imageFilter, only set with imageRegex
+	imageTagRegex      *regexp.Regexp     // compiled imageTag, only set with imageRegex
+	truncateNames      int                // shorten container names to this many characters in the table output
+	showSymbols        bool               // print true and false as symbols in the table output
+	noHeaders          bool               // leave the header line out of the table and csv output
+	quiet              bool               // dont print the number of matching containers after the table output
+	useASCII           bool               // only use ascii characters for the tree and symbols in the table output
+	concurrency        int                // number of namespaces to list pods from at the same time when using -A
+	strict             bool               // fail when pods cant be listed from a namespace rather than skipping it
+	cachedRead         bool               // read pods from the api servers watch cache (resourceVersion=0) rather than a consistent read
+	withMetadata       bool               // add the row counts and active filters to the json output
+	outputLayout       int                // LAYOUT_DEFAULT, LAYOUT_COMPACT or LAYOUT_PRETTY for the json and yaml output
+	activeFilters      map[string]string  // filter flags that were set and their values, reported in the json metadata
+	pickPods           bool               // ask which pods to show when more than one matches, only on a terminal
+	outputFiles        []outputFile       // extra formats to write the table to, alongside the -o output on stdout
+	messageReflow      bool               // print the MESSAGE column on its own lines under each row in the table output
+	outputTemplate     *template.Template // read from --template-file, each row is rendered with it in place of -o
+	splitOutputDir     string             // write one file per group of rows to this directory in place of stdout
+	splitBy            string             // how the rows are grouped into files with splitOutputDir, a key of splitByColumns
+	contextResults     *contextResults    // set while --contexts runs the command, the table is added to it rather than printed
 }
 
+// splitByColumns lists the --split-by values and the columns used to group the rows into files
+var splitByColumns = map[string][]string{
+	"pod": {"NAMESPACE", "PODNAME"},
+}
+
+// outputFile is a single format=filename pair from --output-file
+type outputFile struct {
+	format   string // same names as -o with table for the default table output
+	filename string
+}
+
+// outputVersions lists the supported json/yaml layouts, the last entry is the latest and is used by default
+var outputVersions = []string{"v1"}
+
 const (
 	COLOUR_NONE      = 0
 	COLOUR_ERRORS    = 1
@@ -46,13 +107,37 @@ const (
 	COLOUR_CUSTOMMIX = 5
 )
 
+const (
+	STYLE_DEFAULT = 0
+	STYLE_COMPACT = 1
+	STYLE_BOX     = 2
+)
+
+const (
+	LAYOUT_DEFAULT = 0
+	LAYOUT_COMPACT = 1
+	LAYOUT_PRETTY  = 2
+)
+
 func InitSubCommands(rootCmd *cobra.Command) {
 	var includeInitShort string = "include init container(s) in the output, by default init containers are hidden"
 	var odditiesShort string = "show only the outlier rows that dont fall within the computed range"
+	var explainOdditiesShort string = "with --oddities write the computed range and the value of each outlier row to stderr"
+	var podFootprintShort string = "add a row for each pod showing what it reserves on a node, the larger of the biggest init container and the other containers added together plus the pod overhead"
+	var showResizeShort string = "show the in-place resize status of the pod (RESIZE) with the request allocated by the node (ALLOCATED) and the limit applied by the runtime (ACTUAL-LIMIT), highlighted while they differ from the spec"
+	var requireLimitsShort string = "show LIMIT as missing for every container without a limit, the cpu command checks cpu limits and memory checks memory limits"
+	var failOnMissingLimitsShort string = "Exit with a non zero exit code when any container has no limit, needs --require-limits"
+	var topShort string = "show only the N containers using the most resources, sorted by usage"
 	var sizeShort string = "allows conversion to the selected size rather then the default megabyte output"
 	var treeShort string = "Display tree like view instead of the standard list"
+	var flattenTreeShort string = "Use the Pod/ and Container/ names of the tree view in a flat table that can be sorted"
+	var compactTreeShort string = "Display the tree view with each pod that has a single container shown on one line, pods with more containers are still expanded"
 	var nodetreeShort string = "Displays the tree with the nodes as the root"
+	var noTreeSummaryShort string = "In tree view dont show the summary values on the pod line, only the pod name"
 	var showIPShort string = "Show the pods IP address column"
+	var imageFilterShort string = "Only show containers whose image from the pod spec contains this text (e.g. nginx or registry.local/web)"
+	var imageTagShort string = "Only show containers whose image from the pod spec has this tag (e.g. 1.21)"
+	var imageRegexShort string = "Match --image-filter and --image-tag as regular expressions instead of text"
 	// var treeShort string = "Display tree like view instead of the standard list"
 
 	log := logger{location: "InitSubCommands"}
@@ -78,8 +163,12 @@ func InitSubCommands(rootCmd *cobra.Command) {
 		},
 	}
 	KubernetesConfigFlags.AddFlags(cmdCapabilities.Flags())
+	cmdCapabilities.Flags().BoolP("lint", "", false, "Check each container for dangerous capabilities such as SYS_ADMIN and NET_ADMIN and list the problems in the WARN column")
+	cmdCapabilities.Flags().BoolP("fail-on-warn", "", false, "Exit with a non zero exit code when any container adds a dangerous capability, needs --lint")
 	cmdCapabilities.Flags().BoolP("tree", "t", false, treeShort)
+	cmdCapabilities.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdCapabilities.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdCapabilities.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdCapabilities)
 	rootCmd.AddCommand(cmdCapabilities)
 
@@ -100,11 +189,34 @@ func InitSubCommands(rootCmd *cobra.Command) {
 		},
 	}
 	KubernetesConfigFlags.AddFlags(cmdCommands.Flags())
+	cmdCommands.Flags().BoolP("details", "d", false, "Display the working directory and tty/stdin settings of each container")
 	cmdCommands.Flags().BoolP("tree", "t", false, treeShort)
+	cmdCommands.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdCommands.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdCommands.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdCommands)
 	rootCmd.AddCommand(cmdCommands)
 
+	// containers-per-pod
+	var cmdContainersPerPod = &cobra.Command{
+		Use:     "containers-per-pod",
+		Short:   containersPerPodShort,
+		Long:    fmt.Sprintf("%s\n\n%s", containersPerPodShort, containersPerPodDescription),
+		Example: fmt.Sprintf(containersPerPodExample, rootCmd.CommandPath()),
+		Aliases: []string{"per-pod", "cpp"},
+		// SuggestFor: []string{""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := ContainersPerPod(cmd, KubernetesConfigFlags, args); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+	KubernetesConfigFlags.AddFlags(cmdContainersPerPod.Flags())
+	addCommonFlags(cmdContainersPerPod)
+	rootCmd.AddCommand(cmdContainersPerPod)
+
 	// cpu
 	var cmdCPU = &cobra.Command{
 		Use:     "cpu",
@@ -123,9 +235,17 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	KubernetesConfigFlags.AddFlags(cmdCPU.Flags())
 	cmdCPU.Flags().BoolP("include-init", "i", false, includeInitShort)
 	cmdCPU.Flags().BoolP("oddities", "", false, odditiesShort)
+	cmdCPU.Flags().BoolP("explain-oddities", "", false, explainOdditiesShort)
 	cmdCPU.Flags().BoolP("raw", "r", false, "show raw values")
+	cmdCPU.Flags().IntP("top", "", 0, topShort)
+	cmdCPU.Flags().BoolP("pod-footprint", "", false, podFootprintShort)
+	cmdCPU.Flags().BoolP("show-resize", "", false, showResizeShort)
+	cmdCPU.Flags().BoolP("require-limits", "", false, requireLimitsShort)
+	cmdCPU.Flags().BoolP("fail-on-missing-limits", "", false, failOnMissingLimitsShort)
 	cmdCPU.Flags().BoolP("tree", "t", false, treeShort)
+	cmdCPU.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdCPU.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdCPU.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdCPU)
 	rootCmd.AddCommand(cmdCPU)
 
@@ -147,11 +267,65 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	}
 	KubernetesConfigFlags.AddFlags(cmdEnvironment.Flags())
 	cmdEnvironment.Flags().BoolP("translate", "", false, "read the configmap show its values")
+	cmdEnvironment.Flags().BoolP("details", "d", false, "Show the source type, source name and prefix of the variables imported with envFrom")
 	cmdEnvironment.Flags().BoolP("tree", "t", false, treeShort)
+	cmdEnvironment.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdEnvironment.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdEnvironment.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdEnvironment)
 	rootCmd.AddCommand(cmdEnvironment)
 
+	// events
+	var cmdEvents = &cobra.Command{
+		Use:     "events",
+		Short:   eventsShort,
+		Long:    fmt.Sprintf("%s\n\n%s", eventsShort, eventsDescription),
+		Example: fmt.Sprintf(eventsExample, rootCmd.CommandPath()),
+		Aliases: []string{"event", "ev"},
+		// SuggestFor: []string{""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := Events(cmd, KubernetesConfigFlags, args); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+	KubernetesConfigFlags.AddFlags(cmdEvents.Flags())
+	cmdEvents.Flags().BoolP("only-warnings", "", false, "only show events with the type Warning")
+	cmdEvents.Flags().DurationP("since", "", 0, "only show events last seen within this duration (e.g. 15m or 2h), by default all events are shown")
+	cmdEvents.Flags().BoolP("tree", "t", false, treeShort)
+	cmdEvents.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
+	cmdEvents.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdEvents.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
+	addCommonFlags(cmdEvents)
+	rootCmd.AddCommand(cmdEvents)
+
+	// gates
+	var cmdGates = &cobra.Command{
+		Use:     "gates",
+		Short:   gatesShort,
+		Long:    fmt.Sprintf("%s\n\n%s", gatesShort, gatesDescription),
+		Example: fmt.Sprintf(gatesExample, rootCmd.CommandPath()),
+		Aliases: []string{"gate", "readiness-gates"},
+		// SuggestFor: []string{""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := Gates(cmd, KubernetesConfigFlags, args); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+	KubernetesConfigFlags.AddFlags(cmdGates.Flags())
+	cmdGates.Flags().BoolP("not-ready", "", false, "only show the readiness gates that are not True")
+	cmdGates.Flags().BoolP("tree", "t", false, treeShort)
+	cmdGates.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
+	cmdGates.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdGates.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
+	addCommonFlags(cmdGates)
+	rootCmd.AddCommand(cmdGates)
+
 	// ip
 	var cmdIP = &cobra.Command{
 		Use:     "ip",
@@ -189,8 +363,15 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	}
 	KubernetesConfigFlags.AddFlags(cmdImage.Flags())
 	cmdImage.Flags().BoolP("id", "", false, "Show running containers id")
+	cmdImage.Flags().BoolP("image-size", "", false, "Show the size of each image as reported by the node the pod is running on")
+	cmdImage.Flags().BoolP("image-drift", "", false, "Mark the containers in the DRIFT column that are running a different image id to the same container in most of the other pods of their workload")
+	cmdImage.Flags().StringP("image-filter", "", "", imageFilterShort)
+	cmdImage.Flags().StringP("image-tag", "", "", imageTagShort)
+	cmdImage.Flags().BoolP("image-regex", "", false, imageRegexShort)
 	cmdImage.Flags().BoolP("tree", "t", false, treeShort)
+	cmdImage.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdImage.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdImage.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdImage)
 	rootCmd.AddCommand(cmdImage)
 
@@ -212,7 +393,9 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	}
 	KubernetesConfigFlags.AddFlags(cmdLifecycle.Flags())
 	cmdLifecycle.Flags().BoolP("tree", "t", false, treeShort)
+	cmdLifecycle.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdLifecycle.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdLifecycle.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdLifecycle)
 	rootCmd.AddCommand(cmdLifecycle)
 
@@ -236,10 +419,18 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	KubernetesConfigFlags.AddFlags(cmdMemory.Flags())
 	cmdMemory.Flags().BoolP("include-init", "i", false, includeInitShort)
 	cmdMemory.Flags().BoolP("oddities", "", false, odditiesShort)
+	cmdMemory.Flags().BoolP("explain-oddities", "", false, explainOdditiesShort)
 	cmdMemory.Flags().BoolP("raw", "r", false, "show raw values")
+	cmdMemory.Flags().IntP("top", "", 0, topShort)
+	cmdMemory.Flags().BoolP("pod-footprint", "", false, podFootprintShort)
+	cmdMemory.Flags().BoolP("show-resize", "", false, showResizeShort)
+	cmdMemory.Flags().BoolP("require-limits", "", false, requireLimitsShort)
+	cmdMemory.Flags().BoolP("fail-on-missing-limits", "", false, failOnMissingLimitsShort)
 	cmdMemory.Flags().String("size", "Mi", sizeShort)
 	cmdMemory.Flags().BoolP("tree", "t", false, treeShort)
+	cmdMemory.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdMemory.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdMemory.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdMemory)
 	rootCmd.AddCommand(cmdMemory)
 
@@ -261,11 +452,38 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	}
 	KubernetesConfigFlags.AddFlags(cmdPorts.Flags())
 	cmdPorts.Flags().BoolP("tree", "t", false, treeShort)
+	cmdPorts.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdPorts.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdPorts.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	cmdPorts.Flags().BoolP("show-ip", "", false, showIPShort)
 	addCommonFlags(cmdPorts)
 	rootCmd.AddCommand(cmdPorts)
 
+	// priority
+	var cmdPriority = &cobra.Command{
+		Use:     "priority",
+		Short:   priorityShort,
+		Long:    fmt.Sprintf("%s\n\n%s", priorityShort, priorityDescription),
+		Example: fmt.Sprintf(priorityExample, rootCmd.CommandPath()),
+		Aliases: []string{"prio", "preemption"},
+		// SuggestFor: []string{""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := Priority(cmd, KubernetesConfigFlags, args); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+	KubernetesConfigFlags.AddFlags(cmdPriority.Flags())
+	cmdPriority.Flags().BoolP("oddities", "", false, odditiesShort)
+	cmdPriority.Flags().BoolP("tree", "t", false, treeShort)
+	cmdPriority.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
+	cmdPriority.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdPriority.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
+	addCommonFlags(cmdPriority)
+	rootCmd.AddCommand(cmdPriority)
+
 	// probes
 	var cmdProbes = &cobra.Command{
 		Use:     "probes",
@@ -283,8 +501,15 @@ func InitSubCommands(rootCmd *cobra.Command) {
 		},
 	}
 	KubernetesConfigFlags.AddFlags(cmdProbes.Flags())
+	cmdProbes.Flags().BoolP("details", "d", false, "Show how long each container has been running in the AGE column, startup probe rows are a warning while inside the startup window")
+	cmdProbes.Flags().BoolP("health", "", false, "Show if each probe is passing right now in the HEALTHY column, Unknown is shown when the container state and events dont prove it either way")
+	cmdProbes.Flags().BoolP("lint", "", false, "Check each probe for suspicious settings and list the problems in the WARN column")
+	cmdProbes.Flags().BoolP("fail-on-warn", "", false, "Exit with a non zero exit code when any probe fails the --lint checks")
 	cmdProbes.Flags().BoolP("tree", "t", false, treeShort)
+	cmdProbes.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
+	cmdProbes.Flags().BoolP("compact-tree", "", false, compactTreeShort)
 	cmdProbes.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdProbes.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdProbes)
 	rootCmd.AddCommand(cmdProbes)
 
@@ -306,9 +531,17 @@ func InitSubCommands(rootCmd *cobra.Command) {
 		},
 	}
 	KubernetesConfigFlags.AddFlags(cmdRestart.Flags())
+	cmdRestart.Flags().BoolP("cause", "", false, "Show the likely cause of the last restart, OOM, ProbeKill, Crash or Exited")
 	cmdRestart.Flags().BoolP("oddities", "", false, odditiesShort)
+	cmdRestart.Flags().BoolP("explain-oddities", "", false, explainOdditiesShort)
+	cmdRestart.Flags().IntP("repeat", "", 1, "Number of times to sample the restart counts, the change between the first and last sample is shown in the RESTART-DELTA column")
+	cmdRestart.Flags().DurationP("interval", "", 10*time.Second, "Time to wait between each sample when using --repeat")
+	cmdRestart.Flags().IntP("max-restarts", "", 0, "Highlight containers that have restarted more than this many times, a * is added when the table has no colour")
+	cmdRestart.Flags().BoolP("rate", "", false, "Show the number of restarts per hour since the pod started")
 	cmdRestart.Flags().BoolP("tree", "t", false, treeShort)
+	cmdRestart.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdRestart.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdRestart.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdRestart)
 	rootCmd.AddCommand(cmdRestart)
 
@@ -330,8 +563,13 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	}
 	KubernetesConfigFlags.AddFlags(cmdSecurity.Flags())
 	cmdSecurity.Flags().BoolP("selinux", "", false, "show the SELinux context thats applied to the containers")
+	cmdSecurity.Flags().BoolP("profiles", "", false, "show the seccomp and AppArmor profiles that are applied to the containers")
+	cmdSecurity.Flags().BoolP("lint", "", false, "Check the seccomp and AppArmor profiles, mount propagation and root filesystem of each container and list any problems in the WARN column")
+	cmdSecurity.Flags().BoolP("fail-on-warn", "", false, "Exit with a non zero exit code when any container has an unconfined profile or a Bidirectional mount, needs --lint")
 	cmdSecurity.Flags().BoolP("tree", "t", false, treeShort)
+	cmdSecurity.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdSecurity.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdSecurity.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdSecurity)
 	rootCmd.AddCommand(cmdSecurity)
 
@@ -357,10 +595,37 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	KubernetesConfigFlags.AddFlags(cmdStatus.Flags())
 	cmdStatus.Flags().BoolP("details", "d", false, `Display the timestamp instead of age along with the message column`)
 	cmdStatus.Flags().BoolP("oddities", "", false, odditiesShort)
+	cmdStatus.Flags().BoolP("explain-oddities", "", false, explainOdditiesShort)
+	cmdStatus.Flags().BoolP("containers-summary-per-pod", "", false, "Show one row per pod with the number of containers, how many are ready, the total restarts and the containers that are not running")
 	cmdStatus.Flags().BoolP("previous", "p", false, "Show previous state")
-	cmdStatus.Flags().BoolP("id", "", false, "Show running containers id")
+	cmdStatus.Flags().BoolP("show-sidecar", "", false, "Show if each init container is a native sidecar, sidecars have a restartPolicy of Always and keep running after the pod has started")
+	cmdStatus.Flags().BoolP("show-restart-policy", "", false, "Show the restart policy of the pod on each container, a container that failed in a pod with a Never policy will not be restarted")
+	cmdStatus.Flags().BoolP("kubelet", "", false, "Also show the ready, restarts and state of each container as reported by the kubelet on its node, read through the api servers node proxy (needs get on nodes/proxy), highlighted when the kubelet is ahead of the api server")
+	cmdStatus.Flags().BoolP("id", "", false, "Show the short id of each container along with the container runtime (e.g. containerd) in the RUNTIME column, to match the containers listed by crictl on the node")
+	cmdStatus.Flags().StringSliceP("state", "", []string{}, "Only show containers in the selected state, repeat the flag or use a comma seperated list of running, waiting and terminated")
+	cmdStatus.Flags().StringP("image-filter", "", "", imageFilterShort)
+	cmdStatus.Flags().StringP("image-tag", "", "", imageTagShort)
+	cmdStatus.Flags().BoolP("image-regex", "", false, imageRegexShort)
+	cmdStatus.Flags().BoolP("uptime", "", false, "Show the percentage of the pods lifetime each container has been running, only the current and last run are known so this is the lowest it could be")
+	cmdStatus.Flags().BoolP("timeline", "", false, "Display the tree view with each pods containers ordered by the time they started")
+	cmdStatus.Flags().BoolP("completed", "", false, "Only show pods that have completed (Succeeded or Failed) along with the exit code and finish time of each container")
+	cmdStatus.Flags().BoolP("hide-completed", "", false, "Leave out pods that have completed (Succeeded or Failed)")
+	cmdStatus.Flags().BoolP("init-problems", "", false, "Only show init containers that are crashlooping along with the exit code of their last run")
+	cmdStatus.Flags().BoolP("only-ephemeral", "", false, "Only show ephemeral (debug) containers, use with --details to show the container each one targets")
+	cmdStatus.Flags().BoolP("diff", "", false, "Compare two pods (pod-a pod-b) or two namespaces (ns-a/ ns-b/ or ns-a/prefix ns-b/prefix) and only list the values that are different")
+	cmdStatus.Flags().StringP("save", "", "", "Save the restart count and state of each container shown to this file, to be used later with --compare")
+	cmdStatus.Flags().StringP("compare", "", "", "Show the restarts and state changes of each container since the baseline file written by --save")
+	cmdStatus.Flags().BoolP("follow", "", false, "Keep checking the pods and print a timestamped line each time a container restarts or changes state")
+	cmdStatus.Flags().BoolP("single", "", false, "Print the complete status object of a single container exactly as the api returns it, rather than the table columns. Needs one pod name, -c and -o json")
+	cmdStatus.Flags().BoolP("wait-ready", "", false, "Wait until every container in the matching pods is ready then print the table, exits with code 7 if --timeout is reached first")
+	cmdStatus.Flags().DurationP("timeout", "", 5*time.Minute, "How long --wait-ready waits for the containers to be ready")
+	cmdStatus.Flags().DurationP("interval", "", 5*time.Second, "Time to wait between each check when using --follow or --wait-ready")
+	cmdStatus.Flags().IntP("max-restarts", "", 0, "Highlight containers that have restarted more than this many times, a * is added when the table has no colour")
 	cmdStatus.Flags().BoolP("tree", "t", false, treeShort)
+	cmdStatus.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
+	cmdStatus.Flags().BoolP("compact-tree", "", false, compactTreeShort)
 	cmdStatus.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdStatus.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	// TODO: check if I can add labels for service/replicaset/configmap etc.
 	addCommonFlags(cmdStatus)
 	rootCmd.AddCommand(cmdStatus)
@@ -376,6 +641,32 @@ func InitSubCommands(rootCmd *cobra.Command) {
 	}
 	rootCmd.AddCommand(cmdVersion)
 
+	// topology
+	var cmdTopology = &cobra.Command{
+		Use:     "topology",
+		Short:   topologyShort,
+		Long:    fmt.Sprintf("%s\n\n%s", topologyShort, topologyDescription),
+		Example: fmt.Sprintf(topologyExample, rootCmd.CommandPath()),
+		Aliases: []string{"topo", "zone", "zones"},
+		// SuggestFor: []string{""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := Topology(cmd, KubernetesConfigFlags, args); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+	KubernetesConfigFlags.AddFlags(cmdTopology.Flags())
+	cmdTopology.Flags().BoolP("oddities", "", false, odditiesShort)
+	cmdTopology.Flags().BoolP("explain-oddities", "", false, explainOdditiesShort)
+	cmdTopology.Flags().BoolP("tree", "t", false, treeShort)
+	cmdTopology.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
+	cmdTopology.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdTopology.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
+	addCommonFlags(cmdTopology)
+	rootCmd.AddCommand(cmdTopology)
+
 	// volumes
 	var cmdVolume = &cobra.Command{
 		Use:     "volumes",
@@ -393,34 +684,112 @@ func InitSubCommands(rootCmd *cobra.Command) {
 		},
 	}
 	KubernetesConfigFlags.AddFlags(cmdVolume.Flags())
-	cmdVolume.Flags().BoolP("device", "d", false, "show raw block device mappings within a container")
+	cmdVolume.Flags().BoolP("device", "d", false, "Only show the raw block devices within each container, the filesystem mounts are left out")
 	cmdVolume.Flags().BoolP("tree", "t", false, treeShort)
+	cmdVolume.Flags().BoolP("flatten-tree", "", false, flattenTreeShort)
 	cmdVolume.Flags().BoolP("node-tree", "", false, nodetreeShort)
+	cmdVolume.Flags().BoolP("no-tree-summary", "", false, noTreeSummaryShort)
 	addCommonFlags(cmdVolume)
 	rootCmd.AddCommand(cmdVolume)
 
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Flags().Lookup("contexts") != nil {
+			addContextsLoop(cmd, KubernetesConfigFlags)
+		}
+		// wrapped last so the preview is checked before the contexts loop
+		if cmd.Flags().Lookup("preview-selector") != nil {
+			addPreviewSelector(cmd, KubernetesConfigFlags)
+		}
+		// the defaults are set before any of the other wrappers read the flags
+		if cmd.RunE != nil {
+			addFlagDefaults(cmd)
+		}
+	}
+
 }
 
 // adds common flags to the passed command
 func addCommonFlags(cmdObj *cobra.Command) {
-	cmdObj.Flags().BoolP("all-namespaces", "A", false, "list containers form pods in all namespaces")
+	cmdObj.Flags().BoolP("all-namespaces", "A", false, "list containers form pods in all namespaces, pod names are looked up in every namespace")
+	cmdObj.Flags().StringSliceP("contexts", "", []string{}, `Run the same query against each of these kubeconfig contexts, repeat the flag or use a comma seperated list. The rows are shown together with a CONTEXT column, a context that cant be reached is skipped with a warning`)
+	cmdObj.Flags().BoolP("show-context", "", false, `Add a CONTEXT column with the name of the kubeconfig context, so saved output records which cluster it came from`)
+	cmdObj.Flags().IntP("concurrency", "", 0, `With -A list the pods one namespace at a time using this many requests in parallel, rather than a single cluster wide request`)
+	cmdObj.Flags().BoolP("strict", "", false, `With -A stop with an error when pods can not be listed from a namespace, by default forbidden namespaces are skipped with a warning`)
+	cmdObj.Flags().BoolP("cached-read", "", false, `Read pods from the api servers watch cache (resourceVersion=0), cheaper on large clusters but restart counts and states can be slightly behind. By default pods are read with a consistent (quorum) read`)
 	cmdObj.Flags().StringP("selector", "l", "", `Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2`)
-	cmdObj.Flags().StringP("container", "c", "", `Container name. If omitted show all containers in the pod`)
+	cmdObj.Flags().StringP("selector-file", "", "", "Read label selectors from a file, one per line, and list the pods that match any of them. Blank lines and lines starting with # are ignored")
+	cmdObj.Flags().StringSliceP("container", "c", []string{}, `Container name, repeat the flag or use a comma seperated list to match several containers. If omitted show all containers in the pod`)
+	cmdObj.Flags().StringSliceP("exclude-container", "", []string{}, `Container names to leave out of the output, repeat the flag or use a comma seperated list (e.g. istio-proxy,linkerd-proxy)`)
 	cmdObj.Flags().StringP("sort", "", "", `Sort by column`)
-	cmdObj.Flags().StringP("output", "o", "", `Output format, currently csv, list, json and yaml are supported`)
+	cmdObj.Flags().BoolP("sort-default-desc", "", false, `Sort the --sort columns in descending order, a ! in front of a column name then sorts that column ascending`)
+	cmdObj.Flags().StringP("output", "o", "", `Output format, currently csv, list, json, json-nested and yaml are supported`)
+	cmdObj.Flags().StringP("output-file", "", "", `Also write the output to files, comma seperated list of FORMAT=FILENAME where FORMAT is table or one of the -o formats (e.g. table=out.txt,json=out.json)`)
+	cmdObj.Flags().StringP("split-output-dir", "", "", `Write the output to one file per pod in this directory instead of printing it, each file is named namespace_podname with an extension to match -o`)
+	cmdObj.Flags().StringP("split-by", "", "pod", `How the rows are split into files with --split-output-dir, only pod is currently supported`)
+	cmdObj.Flags().StringP("template-file", "", "", `Render the output with a go template read from this file, the template is passed a list of rows with each row a map of column name to value`)
+	cmdObj.Flags().StringP("template-name", "", "", `Name of the {{define}} block in the --template-file to render, defaults to the whole file`)
+	cmdObj.Flags().StringP("rename", "", "", `Rename column headers in the output, comma seperated list of COLUMN=NEWNAME (e.g. RESTARTS=RST,CONTAINER=C)`)
+	cmdObj.Flags().BoolP("message-reflow", "", false, `Print the MESSAGE column wrapped on its own indented lines under each row in the table output, so the other columns stay lined up`)
+	cmdObj.Flags().IntP("truncate-names", "", 0, `Shorten the container names in the CONTAINER column, or the NAME column of the tree view, to this many characters in the table output, json and yaml output always contain the full name`)
+	cmdObj.Flags().StringP("style", "", "", `Table style to use when printing, one of default, compact or box`)
+	cmdObj.Flags().BoolP("no-headers", "", false, `Dont print the header line in the table and csv output, the column widths are worked out from the rows alone`)
+	cmdObj.Flags().BoolP("headless", "", false, `The same as --no-headers`)
+	cmdObj.Flags().BoolP("quiet", "q", false, `Dont print the number of matching containers to stderr after the table output`)
+	cmdObj.Flags().BoolP("symbols", "", false, `Show true and false as ✓ and ✗ in the table output, the json and yaml output is unchanged`)
+	cmdObj.Flags().BoolP("ascii", "", false, `Only use ascii characters for the tree view and --symbols (Y and N)`)
+	cmdObj.Flags().BoolP("count-only", "", false, `Only print the number of matching containers, use -o json to also include the number of pods`)
+	cmdObj.Flags().BoolP("error-on-empty", "", false, `Exit with code 4 when no containers match, by default an empty result is a success`)
+	cmdObj.Flags().BoolP("preview-selector", "", false, `Only print how many pods and containers the pod names, -l, -c and the other pod filters match, the table is not built. Use -o json for {"pods":N,"containers":M}`)
+	cmdObj.Flags().BoolP("compact", "", false, `Print -o json on a single line and each -o yaml row as a single line`)
+	cmdObj.Flags().BoolP("json-pretty", "", false, `Indent the -o json output`)
+	cmdObj.Flags().BoolP("with-metadata", "", false, `Add a metadata object to -o json holding the total, shown and hidden row counts along with the filters that were used`)
+	cmdObj.Flags().StringP("output-version", "", "", `Pin the json and yaml output to a layout version, defaults to the latest (currently `+outputVersions[len(outputVersions)-1]+`)`)
 	cmdObj.Flags().StringP("match", "m", "", `Filters out results, comma seperated list of COLUMN OP VALUE, where OP can be one of ==,<,>,<=,>= and != `)
 	cmdObj.Flags().StringP("match-only", "M", "", `Filters out results but only calculates up visible rows`)
+	cmdObj.Flags().StringSliceP("phase", "", []string{}, `Only include pods in the selected phase, repeat the flag or use a comma seperated list of Pending, Running, Succeeded, Failed and Unknown`)
+	cmdObj.Flags().StringSliceP("on-node", "", []string{}, `Only include pods scheduled to the named node, repeat the flag or use a comma seperated list to match several nodes`)
+	cmdObj.Flags().BoolP("include-terminated-pods", "", true, `Include pods that have finished (Succeeded or Failed), the default. Use --include-terminated-pods=false to leave out the pods from completed jobs`)
 	cmdObj.Flags().StringP("select", "", "", `Filters pods based on their spec field, comma seperated list of FIELD OP VALUE, where OP can be one of ==, = and != `)
 	cmdObj.Flags().BoolP("show-namespace", "", false, `Show the namespace column`)
 	cmdObj.Flags().BoolP("show-node", "", false, `Show the node name column`)
 	cmdObj.Flags().BoolP("show-type", "T", false, `Show the container type column, where:
     I=init container, C=container, E=ephemerial container, P=Pod, D=Deployment, R=ReplicaSet, A=DaemonSet, S=StatefulSet, N=Node`)
 	cmdObj.Flags().StringP("node-label", "", "", `Show the selected node label as a column`)
+	cmdObj.Flags().StringP("node-condition", "", "", `Show the status of the selected node condition as a column (e.g. MemoryPressure, DiskPressure, PIDPressure)`)
 	cmdObj.Flags().StringP("pod-label", "", "", `Show the selected pod label as a column`)
 	cmdObj.Flags().StringP("annotation", "", "", `Show the selected annotation as a column`)
+	cmdObj.Flags().BoolP("managed-by", "", false, `Show the app.kubernetes.io/managed-by label of the pod in the MANAGED-BY column and if it was created by kubectl apply in the APPLIED column`)
+	cmdObj.Flags().BoolP("show-uid", "", false, `Show the uid of the pod in the UID column, tells apart pods that were recreated with the same name when matching up audit logs and metrics`)
+	cmdObj.Flags().BoolP("show-scheduler", "", false, `Show the name of the scheduler the pod is assigned to in the SCHEDULER column, useful when pods are not being scheduled in clusters running more than one scheduler`)
+	cmdObj.Flags().StringP("order-from-annotation", "", "", `Order the containers of each pod by the comma seperated list of container names in the selected pod annotation, unlisted containers are shown last`)
 	cmdObj.Flags().StringP("filename", "f", "", `read pod information from this yaml file instead`)
 	cmdObj.Flags().StringP("columns", "", "", `list of column names to show in the table output, all other columns are hidden`)
 	cmdObj.Flags().StringP("color", "", "", `Add some much needed colour to the table output. string can be one of: columns, custom, errors, mix and none (overrides env variable ICE_COLOUR)`)
+	cmdObj.Flags().BoolP("wide", "", false, `Show the detail columns that are hidden by default along with the node name, --wide implies --show-node. Columns that need their own flag to be worked out stay hidden`)
+	cmdObj.Flags().BoolP("pick", "", false, `When more than one pod matches, list them and ask which to show. Only used when running in a terminal`)
+}
+
+// filterFlagNames are the flags that remove rows from the output, listed in the json metadata when they are set
+var filterFlagNames = []string{"selector", "selector-file", "container", "exclude-container", "match", "match-only", "select", "phase", "on-node", "image-filter", "image-tag", "include-terminated-pods", "oddities", "init-problems", "only-ephemeral"}
+
+// changedFlagValues returns the value of each named flag that was set on the command line, flags the command doesnt
+//
+//	have are ignored
+func changedFlagValues(cmd *cobra.Command, names []string) map[string]string {
+	values := make(map[string]string)
+	for _, name := range names {
+		flag := cmd.Flag(name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		if flag.Value.Type() == "stringSlice" {
+			// slices are shown as [a,b] so we drop the brackets to match what was typed
+			values[name] = strings.Trim(flag.Value.String(), "[]")
+		} else {
+			values[name] = flag.Value.String()
+		}
+	}
+	return values
 }
 
 func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
@@ -445,15 +814,44 @@ func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
 		}
 	}
 
+	if cmd.Flag("explain-oddities") != nil {
+		if cmd.Flag("explain-oddities").Value.String() == "true" {
+			if !f.showOddities {
+				return commonFlags{}, errors.New("--explain-oddities can only be used with --oddities")
+			}
+			f.explainOddities = true
+		}
+	}
+
 	if cmd.Flag("selector") != nil {
 		if len(cmd.Flag("selector").Value.String()) > 0 {
 			f.labels = cmd.Flag("selector").Value.String()
 		}
 	}
 
+	if cmd.Flag("selector-file") != nil {
+		if filename := cmd.Flag("selector-file").Value.String(); len(filename) > 0 {
+			if len(f.labels) > 0 {
+				return commonFlags{}, errors.New("--selector and --selector-file can not be used together")
+			}
+			f.labelsList, err = readSelectorFile(filename)
+			if err != nil {
+				return commonFlags{}, err
+			}
+		}
+	}
+
 	if cmd.Flag("container") != nil {
-		if len(cmd.Flag("container").Value.String()) > 0 {
-			f.container = cmd.Flag("container").Value.String()
+		f.container, err = getNameListFlag(cmd, "container")
+		if err != nil {
+			return commonFlags{}, err
+		}
+	}
+
+	if cmd.Flag("exclude-container") != nil {
+		f.excludeContainer, err = getNameListFlag(cmd, "exclude-container")
+		if err != nil {
+			return commonFlags{}, err
 		}
 	}
 
@@ -468,15 +866,290 @@ func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
 				f.outputAs = "list"
 			case "json":
 				f.outputAs = "json"
+			case "json-nested":
+				f.outputAs = "json-nested"
 			case "yaml":
 				f.outputAs = "yaml"
 
 			default:
-				return commonFlags{}, errors.New("unknown output format only csv, list, json and yaml are supported")
+				return commonFlags{}, errors.New("unknown output format only csv, list, json, json-nested and yaml are supported")
+			}
+		}
+	}
+
+	if cmd.Flag("template-file") != nil {
+		templateFile := cmd.Flag("template-file").Value.String()
+		templateName := cmd.Flag("template-name").Value.String()
+		if len(templateFile) > 0 {
+			if len(f.outputAs) > 0 {
+				return commonFlags{}, errors.New("--template-file can not be used with -o")
+			}
+			f.outputTemplate, err = readTemplateFile(templateFile, templateName)
+			if err != nil {
+				return commonFlags{}, err
+			}
+			f.outputAs = "template"
+		} else if len(templateName) > 0 {
+			return commonFlags{}, errors.New("--template-name can only be used with --template-file")
+		}
+	}
+
+	if cmd.Flag("output-file") != nil {
+		if len(cmd.Flag("output-file").Value.String()) > 0 {
+			f.outputFiles, err = splitOutputFileList(cmd.Flag("output-file").Value.String())
+			if err != nil {
+				return commonFlags{}, err
+			}
+		}
+	}
+
+	if cmd.Flag("split-output-dir") != nil {
+		if len(cmd.Flag("split-output-dir").Value.String()) > 0 {
+			if len(f.outputFiles) > 0 {
+				return commonFlags{}, errors.New("--split-output-dir can not be used with --output-file")
+			}
+			f.splitOutputDir = cmd.Flag("split-output-dir").Value.String()
+			f.splitBy = strings.ToLower(cmd.Flag("split-by").Value.String())
+			if _, ok := splitByColumns[f.splitBy]; !ok {
+				return commonFlags{}, fmt.Errorf("unknown --split-by value %s only pod is supported", f.splitBy)
+			}
+		} else if cmd.Flag("split-by").Changed {
+			return commonFlags{}, errors.New("--split-by can only be used with --split-output-dir")
+		}
+	}
+
+	if cmd.Flag("phase") != nil {
+		phaseList, err := getNameListFlag(cmd, "phase")
+		if err != nil {
+			return commonFlags{}, err
+		}
+		for _, phase := range phaseList {
+			switch strings.ToLower(phase) {
+			case "pending":
+				f.podPhase = append(f.podPhase, "Pending")
+			case "running":
+				f.podPhase = append(f.podPhase, "Running")
+			case "succeeded":
+				f.podPhase = append(f.podPhase, "Succeeded")
+			case "failed":
+				f.podPhase = append(f.podPhase, "Failed")
+			case "unknown":
+				f.podPhase = append(f.podPhase, "Unknown")
+			default:
+				return commonFlags{}, fmt.Errorf("unknown pod phase %s, only Pending, Running, Succeeded, Failed and Unknown are supported", phase)
+			}
+		}
+	}
+
+	if cmd.Flag("on-node") != nil {
+		f.onNodes, err = getNameListFlag(cmd, "on-node")
+		if err != nil {
+			return commonFlags{}, err
+		}
+	}
+
+	if cmd.Flag("image-filter") != nil {
+		f.imageFilter = cmd.Flag("image-filter").Value.String()
+		f.imageTag = cmd.Flag("image-tag").Value.String()
+
+		if cmd.Flag("image-regex").Value.String() == "true" {
+			if len(f.imageFilter) == 0 && len(f.imageTag) == 0 {
+				return commonFlags{}, errors.New("--image-regex can only be used with --image-filter or --image-tag")
+			}
+			f.imageRegex = true
+			if len(f.imageFilter) > 0 {
+				f.imageFilterRegex, err = regexp.Compile(f.imageFilter)
+				if err != nil {
+					return commonFlags{}, fmt.Errorf("invalid --image-filter regular expression: %w", err)
+				}
+			}
+			if len(f.imageTag) > 0 {
+				f.imageTagRegex, err = regexp.Compile(f.imageTag)
+				if err != nil {
+					return commonFlags{}, fmt.Errorf("invalid --image-tag regular expression: %w", err)
+				}
+			}
+		}
+	}
+
+	if cmd.Flag("include-terminated-pods") != nil {
+		if cmd.Flag("include-terminated-pods").Value.String() == "false" {
+			if len(f.podPhase) > 0 {
+				return commonFlags{}, errors.New("--phase can not be used with --include-terminated-pods=false")
+			}
+			// terminated pods are the ones in a final phase, everything else could still be running
+			f.podPhase = []string{"Pending", "Running", "Unknown"}
+		}
+	}
+
+	if cmd.Flag("rename") != nil {
+		if len(cmd.Flag("rename").Value.String()) > 0 {
+			f.renameColumns = make(map[string]string)
+			for _, item := range strings.Split(cmd.Flag("rename").Value.String(), ",") {
+				nameList := strings.SplitN(item, "=", 2)
+				if len(nameList) != 2 || len(strings.TrimSpace(nameList[0])) == 0 || len(strings.TrimSpace(nameList[1])) == 0 {
+					return commonFlags{}, fmt.Errorf("invalid rename \"%s\", expected COLUMN=NEWNAME", item)
+				}
+				column := strings.ToUpper(strings.TrimSpace(nameList[0]))
+				f.renameColumns[column] = strings.TrimSpace(nameList[1])
 			}
 		}
 	}
 
+	if cmd.Flag("cached-read") != nil {
+		if cmd.Flag("cached-read").Value.String() == "true" {
+			f.cachedRead = true
+		}
+	}
+
+	if cmd.Flag("strict") != nil {
+		if cmd.Flag("strict").Value.String() == "true" {
+			f.strict = true
+		}
+	}
+
+	if cmd.Flag("concurrency") != nil {
+		f.concurrency, err = cmd.Flags().GetInt("concurrency")
+		if err != nil {
+			return commonFlags{}, err
+		}
+		if f.concurrency < 0 {
+			return commonFlags{}, errors.New("--concurrency must be zero or more")
+		}
+		if f.concurrency > 0 && !f.allNamespaces {
+			return commonFlags{}, errors.New("--concurrency can only be used with -A")
+		}
+	}
+
+	if cmd.Flag("truncate-names") != nil {
+		f.truncateNames, err = cmd.Flags().GetInt("truncate-names")
+		if err != nil {
+			return commonFlags{}, err
+		}
+		if f.truncateNames < 0 || f.truncateNames == 1 {
+			return commonFlags{}, errors.New("--truncate-names must be at least 2 to leave room for the ellipsis")
+		}
+	}
+
+	if cmd.Flag("style") != nil {
+		switch strings.ToLower(cmd.Flag("style").Value.String()) {
+		case "", "default":
+			f.tableStyle = STYLE_DEFAULT
+		case "compact":
+			f.tableStyle = STYLE_COMPACT
+		case "box":
+			f.tableStyle = STYLE_BOX
+		default:
+			return commonFlags{}, errors.New("unknown table style only default, compact and box are supported")
+		}
+	}
+
+	if cmd.Flag("symbols") != nil {
+		if cmd.Flag("symbols").Value.String() == "true" {
+			f.showSymbols = true
+		}
+	}
+
+	if cmd.Flag("no-headers") != nil {
+		if cmd.Flag("no-headers").Value.String() == "true" || cmd.Flag("headless").Value.String() == "true" {
+			f.noHeaders = true
+		}
+	}
+
+	if cmd.Flag("quiet") != nil {
+		if cmd.Flag("quiet").Value.String() == "true" {
+			f.quiet = true
+		}
+	}
+
+	if cmd.Flag("ascii") != nil {
+		if cmd.Flag("ascii").Value.String() == "true" {
+			f.useASCII = true
+		}
+	}
+
+	if cmd.Flag("message-reflow") != nil {
+		if cmd.Flag("message-reflow").Value.String() == "true" {
+			f.messageReflow = true
+		}
+	}
+
+	if cmd.Flag("pick") != nil {
+		if cmd.Flag("pick").Value.String() == "true" {
+			f.pickPods = true
+		}
+	}
+
+	if cmd.Flag("count-only") != nil {
+		if cmd.Flag("count-only").Value.String() == "true" {
+			if len(f.outputFiles) > 0 {
+				return commonFlags{}, errors.New("--count-only can not be used with --output-file")
+			}
+			if f.outputTemplate != nil {
+				return commonFlags{}, errors.New("--count-only can not be used with --template-file")
+			}
+			if len(f.splitOutputDir) > 0 {
+				return commonFlags{}, errors.New("--count-only can not be used with --split-output-dir")
+			}
+			f.countOnly = true
+		}
+	}
+
+	if cmd.Flag("error-on-empty") != nil {
+		if cmd.Flag("error-on-empty").Value.String() == "true" {
+			f.errorOnEmpty = true
+		}
+	}
+
+	if cmd.Flag("compact") != nil {
+		if cmd.Flag("compact").Value.String() == "true" {
+			if f.outputAs != "json" && f.outputAs != "json-nested" && f.outputAs != "yaml" {
+				return commonFlags{}, errors.New("--compact can only be used with -o json, json-nested or yaml")
+			}
+			f.outputLayout = LAYOUT_COMPACT
+		}
+	}
+
+	if cmd.Flag("json-pretty") != nil {
+		if cmd.Flag("json-pretty").Value.String() == "true" {
+			if f.outputLayout == LAYOUT_COMPACT {
+				return commonFlags{}, errors.New("you may not use the compact and json-pretty flags together")
+			}
+			if f.outputAs != "json" && f.outputAs != "json-nested" {
+				return commonFlags{}, errors.New("--json-pretty can only be used with -o json or json-nested")
+			}
+			f.outputLayout = LAYOUT_PRETTY
+		}
+	}
+
+	if cmd.Flag("with-metadata") != nil {
+		if cmd.Flag("with-metadata").Value.String() == "true" {
+			if f.outputAs != "json" {
+				return commonFlags{}, errors.New("--with-metadata can only be used with -o json")
+			}
+			f.withMetadata = true
+			f.activeFilters = changedFlagValues(cmd, filterFlagNames)
+		}
+	}
+
+	// always default to the latest layout
+	f.outputVersion = outputVersions[len(outputVersions)-1]
+	if cmd.Flag("output-version") != nil {
+		if len(cmd.Flag("output-version").Value.String()) > 0 {
+			version := strings.ToLower(cmd.Flag("output-version").Value.String())
+			found := false
+			for _, v := range outputVersions {
+				if v == version {
+					found = true
+				}
+			}
+			if !found {
+				return commonFlags{}, fmt.Errorf("unknown output version, supported versions are %s", strings.Join(outputVersions, ", "))
+			}
+			f.outputVersion = version
+		}
+	}
+
 	if cmd.Flag("size") != nil {
 		if len(cmd.Flag("size").Value.String()) > 0 {
 			f.byteSize = cmd.Flag("size").Value.String()
@@ -495,6 +1168,12 @@ func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
 		}
 	}
 
+	if cmd.Flag("sort-default-desc") != nil {
+		if cmd.Flag("sort-default-desc").Value.String() == "true" {
+			f.sortList = invertSortList(f.sortList)
+		}
+	}
+
 	rawMatchString := ""
 	if cmd.Flag("match") != nil {
 		if len(cmd.Flag("match").Value.String()) > 0 {
@@ -514,9 +1193,26 @@ func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
 		}
 	}
 
+	if cmd.Flag("flatten-tree") != nil {
+		if cmd.Flag("flatten-tree").Value.String() == "true" {
+			f.flattenTree = true
+			f.showTreeView = true
+		}
+	}
+
+	if cmd.Flag("compact-tree") != nil {
+		if cmd.Flag("compact-tree").Value.String() == "true" {
+			if len(f.sortList) != 0 && !f.flattenTree {
+				return commonFlags{}, errors.New("you may not use the compact-tree and sort flags together")
+			}
+			f.compactTree = true
+			f.showTreeView = true
+		}
+	}
+
 	if cmd.Flag("tree") != nil {
 		if cmd.Flag("tree").Value.String() == "true" {
-			if len(f.sortList) != 0 {
+			if len(f.sortList) != 0 && !f.flattenTree {
 				return commonFlags{}, errors.New("you may not use the tree and sort flags together")
 			}
 			f.showTreeView = true
@@ -525,13 +1221,27 @@ func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
 
 	if cmd.Flag("node-tree") != nil {
 		if cmd.Flag("node-tree").Value.String() == "true" {
-			if len(f.sortList) != 0 {
+			if len(f.sortList) != 0 && !f.flattenTree {
 				return commonFlags{}, errors.New("you may not use the node-tree and sort flags together")
 			}
 			f.showNodeTree = true
 			f.showTreeView = true
 		}
 	}
+
+	if f.showTreeView && f.outputAs == "json-nested" {
+		return commonFlags{}, errors.New("json-nested output groups the rows by pod name so can not be used with the tree view")
+	}
+
+	if f.showTreeView && len(f.splitOutputDir) > 0 {
+		return commonFlags{}, errors.New("--split-output-dir groups the rows by pod name so can not be used with the tree view")
+	}
+
+	if cmd.Flag("no-tree-summary") != nil {
+		if cmd.Flag("no-tree-summary").Value.String() == "true" {
+			f.hideTreeSummary = true
+		}
+	}
 	if cmd.Flag("select") != nil {
 		if len(cmd.Flag("select").Value.String()) > 0 {
 			rawFilterString := cmd.Flag("select").Value.String()
@@ -554,11 +1264,20 @@ func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
 		f.showContainerType = true
 	}
 
+	if cmd.Flag("wide").Value.String() == "true" {
+		f.showWide = true
+		f.showNodeName = true
+	}
+
 	if cmd.Flag("node-label").Value.String() != "" {
 		label := cmd.Flag("node-label").Value.String()
 		f.labelNodeName = label
 	}
 
+	if cmd.Flag("node-condition").Value.String() != "" {
+		f.conditionNodeName = cmd.Flag("node-condition").Value.String()
+	}
+
 	if cmd.Flag("pod-label").Value.String() != "" {
 		label := cmd.Flag("pod-label").Value.String()
 		f.labelPodName = label
@@ -569,6 +1288,22 @@ func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
 		f.annotationPodName = annotation
 	}
 
+	if cmd.Flag("managed-by").Value.String() == "true" {
+		f.showManagedBy = true
+	}
+
+	if cmd.Flag("show-uid").Value.String() == "true" {
+		f.showPodUID = true
+	}
+
+	if cmd.Flag("show-scheduler").Value.String() == "true" {
+		f.showScheduler = true
+	}
+
+	if cmd.Flag("order-from-annotation").Value.String() != "" {
+		f.orderAnnotation = cmd.Flag("order-from-annotation").Value.String()
+	}
+
 	if cmd.Flag("filename").Value.String() != "" {
 		inputFilename := cmd.Flag("filename").Value.String()
 		f.inputFilename = inputFilename
@@ -616,9 +1351,144 @@ func processCommonFlags(cmd *cobra.Command) (commonFlags, error) {
 		}
 	}
 
+	f.contextResults = contextResultsFrom(cmd)
+
 	return f, nil
 }
 
+// getNameListFlag returns the trimmed list of names passed to a flag that can be repeated or given a comma seperated list
+func getNameListFlag(cmd *cobra.Command, flagName string) ([]string, error) {
+	var nameList []string
+
+	rawList, err := cmd.Flags().GetStringSlice(flagName)
+	if err != nil {
+		return []string{}, err
+	}
+
+	for _, name := range rawList {
+		name = strings.TrimSpace(name)
+		if len(name) > 0 {
+			nameList = append(nameList, name)
+		}
+	}
+
+	return nameList, nil
+}
+
+// readTemplateFile parses the go template in filename, when templateName is set the {{define}} block with that name is
+//
+//	returned so a single file can hold a library of templates. missing columns are reported as an error rather than
+//	printing <no value> so a typo in a column name is found straight away
+func readTemplateFile(filename string, templateName string) (*template.Template, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template file: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(filename)).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template file: %w", err)
+	}
+
+	if len(templateName) == 0 {
+		return tmpl, nil
+	}
+
+	named := tmpl.Lookup(templateName)
+	if named == nil {
+		return nil, fmt.Errorf("template %q not found in %s%s", templateName, filename, tmpl.DefinedTemplates())
+	}
+
+	return named, nil
+}
+
+// readSelectorFile returns the label selectors listed in filename, one per line. blank lines and lines starting with #
+//
+//	are skipped and each selector is checked so a typo is reported with its line number rather than by the api
+func readSelectorFile(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return []string{}, fmt.Errorf("unable to read selector file: %w", err)
+	}
+
+	return parseSelectorList(string(data), filename)
+}
+
+// parseSelectorList splits the contents of a selector file into a list of label selectors
+func parseSelectorList(data string, filename string) ([]string, error) {
+	var selectorList []string
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if _, err := labels.Parse(line); err != nil {
+			return []string{}, fmt.Errorf("%s line %d: invalid selector %q: %w", filename, i+1, line, err)
+		}
+		selectorList = append(selectorList, line)
+	}
+
+	if len(selectorList) == 0 {
+		return []string{}, fmt.Errorf("no selectors found in %s", filename)
+	}
+
+	return selectorList, nil
+}
+
+// splitOutputFileList parses the comma seperated FORMAT=FILENAME pairs passed to --output-file
+func splitOutputFileList(rawList string) ([]outputFile, error) {
+	var fileList []outputFile
+	seen := make(map[string]bool)
+
+	for _, item := range strings.Split(rawList, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+
+		format, filename, found := strings.Cut(item, "=")
+		format = strings.ToLower(strings.TrimSpace(format))
+		filename = strings.TrimSpace(filename)
+		if !found || len(filename) == 0 {
+			return []outputFile{}, fmt.Errorf("invalid output file %q, expected FORMAT=FILENAME", item)
+		}
+
+		switch format {
+		case "table", "csv", "list", "json", "json-nested", "yaml":
+		default:
+			return []outputFile{}, fmt.Errorf("unknown output file format %s only table, csv, list, json, json-nested and yaml are supported", format)
+		}
+
+		if seen[filename] {
+			return []outputFile{}, fmt.Errorf("%s is used more than once in --output-file", filename)
+		}
+		seen[filename] = true
+
+		fileList = append(fileList, outputFile{format: format, filename: filename})
+	}
+
+	return fileList, nil
+}
+
+// invertSortList swaps the direction of each sort key, keys without a ! become descending and keys starting with ! become
+//
+//	ascending. the table only understands ! as descending so the toggle is applied before the keys reach it
+func invertSortList(sortList []string) []string {
+	inverted := make([]string, 0, len(sortList))
+
+	for _, name := range sortList {
+		if strings.HasPrefix(name, "!") {
+			inverted = append(inverted, name[1:])
+		} else {
+			inverted = append(inverted, "!"+name)
+		}
+	}
+
+	return inverted
+}
+
 func splitAndFilterList(rawSortString string, filterString string) ([]string, error) {
 	// based on a whitelist approach sort just removes invalid chars,
 	// we cant check header names as we dont know them at this point
@@ -0,0 +1,294 @@
+package plugin
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// *****************
+// parseSelectorList
+// *****************
+type parseSelectorListTest struct {
+	data        string
+	expected    []string
+	expectError bool
+}
+
+var parseSelectorListTests = []parseSelectorListTest{
+	{"app=web\napp=mail\n", []string{"app=web", "app=mail"}, false},
+	{"# payment services\n\n  tier=payments,env!=dev  \n\t\napp in (web,api)\n", []string{"tier=payments,env!=dev", "app in (web,api)"}, false},
+	{"app=web\r\napp=mail\r\n", []string{"app=web", "app=mail"}, false},
+	{"app=web\napp==in(\n", nil, true},
+	{"# nothing here\n\n", nil, true},
+	{"", nil, true},
+}
+
+func TestParseSelectorList(t *testing.T) {
+
+	for _, test := range parseSelectorListTests {
+		output, err := parseSelectorList(test.data, "selectors.txt")
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error for %q", test.data)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		} else if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// all-namespaces
+// *****************
+func TestAllNamespacesFlag(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "kubectl-ice"}
+	InitSubCommands(rootCmd)
+
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "completion" {
+			continue
+		}
+
+		flag := cmd.Flags().Lookup("all-namespaces")
+		if flag == nil || flag.Shorthand != "A" {
+			t.Errorf("%s: -A/--all-namespaces is not registered", cmd.Name())
+			continue
+		}
+
+		for _, args := range [][]string{{"-A"}, {"--all-namespaces"}} {
+			flag.Changed = false
+			if err := flag.Value.Set("false"); err != nil {
+				t.Fatal(err)
+			}
+			if err := cmd.ParseFlags(args); err != nil {
+				t.Fatalf("%s: unexpected error %v", cmd.Name(), err)
+			}
+
+			f, err := processCommonFlags(cmd)
+			if err != nil {
+				t.Errorf("%s %v: unexpected error %v", cmd.Name(), args, err)
+				continue
+			}
+			if !f.allNamespaces || !f.showNamespaceName {
+				t.Errorf("%s %v: allNamespaces %t showNamespaceName %t, expected both to be true", cmd.Name(), args, f.allNamespaces, f.showNamespaceName)
+			}
+		}
+	}
+
+}
+
+// *****************
+// splitOutputFileList
+// *****************
+type splitOutputFileListTest struct {
+	rawList     string
+	expected    []outputFile
+	expectError bool
+}
+
+var splitOutputFileListTests = []splitOutputFileListTest{
+	{"table=out.txt,json=out.json", []outputFile{{"table", "out.txt"}, {"json", "out.json"}}, false},
+	{" YAML = report.yaml ,", []outputFile{{"yaml", "report.yaml"}}, false},
+	{"json-nested=a=b.json", []outputFile{{"json-nested", "a=b.json"}}, false},
+	{"xml=out.xml", nil, true},
+	{"out.txt", nil, true},
+	{"json=", nil, true},
+	{"json=out,table=out", nil, true},
+}
+
+func TestSplitOutputFileList(t *testing.T) {
+
+	for _, test := range splitOutputFileListTests {
+		output, err := splitOutputFileList(test.rawList)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error for %q", test.rawList)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		} else if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// readTemplateFile
+// *****************
+type readTemplateFileTest struct {
+	name     string
+	expected string
+	err      bool
+}
+
+var readTemplateFileTests = []readTemplateFileTest{
+	{"", "header\n", false},
+	{"names", "web-1/web\nweb-2/proxy\n", false},
+	{"restarts", "web-2 4\n", false},
+	{"missing", "", true},
+	// column names are checked when the template runs
+	{"typo", "", true},
+	// hidden rows and columns are left out
+	{"hidden", "web-1 2\nweb-2 2\n", false},
+}
+
+func TestReadTemplateFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "report.tmpl")
+	library := `{{define "names"}}{{range .}}{{.PODNAME}}/{{.CONTAINER}}
+{{end}}{{end}}{{define "restarts"}}{{range .}}{{if ne .RESTARTS "0"}}{{.PODNAME}} {{.RESTARTS}}
+{{end}}{{end}}{{end}}{{define "typo"}}{{range .}}{{.PODNAM}}{{end}}{{end}}{{define "hidden"}}{{range .}}{{.PODNAME}} {{len .}}
+{{end}}{{end}}header
+`
+	if err := os.WriteFile(filename, []byte(library), 0644); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	tbl := Table{}
+	tbl.SetHeader("PODNAME", "CONTAINER", "RESTARTS")
+	tbl.AddRow(NewCellText("web-1"), NewCellText("web"), NewCellInt("0", 0))
+	tbl.AddRow(NewCellText("web-2"), NewCellText("proxy"), NewCellInt("4", 4))
+
+	hiddenTbl := Table{}
+	hiddenTbl.SetHeader("PODNAME", "CONTAINER", "RESTARTS")
+	hiddenTbl.AddRow(NewCellText("web-1"), NewCellText("web"), NewCellInt("0", 0))
+	hiddenTbl.AddRow(NewCellText("web-1"), NewCellText("init"), NewCellInt("2", 2))
+	hiddenTbl.AddRow(NewCellText("web-2"), NewCellText("proxy"), NewCellInt("4", 4))
+	hiddenTbl.HideRows([]int{1})
+	hiddenTbl.HideColumn(2)
+
+	for _, test := range readTemplateFileTests {
+		tbl := tbl
+		if test.name == "hidden" {
+			tbl = hiddenTbl
+		}
+
+		tmpl, err := readTemplateFile(filename, test.name)
+		if err == nil {
+			out := bytes.Buffer{}
+			tbl.Out = &out
+			err = tbl.PrintTemplate(tmpl)
+			if output := out.String(); output != test.expected {
+				t.Errorf("Output %q not equal to expected %q", output, test.expected)
+			}
+		}
+		if (err != nil) != test.err {
+			t.Errorf("template %q returned error %v, expected error %t", test.name, err, test.err)
+		}
+	}
+
+}
+
+// *****************
+// include-terminated-pods
+// *****************
+type includeTerminatedPodsTest struct {
+	args        []string
+	expected    []string
+	expectError bool
+}
+
+var includeTerminatedPodsTests = []includeTerminatedPodsTest{
+	// terminated pods are included by default
+	{[]string{}, nil, false},
+	{[]string{"--include-terminated-pods"}, nil, false},
+	{[]string{"--include-terminated-pods=false"}, []string{"Pending", "Running", "Unknown"}, false},
+	{[]string{"--phase", "Succeeded"}, []string{"Succeeded"}, false},
+	{[]string{"--include-terminated-pods=false", "--phase", "Running"}, nil, true},
+}
+
+func TestIncludeTerminatedPodsFlag(t *testing.T) {
+
+	for _, test := range includeTerminatedPodsTests {
+		cmd := &cobra.Command{Use: "status"}
+		addCommonFlags(cmd)
+		if err := cmd.ParseFlags(test.args); err != nil {
+			t.Fatalf("%v: unexpected error %v", test.args, err)
+		}
+
+		f, err := processCommonFlags(cmd)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("%v: expected an error", test.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.args, err)
+		} else if !reflect.DeepEqual(f.podPhase, test.expected) {
+			t.Errorf("%v: Output %v not equal to expected %v", test.args, f.podPhase, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// cached-read
+// *****************
+type cachedReadTest struct {
+	args     []string
+	expected bool
+}
+
+var cachedReadTests = []cachedReadTest{
+	// pods are read with a consistent read unless the cache is asked for
+	{[]string{}, false},
+	{[]string{"--cached-read"}, true},
+	{[]string{"--cached-read=false"}, false},
+}
+
+func TestCachedReadFlag(t *testing.T) {
+
+	for _, test := range cachedReadTests {
+		cmd := &cobra.Command{Use: "status"}
+		addCommonFlags(cmd)
+		if err := cmd.ParseFlags(test.args); err != nil {
+			t.Fatalf("%v: unexpected error %v", test.args, err)
+		}
+
+		f, err := processCommonFlags(cmd)
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", test.args, err)
+		}
+		if f.cachedRead != test.expected {
+			t.Errorf("%v: Output %t not equal to expected %t", test.args, f.cachedRead, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// invertSortList
+// *****************
+type invertSortListTest struct {
+	sortList []string
+	expected []string
+}
+
+var invertSortListTests = []invertSortListTest{
+	{[]string{"RESTARTS"}, []string{"!RESTARTS"}},
+	{[]string{"RESTARTS", "!PODNAME"}, []string{"!RESTARTS", "PODNAME"}},
+	{[]string{}, []string{}},
+}
+
+func TestInvertSortList(t *testing.T) {
+
+	for _, test := range invertSortListTests {
+		if output := invertSortList(test.sortList); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
@@ -100,8 +100,7 @@ func Ports(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	return outputTableAs(table, commonFlagList)
 
 }
 
@@ -125,7 +124,7 @@ func (s *ports) BuildEphemeralContainerStatus(container v1.ContainerStatus, info
 }
 
 func (s *ports) HideColumns(info BuilderInformation) []int {
-	if s.ShowIPAddress {
+	if s.ShowIPAddress || (info.Wide && !s.DontListContainers) {
 		return []int{}
 	}
 	if s.DontListContainers {
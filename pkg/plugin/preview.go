@@ -0,0 +1,113 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// addPreviewSelector wraps the commands RunE so --preview-selector only lists the pods and prints how many pods and
+//
+//	containers the selector flags match, the table is never built. without the flag the command is run as normal
+func addPreviewSelector(cmdObj *cobra.Command, kubeFlags *genericclioptions.ConfigFlags) {
+	run := cmdObj.RunE
+
+	cmdObj.RunE = func(cmd *cobra.Command, args []string) error {
+		if cmd.Flag("preview-selector").Value.String() != "true" {
+			return run(cmd, args)
+		}
+
+		return previewSelector(cmd, kubeFlags, args, os.Stdout)
+	}
+}
+
+// previewSelector loads the pods selected by the pod names, -l, --selector-file, --phase, --on-node and --select
+//
+//	then counts the pods with at least one container left after -c and --exclude-container
+func previewSelector(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []string, out io.Writer) error {
+	log := logger{location: "previewSelector"}
+	log.Debug("Start")
+
+	if len(cmd.Flag("filename").Value.String()) > 0 {
+		return errors.New("--preview-selector can only be used with live pod data, it can not be combined with a file")
+	}
+	if cmd.Flag("contexts").Changed || cmd.Flag("show-context").Value.String() == "true" {
+		return errors.New("--preview-selector can not be used with --contexts or --show-context")
+	}
+
+	connect := Connector{}
+	if err := connect.LoadConfig(kubeFlags); err != nil {
+		return err
+	}
+
+	commonFlagList, err := processCommonFlags(cmd)
+	if err != nil {
+		return err
+	}
+	connect.Flags = commonFlagList
+
+	podList, err := connect.GetPods(args)
+	if err != nil {
+		return err
+	}
+
+	pods, containers := countPreview(podList, commonFlagList)
+
+	switch commonFlagList.outputAs {
+	case "json", "json-nested":
+		fmt.Fprintf(out, "{\"pods\":%d,\"containers\":%d}\n", pods, containers)
+	case "csv":
+		fmt.Fprintln(out, "\"pods\", \"containers\"")
+		fmt.Fprintf(out, "\"%d\", \"%d\"\n", pods, containers)
+	case "list", "yaml":
+		fmt.Fprintln(out, "pods:", pods)
+		fmt.Fprintln(out, "containers:", containers)
+	default:
+		fmt.Fprintf(out, "%d pods and %d containers match\n", pods, containers)
+	}
+
+	// the same exit code as --count-only so scripts can tell when nothing was matched
+	if commonFlagList.errorOnEmpty && containers == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
+
+// countPreview returns the number of pods with a container that passes the container name and image flags and the
+//
+//	number of those containers, init and ephemeral containers are included
+func countPreview(podList []v1.Pod, flags commonFlags) (int, int) {
+	var pods, containers int
+
+	for _, pod := range podList {
+		names := []string{}
+		for _, container := range pod.Spec.InitContainers {
+			names = append(names, container.Name)
+		}
+		for _, container := range pod.Spec.Containers {
+			names = append(names, container.Name)
+		}
+		for _, container := range pod.Spec.EphemeralContainers {
+			names = append(names, container.Name)
+		}
+
+		matched := 0
+		for _, name := range names {
+			if !skipContainer(flags, pod, name) {
+				matched++
+			}
+		}
+
+		if matched > 0 {
+			pods++
+			containers += matched
+		}
+	}
+
+	return pods, containers
+}
@@ -0,0 +1,44 @@
+package plugin
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// *****************
+// countPreview
+// *****************
+type countPreviewTest struct {
+	flags      commonFlags
+	pods       int
+	containers int
+}
+
+var countPreviewTests = []countPreviewTest{
+	{commonFlags{}, 2, 5},
+	{commonFlags{container: []string{"web"}}, 2, 2},
+	{commonFlags{container: []string{"db"}}, 1, 1},
+	{commonFlags{container: []string{"cache"}}, 0, 0},
+	{commonFlags{excludeContainer: []string{"proxy", "init"}}, 2, 3},
+}
+
+func TestCountPreview(t *testing.T) {
+	podList := []v1.Pod{
+		{Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init"}},
+			Containers:     []v1.Container{{Name: "web"}, {Name: "proxy"}},
+		}},
+		{Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "web"}, {Name: "db"}},
+		}},
+	}
+
+	for _, test := range countPreviewTests {
+		pods, containers := countPreview(podList, test.flags)
+		if pods != test.pods || containers != test.containers {
+			t.Errorf("Output %d pods %d containers not equal to expected %d pods %d containers (flags %+v)", pods, containers, test.pods, test.containers, test.flags)
+		}
+	}
+
+}
@@ -0,0 +1,209 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var priorityShort = "List the priority and preemption policy of each pod"
+
+var priorityDescription = ` Prints the priority, priority class and preemption policy of each pod. When a node is under pressure
+the pods with the lowest priority are evicted first, so a pod that keeps being evicted or preempted is often
+running at a lower priority than expected. Pods are grouped into workloads by their name with the generated
+suffix removed, BELOW-WORKLOAD is how far the pod is below the priority used by most of its workload. Use
+--oddities to only show those pods. If no name is specified the priority of all pods in the current
+namespace are shown.`
+
+var priorityExample = `  # List the priority of each pod
+  %[1]s priority
+
+  # List the priority of each pod output in JSON format
+  %[1]s priority -o json
+
+  # List the priority of a single pod
+  %[1]s priority my-pod-4jh36
+
+  # List the priority of all pods in all namespaces, lowest priority first
+  %[1]s priority -A --sort PRIORITY
+
+  # List only the pods that have a lower priority than the rest of their workload
+  %[1]s priority --oddities
+
+  # List the priority of all pods where label app matches web
+  %[1]s priority -l app=web`
+
+func Priority(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []string) error {
+	log := logger{location: "Priority"}
+	log.Debug("Start")
+
+	loopinfo := priority{}
+	builder := RowBuilder{}
+	builder.DontListContainers = true
+	builder.PodName = args
+
+	connect := Connector{}
+	if err := connect.LoadConfig(kubeFlags); err != nil {
+		return err
+	}
+
+	commonFlagList, err := processCommonFlags(cmd)
+	if err != nil {
+		return err
+	}
+	connect.Flags = commonFlagList
+	builder.Connection = &connect
+	builder.SetFlagsFrom(commonFlagList)
+
+	table := Table{}
+	table.ColourOutput = commonFlagList.outputAsColour
+	table.CustomColours = commonFlagList.useTheseColours
+
+	builder.Table = &table
+	builder.ShowTreeView = commonFlagList.showTreeView
+
+	if err := builder.Build(&loopinfo); err != nil {
+		return err
+	}
+
+	if err := table.SortByNames(commonFlagList.sortList...); err != nil {
+		return err
+	}
+
+	// the odd pods are the ones below the rest of their workload, rather than outside the range of the whole table
+	if commonFlagList.showOddities && !builder.ShowTreeView {
+		var row2Remove []int
+		for idx, row := range table.GetRows() {
+			if row[builder.DefaultHeaderLen+3].number == 0 { // 3 = below-workload column
+				row2Remove = append(row2Remove, idx)
+			}
+		}
+		table.HideRows(row2Remove)
+	}
+
+	return outputTableAs(table, commonFlagList)
+
+}
+
+type priority struct {
+	belowWorkload map[string]int64 // worked out for every pod by SetPodList, keyed by namespace/podname
+}
+
+// SetPodList compares each pod with the rest of its workload before the rows are built
+func (s *priority) SetPodList(podList []v1.Pod) {
+	s.belowWorkload = priorityBelowWorkload(podList)
+}
+
+func (s *priority) Headers() []string {
+	return []string{
+		"PRIORITY", "PRIORITY-CLASS", "PREEMPTION", "BELOW-WORKLOAD",
+	}
+}
+
+func (s *priority) BuildContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *priority) BuildEphemeralContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *priority) HideColumns(info BuilderInformation) []int {
+	return []int{}
+}
+
+func (s *priority) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
+	out := []Cell{
+		NewCellInt("", 0),
+		NewCellText(""),
+		NewCellText(""),
+		NewCellInt("", 0),
+	}
+	return out, nil
+}
+
+func (s *priority) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *priority) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *priority) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{s.priorityBuildRow(pod, s.belowWorkload[pod.Namespace+"/"+pod.Name])}, nil
+}
+
+func (s *priority) priorityBuildRow(pod v1.Pod, below int64) []Cell {
+	priorityCell := NewCellInt("", 0)
+	if pod.Spec.Priority != nil {
+		priorityCell = NewCellInt(fmt.Sprintf("%d", *pod.Spec.Priority), int64(*pod.Spec.Priority))
+	}
+
+	preemption := ""
+	if pod.Spec.PreemptionPolicy != nil {
+		preemption = string(*pod.Spec.PreemptionPolicy)
+	}
+
+	belowText := ""
+	belowColour := colourOk
+	if pod.Spec.Priority != nil {
+		belowText = fmt.Sprintf("%d", below)
+		if below > 0 {
+			belowColour = colourBad
+		}
+	}
+
+	return []Cell{
+		priorityCell,
+		NewCellText(pod.Spec.PriorityClassName),
+		NewCellText(preemption),
+		NewCellColourInt(belowColour, belowText, below),
+	}
+}
+
+// priorityBelowWorkload groups the pods into workloads by namespace and podNamePrefix and returns how far each pod is
+//
+//	below the priority used by most of the pods in its workload, pods at or above it are 0. when two priorities are
+//	used by the same number of pods the higher one wins. the result is keyed by namespace/podname
+func priorityBelowWorkload(podList []v1.Pod) map[string]int64 {
+	out := make(map[string]int64)
+	counts := make(map[string]map[int32]int)
+
+	for _, pod := range podList {
+		if pod.Spec.Priority == nil {
+			continue
+		}
+		workload := pod.Namespace + "/" + podNamePrefix(pod)
+		if counts[workload] == nil {
+			counts[workload] = make(map[int32]int)
+		}
+		counts[workload][*pod.Spec.Priority]++
+	}
+
+	typical := make(map[string]int32)
+	for workload, priorityCount := range counts {
+		best := 0
+		for value, count := range priorityCount {
+			if count > best || (count == best && value > typical[workload]) {
+				best = count
+				typical[workload] = value
+			}
+		}
+	}
+
+	for _, pod := range podList {
+		if pod.Spec.Priority == nil {
+			continue
+		}
+		below := int64(typical[pod.Namespace+"/"+podNamePrefix(pod)]) - int64(*pod.Spec.Priority)
+		if below < 0 {
+			below = 0
+		}
+		out[pod.Namespace+"/"+pod.Name] = below
+	}
+
+	return out
+}
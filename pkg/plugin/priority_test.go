@@ -0,0 +1,58 @@
+package plugin
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// *****************
+// priorityBelowWorkload
+// *****************
+type priorityBelowWorkloadTest struct {
+	podName  string
+	priority *int32
+	expected int64
+}
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+var priorityBelowWorkloadTests = []priorityBelowWorkloadTest{
+	// one web replica was created before the priority class was changed
+	{"web-7d4b9c-aaaaa", int32Ptr(1000), 0},
+	{"web-7d4b9c-bbbbb", int32Ptr(1000), 0},
+	{"web-7d4b9c-ccccc", int32Ptr(10), 990},
+	// a tie goes to the higher priority
+	{"api-5f6c7d-aaaaa", int32Ptr(500), 0},
+	{"api-5f6c7d-bbbbb", int32Ptr(100), 400},
+	// pods without a priority arent compared
+	{"db-0", nil, 0},
+	{"batch-x9k2m", int32Ptr(-10), 0},
+}
+
+func TestPriorityBelowWorkload(t *testing.T) {
+	podList := []v1.Pod{}
+	for _, test := range priorityBelowWorkloadTests {
+		pod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: test.podName, Namespace: "default"},
+			Spec:       v1.PodSpec{Priority: test.priority},
+		}
+		if len(test.podName) == len("web-7d4b9c-aaaaa") {
+			pod.GenerateName = test.podName[:len(test.podName)-5]
+			pod.Labels = map[string]string{"pod-template-hash": test.podName[4 : len(test.podName)-6]}
+		}
+		podList = append(podList, pod)
+	}
+
+	below := priorityBelowWorkload(podList)
+
+	for _, test := range priorityBelowWorkloadTests {
+		if output := below["default/"+test.podName]; output != test.expected {
+			t.Errorf("Output %d not equal to expected %d for %s", output, test.expected, test.podName)
+		}
+	}
+
+}
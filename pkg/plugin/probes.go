@@ -1,11 +1,14 @@
 package plugin
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
+	duration "k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -14,7 +17,16 @@ var probesShort = "Shows details of configured startup, readiness and liveness p
 var probesDescription = ` Prints details of the currently configured startup, liveness and readiness probes for each 
 container. Details like the delay timeout and action are printed along with the configured probe
 type. If no name is specified the container probe details of all pods in the current namespace
-are shown.`
+are shown.
+
+The --details flag adds an AGE column with how long each container has been running, startup probe rows
+are shown as a warning while the container is still inside the time its startup probe allows
+(delay + period x failure threshold).
+
+The --health flag adds a HEALTHY column with whether each probe is passing right now, worked out from the
+containers ready and started state along with the probe failure events of the pod. Healthy and Unhealthy are
+only shown when the state or a recent failure event proves it, everything else is Unknown. A liveness probe
+can only be shown as Healthy when the events can be read, so pods read from a file are always Unknown.`
 
 var probesExample = `  # List containers probe info from pods
   %[1]s probes
@@ -37,6 +49,19 @@ var probesExample = `  # List containers probe info from pods
   # namespace sorted by pod name in ascending order
   %[1]s probes -c web-container --sort PODNAME
 
+  # List container probe info along with how long each container has been running, to see which
+  # containers are still inside their startup probe window
+  %[1]s probes --details
+
+  # List container probe info along with whether each probe is passing right now
+  %[1]s probes --health
+
+  # List container probe info and warn about probes with suspicious settings
+  %[1]s probes --lint
+
+  # Check the probes in a rendered manifest and exit with a non zero exit code if any of them have problems
+  %[1]s probes --lint --fail-on-warn -f manifest.yaml
+
   # List container probe info from all pods where label app matches web
   %[1]s probes -l app=web
 
@@ -76,6 +101,35 @@ func Probes(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args [
 
 	builder.SetFlagsFrom(commonFlagList)
 
+	if cmd.Flag("details").Value.String() == "true" {
+		log.Debug("loopinfo.ShowDetails = true")
+		loopinfo.ShowDetails = true
+	}
+
+	if cmd.Flag("health").Value.String() == "true" {
+		log.Debug("loopinfo.ShowHealth = true")
+		loopinfo.ShowHealth = true
+
+		stdinChanged, err := builder.HasStdinChanged()
+		if err != nil {
+			return err
+		}
+		// events are only kept by the cluster, without them only the container state can be used
+		if len(commonFlagList.inputFilename) == 0 && !stdinChanged {
+			loopinfo.Connection = &connect
+		}
+	}
+
+	if cmd.Flag("lint").Value.String() == "true" {
+		log.Debug("loopinfo.ShowLint = true")
+		loopinfo.ShowLint = true
+	}
+
+	failOnWarn := cmd.Flag("fail-on-warn").Value.String() == "true"
+	if failOnWarn && !loopinfo.ShowLint {
+		return errors.New("--fail-on-warn can only be used with --lint")
+	}
+
 	table := Table{}
 	table.ColourOutput = commonFlagList.outputAsColour
 	table.CustomColours = commonFlagList.useTheseColours
@@ -91,12 +145,86 @@ func Probes(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args [
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	if err := outputTableAs(table, commonFlagList); err != nil {
+		return err
+	}
 
+	if failOnWarn && len(loopinfo.lintFailures) > 0 {
+		return newIceError(ErrLintFailed, fmt.Errorf("probe lint failed:\n  %s", strings.Join(loopinfo.lintFailures, "\n  ")))
+	}
+
+	return nil
 }
 
 type probes struct {
+	Connection  *Connector // used to look up the probe failure events for --health, nil when reading from a file
+	ShowLint    bool       // check each probe for suspicious settings and show the result in the WARN column
+	ShowDetails bool       // show how long each container has been running in the AGE column
+	ShowHealth  bool       // show if each probe is passing in the HEALTHY column
+
+	lintFailures []string // namespace/pod/container probe: rule names, for every probe that failed a lint rule
+}
+
+// defaults applied by kubernetes when the probe values are not set
+const (
+	probeDefaultPeriodSeconds    = 10
+	probeDefaultTimeoutSeconds   = 1
+	probeDefaultFailureThreshold = 3
+)
+
+// probeLintRule checks a probe for a single anti-pattern, check returns a short warning or an empty string when the probe looks fine
+type probeLintRule struct {
+	name  string
+	check func(probe *v1.Probe) string
+}
+
+// probeLintResult holds the name of the rule that was broken along with its warning
+type probeLintResult struct {
+	rule    string
+	warning string
+}
+
+var probeLintRules = []probeLintRule{
+	{"no-initial-delay", lintProbeNoDelay},
+	{"timeout-not-less-than-period", lintProbeTimeoutPeriod},
+}
+
+// probeValueOrDefault returns value or the default kubernetes would use when the value has not been set
+func probeValueOrDefault(value int32, defaultValue int32) int32 {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// lintProbeNoDelay warns when a probe starts checking straight away and gives up after less than the default number of failures
+func lintProbeNoDelay(probe *v1.Probe) string {
+	failureThreshold := probeValueOrDefault(probe.FailureThreshold, probeDefaultFailureThreshold)
+	if probe.InitialDelaySeconds == 0 && failureThreshold < probeDefaultFailureThreshold {
+		return fmt.Sprintf("no initial delay with a failure threshold of %d", failureThreshold)
+	}
+	return ""
+}
+
+// lintProbeTimeoutPeriod warns when the timeout is not shorter than the period as the probes can overlap
+func lintProbeTimeoutPeriod(probe *v1.Probe) string {
+	timeout := probeValueOrDefault(probe.TimeoutSeconds, probeDefaultTimeoutSeconds)
+	period := probeValueOrDefault(probe.PeriodSeconds, probeDefaultPeriodSeconds)
+	if timeout >= period {
+		return fmt.Sprintf("timeout %ds is not shorter than the period %ds", timeout, period)
+	}
+	return ""
+}
+
+// lintProbe runs every lint rule against the probe and returns the rules that were broken
+func lintProbe(probe *v1.Probe) []probeLintResult {
+	results := []probeLintResult{}
+	for _, rule := range probeLintRules {
+		if warning := rule.check(probe); len(warning) > 0 {
+			results = append(results, probeLintResult{rule: rule.name, warning: warning})
+		}
+	}
+	return results
 }
 
 func (s *probes) Headers() []string {
@@ -109,6 +237,9 @@ func (s *probes) Headers() []string {
 		"FAILURE",
 		"CHECK",
 		"ACTION",
+		"WARN",
+		"AGE",
+		"HEALTHY",
 	}
 }
 
@@ -121,7 +252,21 @@ func (s *probes) BuildEphemeralContainerStatus(container v1.ContainerStatus, inf
 }
 
 func (s *probes) HideColumns(info BuilderInformation) []int {
-	return []int{}
+	hideColumns := []int{}
+
+	if !s.ShowLint {
+		hideColumns = append(hideColumns, 8)
+	}
+
+	if !s.ShowDetails {
+		hideColumns = append(hideColumns, 9)
+	}
+
+	if !s.ShowHealth {
+		hideColumns = append(hideColumns, 10)
+	}
+
+	return hideColumns
 }
 
 func (s *probes) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
@@ -134,21 +279,180 @@ func (s *probes) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, er
 		NewCellText(""),
 		NewCellText(""),
 		NewCellText(""),
+		NewCellText(""),
+		NewCellInt("", 0),
+		NewCellText(""),
 	}
 	return out, nil
 }
 
 func (s *probes) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
 	out := [][]Cell{}
+	startedAt := containerStartedAt(info.Data.pod, container.Name)
+
+	var eventList []v1.Event
+	if s.ShowHealth && s.Connection != nil {
+		var err error
+		eventList, err = s.Connection.GetEvents(info.Data.pod.Namespace)
+		if err != nil {
+			return [][]Cell{}, err
+		}
+		eventList = containerEvents(eventList, info.Data.pod, container.Name)
+	}
+
 	probeList := s.buildProbeList(container)
 	for _, probe := range probeList {
 		for _, action := range probe {
-			out = append(out, s.probesBuildRow(info, action))
+			row := s.probesBuildRow(info, action)
+			if s.ShowDetails {
+				row = append(row, probeAgeCell(action, startedAt, time.Now()))
+			} else {
+				row = append(row, NewCellInt("", 0))
+			}
+			if s.ShowHealth {
+				health := probeHealth(action, containerStatus(info.Data.pod, container.Name), eventList, s.Connection != nil, time.Now())
+				row = append(row, NewCellColourText(probeHealthColour(health), health))
+			} else {
+				row = append(row, NewCellText(""))
+			}
+			out = append(out, row)
 		}
 	}
 	return out, nil
 }
 
+// the verdicts shown in the HEALTHY column
+const (
+	probeHealthy   = "Healthy"
+	probeUnhealthy = "Unhealthy"
+	probeUnknown   = "Unknown"
+)
+
+// containerStatus returns the status of the named container searching the init, standard and ephemeral container
+//
+//	statuses of the pod, nil is returned when the container has no status yet
+func containerStatus(pod v1.Pod, containerName string) *v1.ContainerStatus {
+	for _, statusList := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses} {
+		for i := range statusList {
+			if statusList[i].Name == containerName {
+				return &statusList[i]
+			}
+		}
+	}
+	return nil
+}
+
+// probeFailureWindow returns how long ago a probe failure event can be and still count as failing now, its the time
+//
+//	the kubelet takes to act on the failures so an old failure the probe has since recovered from is ignored
+func probeFailureWindow(probe *v1.Probe) time.Duration {
+	period := probeValueOrDefault(probe.PeriodSeconds, probeDefaultPeriodSeconds)
+	failureThreshold := probeValueOrDefault(probe.FailureThreshold, probeDefaultFailureThreshold)
+	return time.Duration(period*failureThreshold) * time.Second
+}
+
+// probeHealth works out if the probe is passing from the container state and its events, haveEvents is false when the
+//
+//	events couldnt be read. Unknown is returned whenever the state doesnt prove it either way:
+//	  startup   - healthy once the container has started
+//	  readiness - healthy while the container is ready, unhealthy when started but not ready
+//	  liveness  - unhealthy with a recent failure event, healthy when the events were read and there are none
+func probeHealth(action probeAction, status *v1.ContainerStatus, containerEvents []v1.Event, haveEvents bool, now time.Time) string {
+	if status == nil || status.State.Running == nil {
+		return probeUnknown
+	}
+
+	started := status.Started != nil && *status.Started
+	notStarted := status.Started != nil && !*status.Started
+
+	// only failures from the current run of the container that are recent enough to still matter are counted
+	since := now.Add(-probeFailureWindow(action.probe))
+	if startedAt := status.State.Running.StartedAt.Time; startedAt.After(since) {
+		since = startedAt
+	}
+	failing := false
+	prefix := strings.ToUpper(action.probeName[:1]) + action.probeName[1:] + " probe"
+	for _, event := range containerEvents {
+		if event.Reason == "Unhealthy" && strings.HasPrefix(event.Message, prefix) && !eventLastSeen(event).Before(since) {
+			failing = true
+		}
+	}
+
+	switch action.probeName {
+	case "startup":
+		if started {
+			return probeHealthy
+		}
+		if failing {
+			return probeUnhealthy
+		}
+	case "readiness":
+		if status.Ready {
+			return probeHealthy
+		}
+		if failing || started {
+			return probeUnhealthy
+		}
+	case "liveness":
+		if failing {
+			return probeUnhealthy
+		}
+		// the liveness probe doesnt run until the startup probe has passed
+		if haveEvents && !notStarted {
+			return probeHealthy
+		}
+	}
+
+	return probeUnknown
+}
+
+// probeHealthColour returns the colour of a HEALTHY cell
+func probeHealthColour(health string) [2]int {
+	switch health {
+	case probeHealthy:
+		return colourOk
+	case probeUnhealthy:
+		return colourBad
+	}
+	return colourWarn
+}
+
+// containerStartedAt returns the time the named container started running, the zero time is returned when the
+//
+//	container isnt running or has no status yet
+func containerStartedAt(pod v1.Pod, containerName string) time.Time {
+	if status := containerStatus(pod, containerName); status != nil && status.State.Running != nil {
+		return status.State.Running.StartedAt.Time
+	}
+	return time.Time{}
+}
+
+// probeStartupWindow returns the longest time a probe allows the container to fail before it is restarted, any unset
+//
+//	values use the kubernetes defaults
+func probeStartupWindow(probe *v1.Probe) time.Duration {
+	period := probeValueOrDefault(probe.PeriodSeconds, probeDefaultPeriodSeconds)
+	failureThreshold := probeValueOrDefault(probe.FailureThreshold, probeDefaultFailureThreshold)
+	return time.Duration(probe.InitialDelaySeconds+period*failureThreshold) * time.Second
+}
+
+// probeAgeCell returns the AGE cell for a probe row, startup probe rows are shown as a warning while the container is
+//
+//	still inside its startup window
+func probeAgeCell(action probeAction, startedAt time.Time, now time.Time) Cell {
+	if startedAt.IsZero() {
+		return NewCellInt("", 0)
+	}
+
+	age := now.Sub(startedAt)
+	colour := colourOk
+	if action.probeName == "startup" && age < probeStartupWindow(action.probe) {
+		colour = colourWarn
+	}
+
+	return NewCellColourInt(colour, duration.HumanDuration(age), int64(age.Seconds()))
+}
+
 func (s *probes) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
 	out := [][]Cell{}
 	return out, nil
@@ -156,6 +460,26 @@ func (s *probes) BuildEphemeralContainerSpec(container v1.EphemeralContainer, in
 
 func (s *probes) probesBuildRow(info BuilderInformation, action probeAction) []Cell {
 	var cellList []Cell
+	var warnCell Cell
+
+	if s.ShowLint {
+		var warnings []string
+		var rules []string
+
+		for _, result := range lintProbe(action.probe) {
+			warnings = append(warnings, result.warning)
+			rules = append(rules, result.rule)
+		}
+
+		if len(warnings) > 0 {
+			warnCell = NewCellColourText(colourWarn, strings.Join(warnings, "; "))
+			s.lintFailures = append(s.lintFailures, fmt.Sprintf("%s/%s/%s %s: %s", info.Namespace, info.PodName, info.Name, action.probeName, strings.Join(rules, ", ")))
+		} else {
+			warnCell = NewCellText("")
+		}
+	} else {
+		warnCell = NewCellText("")
+	}
 
 	// if info.TreeView {
 	// 	cellList = info.BuildTreeCell(cellList)
@@ -170,6 +494,7 @@ func (s *probes) probesBuildRow(info BuilderInformation, action probeAction) []C
 		NewCellInt(fmt.Sprintf("%d", action.probe.FailureThreshold), int64(action.probe.FailureThreshold)),
 		NewCellText(action.actionName),
 		NewCellText(action.action),
+		warnCell,
 	)
 
 	return cellList
@@ -231,7 +556,7 @@ func (s *probes) buildProbeAction(name string, probe *v1.Probe) []probeAction {
 	// translate GRPC action
 	if probe.GRPC != nil {
 		item.actionName = "GRPC"
-		if probe.GRPC.Service == nil {
+		if probe.GRPC.Service != nil {
 			item.action = *probe.GRPC.Service
 		}
 		if probe.GRPC.Port > 0 {
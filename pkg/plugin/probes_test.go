@@ -0,0 +1,263 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// *****************
+// lintProbeNoDelay
+// *****************
+type lintProbeTest struct {
+	arg1     v1.Probe
+	expected string
+}
+
+var lintProbeNoDelayTests = []lintProbeTest{
+	{v1.Probe{}, ""},
+	{v1.Probe{InitialDelaySeconds: 0, FailureThreshold: 3}, ""},
+	{v1.Probe{InitialDelaySeconds: 0, FailureThreshold: 1}, "no initial delay with a failure threshold of 1"},
+	{v1.Probe{InitialDelaySeconds: 0, FailureThreshold: 2}, "no initial delay with a failure threshold of 2"},
+	{v1.Probe{InitialDelaySeconds: 5, FailureThreshold: 1}, ""},
+}
+
+func TestLintProbeNoDelay(t *testing.T) {
+
+	for _, test := range lintProbeNoDelayTests {
+		if output := lintProbeNoDelay(&test.arg1); output != test.expected {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// lintProbeTimeoutPeriod
+// *****************
+var lintProbeTimeoutPeriodTests = []lintProbeTest{
+	{v1.Probe{}, ""},
+	{v1.Probe{TimeoutSeconds: 5, PeriodSeconds: 10}, ""},
+	{v1.Probe{TimeoutSeconds: 10, PeriodSeconds: 10}, "timeout 10s is not shorter than the period 10s"},
+	{v1.Probe{TimeoutSeconds: 15, PeriodSeconds: 5}, "timeout 15s is not shorter than the period 5s"},
+	{v1.Probe{TimeoutSeconds: 10}, "timeout 10s is not shorter than the period 10s"},
+	{v1.Probe{PeriodSeconds: 1}, "timeout 1s is not shorter than the period 1s"},
+}
+
+func TestLintProbeTimeoutPeriod(t *testing.T) {
+
+	for _, test := range lintProbeTimeoutPeriodTests {
+		if output := lintProbeTimeoutPeriod(&test.arg1); output != test.expected {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// lintProbe
+// *****************
+type lintProbeAllTest struct {
+	arg1     v1.Probe
+	expected []probeLintResult
+}
+
+var lintProbeAllTests = []lintProbeAllTest{
+	{v1.Probe{InitialDelaySeconds: 10}, []probeLintResult{}},
+	{v1.Probe{FailureThreshold: 1, TimeoutSeconds: 10, PeriodSeconds: 5}, []probeLintResult{
+		{"no-initial-delay", "no initial delay with a failure threshold of 1"},
+		{"timeout-not-less-than-period", "timeout 10s is not shorter than the period 5s"},
+	}},
+}
+
+func TestLintProbe(t *testing.T) {
+
+	for _, test := range lintProbeAllTests {
+		if output := lintProbe(&test.arg1); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// probeAgeCell
+// *****************
+type probeAgeCellTest struct {
+	probeName string
+	probe     v1.Probe
+	age       time.Duration
+	text      string
+	colour    [2]int
+}
+
+var probeAgeCellTests = []probeAgeCellTest{
+	// 10 + 10 x 30 = 310 seconds to start
+	{"startup", v1.Probe{InitialDelaySeconds: 10, PeriodSeconds: 10, FailureThreshold: 30}, 5 * time.Minute, "5m", colourWarn},
+	{"startup", v1.Probe{InitialDelaySeconds: 10, PeriodSeconds: 10, FailureThreshold: 30}, 6 * time.Minute, "6m", colourOk},
+	// the defaults give 10 x 3 = 30 seconds
+	{"startup", v1.Probe{}, 20 * time.Second, "20s", colourWarn},
+	{"startup", v1.Probe{}, 40 * time.Second, "40s", colourOk},
+	// only the startup probe has a window to be inside of
+	{"liveness", v1.Probe{}, 20 * time.Second, "20s", colourOk},
+}
+
+func TestProbeAgeCell(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, test := range probeAgeCellTests {
+		probe := test.probe
+		output := probeAgeCell(probeAction{probeName: test.probeName, probe: &probe}, now.Add(-test.age), now)
+		if output.text != test.text || output.colour != test.colour {
+			t.Errorf("Output %q colour %v not equal to expected %q colour %v", output.text, output.colour, test.text, test.colour)
+		}
+	}
+
+	// containers that are not running have no age
+	if output := probeAgeCell(probeAction{probeName: "startup", probe: &v1.Probe{}}, time.Time{}, now); output.text != "" {
+		t.Errorf("Output %q not equal to expected %q", output.text, "")
+	}
+
+}
+
+// *****************
+// buildProbeAction
+// *****************
+type buildProbeActionTest struct {
+	probe      v1.Probe
+	actionName string
+	action     string
+}
+
+func grpcProbe(port int32, service *string) v1.Probe {
+	return v1.Probe{ProbeHandler: v1.ProbeHandler{GRPC: &v1.GRPCAction{Port: port, Service: service}}}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+var buildProbeActionTests = []buildProbeActionTest{
+	{grpcProbe(9000, stringPtr("grpc.health.v1.Health")), "GRPC", "grpc.health.v1.Health:9000"},
+	// without a service the server health is checked
+	{grpcProbe(9000, nil), "GRPC", ":9000"},
+	{grpcProbe(9000, stringPtr("")), "GRPC", ":9000"},
+}
+
+func TestBuildProbeAction(t *testing.T) {
+	loopinfo := probes{}
+
+	for _, test := range buildProbeActionTests {
+		probe := test.probe
+		output := loopinfo.buildProbeAction("liveness", &probe)
+		if len(output) != 1 {
+			t.Fatalf("Output %d actions not equal to expected 1", len(output))
+		}
+		if output[0].actionName != test.actionName || output[0].action != test.action {
+			t.Errorf("Output %s %q not equal to expected %s %q", output[0].actionName, output[0].action, test.actionName, test.action)
+		}
+	}
+
+}
+
+// *****************
+// probeHealth
+// *****************
+type probeHealthTest struct {
+	probeName  string
+	started    *bool
+	ready      bool
+	running    bool
+	events     []v1.Event
+	haveEvents bool
+	expected   string
+}
+
+var healthNow = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func probeEvent(message string, age time.Duration) v1.Event {
+	return v1.Event{Reason: "Unhealthy", Message: message, LastTimestamp: metav1.NewTime(healthNow.Add(-age))}
+}
+
+var probeHealthTests = []probeHealthTest{
+	// containers that arent running have nothing to check
+	{"readiness", boolPtr(false), false, false, nil, true, probeUnknown},
+	{"startup", boolPtr(true), false, true, nil, false, probeHealthy},
+	{"startup", boolPtr(false), false, true, nil, true, probeUnknown},
+	{"startup", boolPtr(false), false, true, []v1.Event{probeEvent("Startup probe failed: connection refused", 5*time.Second)}, true, probeUnhealthy},
+	{"readiness", boolPtr(true), true, true, nil, false, probeHealthy},
+	{"readiness", boolPtr(true), false, true, nil, false, probeUnhealthy},
+	// still starting so the readiness probe may not have run yet
+	{"readiness", boolPtr(false), false, true, nil, true, probeUnknown},
+	{"liveness", boolPtr(true), true, true, nil, true, probeHealthy},
+	{"liveness", boolPtr(true), true, true, []v1.Event{probeEvent("Liveness probe failed: timeout", 5*time.Second)}, true, probeUnhealthy},
+	// a failure older than the period x failure threshold has been recovered from
+	{"liveness", boolPtr(true), true, true, []v1.Event{probeEvent("Liveness probe failed: timeout", 10*time.Minute)}, true, probeHealthy},
+	// failures of the other probes dont count
+	{"liveness", boolPtr(true), true, true, []v1.Event{probeEvent("Readiness probe failed: timeout", 5*time.Second)}, true, probeHealthy},
+	// without the events there is no way to tell a passing liveness probe from one that hasnt failed enough yet
+	{"liveness", boolPtr(true), true, true, nil, false, probeUnknown},
+	{"liveness", boolPtr(false), false, true, nil, true, probeUnknown},
+}
+
+func TestProbeHealth(t *testing.T) {
+
+	for i, test := range probeHealthTests {
+		status := v1.ContainerStatus{Name: "web", Started: test.started, Ready: test.ready}
+		if test.running {
+			status.State.Running = &v1.ContainerStateRunning{StartedAt: metav1.NewTime(healthNow.Add(-time.Hour))}
+		}
+		action := probeAction{probeName: test.probeName, probe: &v1.Probe{}}
+		if output := probeHealth(action, &status, test.events, test.haveEvents, healthNow); output != test.expected {
+			t.Errorf("test %d: Output %s not equal to expected %s", i, output, test.expected)
+		}
+	}
+
+	// no status at all
+	if output := probeHealth(probeAction{probeName: "liveness", probe: &v1.Probe{}}, nil, nil, true, healthNow); output != probeUnknown {
+		t.Errorf("Output %s not equal to expected %s", output, probeUnknown)
+	}
+
+}
+
+// *****************
+// containerStatus
+// *****************
+type containerStatusTest struct {
+	name     string
+	found    bool
+	restarts int32
+}
+
+var containerStatusTests = []containerStatusTest{
+	{"init", true, 1},
+	{"web", true, 2},
+	{"debugger", true, 3},
+	{"missing", false, 0},
+}
+
+func TestContainerStatus(t *testing.T) {
+	pod := v1.Pod{Status: v1.PodStatus{
+		InitContainerStatuses:      []v1.ContainerStatus{{Name: "init", RestartCount: 1}},
+		ContainerStatuses:          []v1.ContainerStatus{{Name: "web", RestartCount: 2}},
+		EphemeralContainerStatuses: []v1.ContainerStatus{{Name: "debugger", RestartCount: 3}},
+	}}
+
+	for _, test := range containerStatusTests {
+		output := containerStatus(pod, test.name)
+		if (output != nil) != test.found {
+			t.Fatalf("Output found %t not equal to expected %t for %s", output != nil, test.found, test.name)
+		}
+		if output != nil && output.RestartCount != test.restarts {
+			t.Errorf("Output %d restarts not equal to expected %d for %s", output.RestartCount, test.restarts, test.name)
+		}
+	}
+
+}
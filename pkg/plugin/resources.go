@@ -1,7 +1,10 @@
 package plugin
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
@@ -22,7 +25,15 @@ serve as an easy way to see how close you are to the configured sizes.  By speci
 flag you can see raw unfiltered values.  If no name is specified the container %[1]s details
 of all pods in the current namespace are shown.
 
-The T column in the table output denotes S for Standard and I for init containers`, r)
+The T column in the table output denotes S for Standard and I for init containers, use -i to include the init
+containers. The --pod-footprint flag adds a P row under each pod with the %[1]s the pod reserves on its node, this
+is the larger of the biggest init container request and the requests of the other containers added together, plus
+the pod overhead. The same is done for the limits, a pod with any container that has no limit has no limit.
+
+The BURST column is the limit minus the request, how much %[1]s a container can use over what it asked for. A
+guaranteed container has the same request and limit so shows 0, a container without a limit is shown as unbounded.
+The --require-limits flag shows the LIMIT of each container without one as missing, add --fail-on-missing-limits
+to exit with a non zero exit code so it can be used to check rendered manifests in a pipeline.`, r)
 }
 
 // returns a string replacing %[2] with the resourse type r
@@ -49,6 +60,27 @@ func resourceExample(r string) string {
   # namespace sorted by pod name in ascending order
   %[1]s %[2]s -c web-container --sort PODNAME
 
+  # List the 5 containers using the most %[2]s in the current namespace
+  %[1]s %[2]s --top 5
+
+  # List containers with a %[2]s limit above a kubernetes quantity, plain numbers are compared
+  # against the raw value
+  %[1]s memory -m 'LIMIT>512Mi'
+  %[1]s cpu -m 'REQUEST>=0.5'
+
+  # List containers by how much %[2]s they can burst over their request, containers without a limit are first
+  %[1]s %[2]s --sort '!BURST'
+
+  # Check every container in a rendered manifest has a %[2]s limit, exits with a non zero exit code when any are missing
+  %[1]s %[2]s --require-limits --fail-on-missing-limits -f manifest.yaml
+
+  # List container %[2]s info including the init containers along with the %[2]s each pod reserves on its node
+  %[1]s %[2]s -i --pod-footprint
+
+  # List the containers of pods part way through an in-place resize along with the request the node has
+  # allocated and the limit the runtime has applied
+  %[1]s %[2]s --show-resize -m 'RESIZE==InProgress'
+
   # List container %[2]s info from all pods where label app matches web
   %[1]s %[2]s -l app=web
 
@@ -87,6 +119,41 @@ func Resources(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, arg
 		return err
 	}
 
+	top, err := cmd.Flags().GetInt("top")
+	if err != nil {
+		return err
+	}
+	if top < 0 {
+		return errors.New("--top must be a positive number")
+	}
+	if top > 0 && commonFlagList.showTreeView {
+		return errors.New("--top can not be used with the tree view")
+	}
+
+	if cmd.Flag("pod-footprint").Value.String() == "true" {
+		if commonFlagList.showTreeView {
+			return errors.New("--pod-footprint can not be used with the tree view")
+		}
+		// the pod rows would be ranked against the containers
+		if top > 0 || commonFlagList.showOddities {
+			return errors.New("--pod-footprint can not be used with --top or --oddities")
+		}
+		loopinfo.ShowPodFootprint = true
+	}
+
+	if cmd.Flag("show-resize").Value.String() == "true" {
+		loopinfo.ShowResize = true
+	}
+
+	requireLimits := cmd.Flag("require-limits").Value.String() == "true"
+	if requireLimits && commonFlagList.showTreeView {
+		return errors.New("--require-limits can not be used with the tree view")
+	}
+	failOnMissing := cmd.Flag("fail-on-missing-limits").Value.String() == "true"
+	if failOnMissing && !requireLimits {
+		return errors.New("--fail-on-missing-limits can only be used with --require-limits")
+	}
+
 	//only need to pull metrics info we are reading live data,
 	// if we read from a file metric data wont exist
 	if len(commonFlagList.inputFilename) == 0 && !stdinChanged {
@@ -95,6 +162,10 @@ func Resources(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, arg
 		}
 		podStateList, err := connect.GetMetricPods(args)
 		if err != nil {
+			// --top has nothing to sort on without the usage figures
+			if top > 0 {
+				return err
+			}
 			log.Tell(err)
 		} else {
 			loopinfo.MetricsResource = loopinfo.podMetrics2Hashtable(podStateList)
@@ -112,6 +183,9 @@ func Resources(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, arg
 		loopinfo.BytesAs = "M"
 	}
 
+	// lets --match take quantities like LIMIT>512Mi or USED>=250m
+	builder.QuantityColumns = loopinfo.quantityColumns()
+
 	table := Table{}
 	table.ColourOutput = commonFlagList.outputAsColour
 	table.CustomColours = commonFlagList.useTheseColours
@@ -129,29 +203,102 @@ func Resources(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, arg
 
 	// do we need to find the outliers, we have enough data to compute a range
 	if commonFlagList.showOddities {
-		row2Remove, err := table.ListOutOfRange(builder.DefaultHeaderLen) //1 = used column
-		if err != nil {
+		if err := hideInRangeRows(&table, builder.DefaultHeaderLen, commonFlagList); err != nil { //1 = used column
 			return err
 		}
-		table.HideRows(row2Remove)
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
+	// keep only the hungriest containers, sorting on USED uses the raw value so different units compare correctly
+	if top > 0 {
+		if err := table.SortByNames("!USED"); err != nil {
+			return err
+		}
+		table.LimitRows(top)
+	}
+
+	var missingLimits []string
+	if requireLimits {
+		missingLimits = markMissingLimits(&table, builder.DefaultHeaderLen+2) // 2 = limit column
+	}
+
+	if err := outputTableAs(table, commonFlagList); err != nil {
+		return err
+	}
+
+	if failOnMissing && len(missingLimits) > 0 {
+		return newIceError(ErrLintFailed, fmt.Errorf("containers without a %s limit:\n  %s", resourceType, strings.Join(missingLimits, "\n  ")))
+	}
+
 	return nil
 }
 
+// markMissingLimits shows the limit cell of every visible container row without a limit as missing and returns the
+//
+//	namespace/pod/container of each one, pod footprint rows are left alone as they only repeat the containers
+func markMissingLimits(t *Table, limitColumn int) []string {
+	var missing []string
+
+	for _, row := range t.getVisibleRows() {
+		switch row[0].text {
+		case TypeIDContainer, TypeIDInitContainer, TypeIDEphemeralContainer:
+		default:
+			continue
+		}
+
+		if row[limitColumn].number != 0 {
+			continue
+		}
+
+		row[limitColumn] = NewCellColourInt(colourBad, "missing", 0)
+		if textWidth(row[limitColumn].text)+2 > t.head[limitColumn].columnLength {
+			t.head[limitColumn].columnLength = textWidth(row[limitColumn].text) + 2
+		}
+		missing = append(missing, fmt.Sprintf("%s/%s/%s", row[1].text, row[3].text, row[4].text))
+	}
+
+	return missing
+}
+
 type resource struct {
-	MetricsResource map[string]map[string]v1.ResourceList
-	ResourceType    string
-	BytesAs         string
-	ShowRaw         bool
-	ShowPrevious    bool
-	ShowDetails     bool
+	MetricsResource  map[string]map[string]v1.ResourceList
+	ResourceType     string
+	BytesAs          string
+	ShowRaw          bool
+	ShowPrevious     bool
+	ShowDetails      bool
+	ShowPodFootprint bool
+	ShowResize       bool // show the in-place resize status along with the allocated request and applied limit
+}
+
+// quantityColumns returns a function for each resource column that converts a quantity into the raw value stored in the
+//
+//	cell, this must match the units used by statsProcessTableRow
+func (s *resource) quantityColumns() map[string]quantityFunc {
+	if s.ResourceType == "cpu" {
+		cpu := func(q apires.Quantity) int64 {
+			if s.ShowRaw {
+				return q.ScaledValue(apires.Nano)
+			}
+			return q.MilliValue()
+		}
+		return map[string]quantityFunc{"USED": cpu, "REQUEST": cpu, "LIMIT": cpu, "ALLOCATED": cpu, "ACTUAL-LIMIT": cpu, "BURST": cpu}
+	}
+
+	bytes := func(q apires.Quantity) int64 { return q.Value() }
+	return map[string]quantityFunc{
+		// used memory is stored in kilobytes
+		"USED":         func(q apires.Quantity) int64 { return q.Value() / 1000 },
+		"REQUEST":      bytes,
+		"LIMIT":        bytes,
+		"ALLOCATED":    bytes,
+		"ACTUAL-LIMIT": bytes,
+		"BURST":        bytes,
+	}
 }
 
 func (s *resource) Headers() []string {
 	return []string{
-		"USED", "REQUEST", "LIMIT", "%REQ", "%LIMIT",
+		"USED", "REQUEST", "LIMIT", "%REQ", "%LIMIT", "RESIZE", "ALLOCATED", "ACTUAL-LIMIT", "BURST",
 	}
 }
 
@@ -160,17 +307,27 @@ func (s *resource) BuildContainerStatus(container v1.ContainerStatus, info Build
 }
 
 func (s *resource) HideColumns(info BuilderInformation) []int {
+	if !s.ShowResize {
+		return []int{5, 6, 7}
+	}
 	return []int{}
 }
 
 func (s *resource) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
-	rowOut := make([]Cell, 5)
+	rowOut := make([]Cell, 9)
 
+	unbounded := false
 	for _, r := range rows {
 		// "USED", "REQUEST", "LIMIT", "%REQ", "%LIMIT",
 		rowOut[0].number += r[0].number
 		rowOut[1].number += r[1].number
 		rowOut[2].number += r[2].number
+		// one container without a limit can burst as far as the node allows
+		if r[8].number == burstUnbounded {
+			unbounded = true
+		} else {
+			rowOut[8].number += r[8].number
+		}
 	}
 
 	floatfmt := "%.6f"
@@ -192,6 +349,7 @@ func (s *resource) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell,
 		}
 		rowOut[1].text = memoryHumanReadable(rowOut[1].number, s.BytesAs)
 		rowOut[2].text = memoryHumanReadable(rowOut[2].number, s.BytesAs)
+		rowOut[8].text = memoryHumanReadable(rowOut[8].number, s.BytesAs)
 	} else {
 		if s.ShowRaw {
 			rowOut[0].text = fmt.Sprintf("%dn", rowOut[0].number)
@@ -200,6 +358,11 @@ func (s *resource) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell,
 		}
 		rowOut[1].text = fmt.Sprintf(typefmt, rowOut[1].number)
 		rowOut[2].text = fmt.Sprintf(typefmt, rowOut[2].number)
+		rowOut[8].text = fmt.Sprintf(typefmt, rowOut[8].number)
+	}
+
+	if unbounded {
+		rowOut[8] = unboundedBurstCell()
 	}
 
 	if rowOut[0].number > 0 {
@@ -229,6 +392,10 @@ func (s *resource) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell,
 		rowOut[0].colour = usedColour
 	}
 
+	if info.TypeName == TypeNamePod {
+		rowOut[5] = resizeStatusCell(info.Data.pod)
+	}
+
 	return rowOut, nil
 }
 
@@ -236,6 +403,8 @@ func (s *resource) BuildContainerSpec(container v1.Container, info BuilderInform
 	metrics := s.MetricsResource[info.PodName][info.Name]
 	out := make([][]Cell, 1)
 	out[0] = s.statsProcessTableRow(container.Resources, metrics, info, s.ResourceType)
+	out[0] = append(out[0], s.resizeCells(container.Resources, info)...)
+	out[0] = append(out[0], s.burstCell(container.Resources))
 	return out, nil
 }
 
@@ -243,9 +412,138 @@ func (s *resource) BuildEphemeralContainerSpec(container v1.EphemeralContainer,
 	metrics := s.MetricsResource[info.PodName][info.Name]
 	out := make([][]Cell, 1)
 	out[0] = s.statsProcessTableRow(container.Resources, metrics, info, s.ResourceType)
+	out[0] = append(out[0], s.resizeCells(container.Resources, info)...)
+	out[0] = append(out[0], s.burstCell(container.Resources))
 	return out, nil
 }
 
+// resizeCells returns the RESIZE, ALLOCATED and ACTUAL-LIMIT cells of the container. the allocated request and the
+//
+//	limit applied by the runtime are highlighted when they are different to the spec, which is the case while an
+//	in-place resize is waiting or in progress. clusters without in-place resize dont set the fields so the cells
+//	are left empty
+func (s *resource) resizeCells(spec v1.ResourceRequirements, info BuilderInformation) []Cell {
+	allocatedCell := NewCellText("")
+	limitCell := NewCellText("")
+
+	container := containerStatus(info.Data.pod, info.Name)
+	if !s.ShowResize || container == nil {
+		return []Cell{NewCellText(""), allocatedCell, limitCell}
+	}
+
+	name := v1.ResourceName(s.ResourceType)
+	if allocated, ok := container.AllocatedResources[name]; ok {
+		allocatedCell = s.quantityCell(allocated, spec.Requests[name])
+	}
+	if container.Resources != nil {
+		if limit, ok := container.Resources.Limits[name]; ok {
+			limitCell = s.quantityCell(limit, spec.Limits[name])
+		}
+	}
+
+	return []Cell{resizeStatusCell(info.Data.pod), allocatedCell, limitCell}
+}
+
+// quantityCell formats the quantity the same way as the REQUEST and LIMIT columns, coloured as a warning when it
+//
+//	doesnt match the value from the spec
+func (s *resource) quantityCell(value apires.Quantity, spec apires.Quantity) Cell {
+	text, raw := s.quantityText(value)
+
+	colour := [2]int{-1, 0}
+	if value.Cmp(spec) != 0 {
+		colour = colourWarn
+	}
+
+	return NewCellColourInt(colour, text, raw)
+}
+
+// quantityText returns the quantity as shown in the REQUEST and LIMIT columns along with the raw value stored in
+//
+//	the cell
+func (s *resource) quantityText(value apires.Quantity) (string, int64) {
+	raw := s.quantityColumns()["REQUEST"](value)
+	switch {
+	case s.ResourceType != "cpu":
+		return value.String(), raw
+	case s.ShowRaw:
+		return fmt.Sprintf("%dn", raw), raw
+	default:
+		return fmt.Sprintf("%dm", raw), raw
+	}
+}
+
+// burstUnbounded is the raw value of the BURST cell of a container without a limit, so it sorts above every other
+//
+//	container
+const burstUnbounded = math.MaxInt64
+
+// burstCell returns how far the container can go over its request, the limit minus the request. guaranteed
+//
+//	containers have the same request and limit so show 0, a container without a limit is shown as unbounded
+func (s *resource) burstCell(res v1.ResourceRequirements) Cell {
+	name := v1.ResourceName(s.ResourceType)
+
+	limit, ok := res.Limits[name]
+	if !ok {
+		return unboundedBurstCell()
+	}
+
+	// the api server sets the request to the limit when only the limit is given
+	burst := limit.DeepCopy()
+	if request, ok := res.Requests[name]; ok {
+		burst.Sub(request)
+	} else {
+		burst.Sub(limit)
+	}
+
+	text, raw := s.quantityText(burst)
+	return NewCellInt(text, raw)
+}
+
+// unboundedBurstCell is the BURST cell of a container or pod without a limit
+func unboundedBurstCell() Cell {
+	return NewCellColourInt(colourBad, "unbounded", burstUnbounded)
+}
+
+// podResizeConditions map the conditions used by newer clusters to report an in-place resize onto the values of the
+//
+//	older pod.Status.Resize field
+var podResizeConditions = map[v1.PodConditionType]string{
+	"PodResizePending":    "Pending",
+	"PodResizeInProgress": string(v1.PodResizeStatusInProgress),
+}
+
+// resizeStatusCell returns the in-place resize status of the pod, this is read from pod.Status.Resize or from the
+//
+//	resize conditions that replaced it. deferred and pending resizes are shown as a warning and infeasible as bad
+func resizeStatusCell(pod v1.Pod) Cell {
+	resize := string(pod.Status.Resize)
+	reason := ""
+	if len(resize) == 0 {
+		for _, condition := range pod.Status.Conditions {
+			if value, ok := podResizeConditions[condition.Type]; ok && condition.Status == v1.ConditionTrue {
+				resize = value
+				reason = condition.Reason
+			}
+		}
+	}
+
+	colour := [2]int{-1, 0}
+	switch {
+	case resize == string(v1.PodResizeStatusInfeasible) || reason == string(v1.PodResizeStatusInfeasible):
+		colour = colourBad
+	case len(resize) > 0:
+		colour = colourWarn
+	}
+
+	if len(reason) > 0 {
+		resize += "(" + reason + ")"
+	}
+
+	return NewCellColourText(colour, resize)
+}
+
 func (s *resource) statsProcessTableRow(res v1.ResourceRequirements, metrics v1.ResourceList, info BuilderInformation, resource string) []Cell {
 	var cellList []Cell
 	var displayValue, request, limit, percentLimit, percentRequest string
@@ -409,3 +707,95 @@ func (s *resource) podMetrics2Hashtable(stateList []v1beta1.PodMetrics) map[stri
 func (s *resource) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
 	return [][]Cell{}, nil
 }
+
+// BuildPodSummary adds the pod footprint row under the containers of each pod, the usage is the total of every
+//
+//	container in the pod
+func (s *resource) BuildPodSummary(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
+	if !s.ShowPodFootprint {
+		return [][]Cell{}, nil
+	}
+
+	used := v1.ResourceList{}
+	for _, usage := range s.MetricsResource[pod.Name] {
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			if value, ok := usage[name]; ok {
+				total := used[name]
+				total.Add(value)
+				used[name] = total
+			}
+		}
+	}
+
+	row := s.statsProcessTableRow(podFootprint(pod), used, info, s.ResourceType)
+	row = append(row, resizeStatusCell(pod), NewCellText(""), NewCellText(""))
+	row = append(row, s.burstCell(podFootprint(pod)))
+	return [][]Cell{row}, nil
+}
+
+// podFootprint works out the cpu and memory the pod reserves on its node the same way the scheduler does, the larger of
+//
+//	the biggest init container and the other containers added together with the pod overhead added on top
+func podFootprint(pod v1.Pod) v1.ResourceRequirements {
+	out := v1.ResourceRequirements{
+		Requests: v1.ResourceList{},
+		Limits:   v1.ResourceList{},
+	}
+
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		if value, ok := footprintQuantity(pod, name, false, func(c v1.Container) v1.ResourceList { return c.Resources.Requests }); ok {
+			out.Requests[name] = value
+		}
+		if value, ok := footprintQuantity(pod, name, true, func(c v1.Container) v1.ResourceList { return c.Resources.Limits }); ok {
+			out.Limits[name] = value
+		}
+	}
+
+	return out
+}
+
+// footprintQuantity returns the pod footprint of a single resource from the list returned by resources for each
+//
+//	container, false is returned when no container sets it. needAll is used for limits where a container without a
+//	limit leaves the whole pod without one
+func footprintQuantity(pod v1.Pod, name v1.ResourceName, needAll bool, resources func(v1.Container) v1.ResourceList) (apires.Quantity, bool) {
+	total := apires.Quantity{}
+	found := false
+
+	for _, container := range pod.Spec.Containers {
+		value, ok := resources(container)[name]
+		if !ok {
+			if needAll {
+				return apires.Quantity{}, false
+			}
+			continue
+		}
+		total.Add(value)
+		found = true
+	}
+
+	// init containers run one at a time before the others so only the biggest one counts
+	for _, container := range pod.Spec.InitContainers {
+		value, ok := resources(container)[name]
+		if !ok {
+			if needAll {
+				return apires.Quantity{}, false
+			}
+			continue
+		}
+		if value.Cmp(total) > 0 {
+			total = value.DeepCopy()
+		}
+		found = true
+	}
+
+	if !found {
+		return apires.Quantity{}, false
+	}
+
+	if value, ok := pod.Spec.Overhead[name]; ok {
+		total.Add(value)
+	}
+
+	return total, true
+}
@@ -0,0 +1,241 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apires "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// *****************
+// podFootprint
+// *****************
+func resourceList(cpu string, memory string) v1.ResourceList {
+	list := v1.ResourceList{}
+	if len(cpu) > 0 {
+		list[v1.ResourceCPU] = apires.MustParse(cpu)
+	}
+	if len(memory) > 0 {
+		list[v1.ResourceMemory] = apires.MustParse(memory)
+	}
+	return list
+}
+
+func TestPodFootprint(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          v1.PodSpec
+		requestCPU    string
+		requestMemory string
+		limitCPU      string // empty when the pod has no limit
+	}{
+		{"sum of containers", v1.PodSpec{
+			Containers: []v1.Container{
+				{Resources: v1.ResourceRequirements{Requests: resourceList("250m", "256Mi"), Limits: resourceList("500m", "")}},
+				{Resources: v1.ResourceRequirements{Requests: resourceList("100m", "64Mi"), Limits: resourceList("100m", "")}},
+			},
+		}, "350m", "320Mi", "600m"},
+		{"init container is bigger", v1.PodSpec{
+			InitContainers: []v1.Container{
+				{Resources: v1.ResourceRequirements{Requests: resourceList("1", "128Mi"), Limits: resourceList("1", "")}},
+			},
+			Containers: []v1.Container{
+				{Resources: v1.ResourceRequirements{Requests: resourceList("250m", "256Mi"), Limits: resourceList("500m", "")}},
+			},
+		}, "1", "256Mi", "1"},
+		{"overhead is added", v1.PodSpec{
+			Overhead: resourceList("50m", "64Mi"),
+			Containers: []v1.Container{
+				{Resources: v1.ResourceRequirements{Requests: resourceList("250m", "256Mi"), Limits: resourceList("500m", "")}},
+			},
+		}, "300m", "320Mi", "550m"},
+		// one container without a limit can use the whole node
+		{"missing limit", v1.PodSpec{
+			Containers: []v1.Container{
+				{Resources: v1.ResourceRequirements{Requests: resourceList("250m", ""), Limits: resourceList("500m", "")}},
+				{Resources: v1.ResourceRequirements{Requests: resourceList("100m", "")}},
+			},
+		}, "350m", "", ""},
+	}
+
+	for _, test := range tests {
+		output := podFootprint(v1.Pod{Spec: test.spec})
+
+		checks := []struct {
+			list     v1.ResourceList
+			name     v1.ResourceName
+			expected string
+		}{
+			{output.Requests, v1.ResourceCPU, test.requestCPU},
+			{output.Requests, v1.ResourceMemory, test.requestMemory},
+			{output.Limits, v1.ResourceCPU, test.limitCPU},
+		}
+		for _, check := range checks {
+			value, ok := check.list[check.name]
+			if len(check.expected) == 0 {
+				if ok {
+					t.Errorf("%s: %s should not be set, got %s", test.name, check.name, value.String())
+				}
+				continue
+			}
+			if !ok || value.Cmp(apires.MustParse(check.expected)) != 0 {
+				t.Errorf("%s: %s %s not equal to expected %s", test.name, check.name, value.String(), check.expected)
+			}
+		}
+	}
+
+}
+
+// *****************
+// resize columns
+// *****************
+func TestResizeCells(t *testing.T) {
+	spec := v1.ResourceRequirements{Requests: resourceList("500m", ""), Limits: resourceList("1", "")}
+
+	tests := []struct {
+		name            string
+		status          v1.PodStatus
+		expected        []string
+		allocatedColour [2]int
+	}{
+		{"not supported by the cluster", v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "web"}},
+		}, []string{"", "", ""}, [2]int{}},
+		{"in progress", v1.PodStatus{
+			Resize: v1.PodResizeStatusInProgress,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:               "web",
+				AllocatedResources: resourceList("250m", ""),
+				Resources:          &v1.ResourceRequirements{Limits: resourceList("500m", "")},
+			}},
+		}, []string{"InProgress", "250m", "500m"}, colourWarn},
+		{"finished", v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:               "web",
+				AllocatedResources: resourceList("0.5", ""),
+				Resources:          &v1.ResourceRequirements{Limits: resourceList("1", "")},
+			}},
+		}, []string{"", "500m", "1000m"}, [2]int{-1, 0}},
+		{"pending condition", v1.PodStatus{
+			Conditions:        []v1.PodCondition{{Type: "PodResizePending", Status: v1.ConditionTrue, Reason: "Infeasible"}},
+			ContainerStatuses: []v1.ContainerStatus{{Name: "web"}},
+		}, []string{"Pending(Infeasible)", "", ""}, [2]int{}},
+	}
+
+	s := resource{ResourceType: "cpu", ShowResize: true}
+	for _, test := range tests {
+		info := BuilderInformation{Name: "web", Data: ParentData{pod: v1.Pod{Status: test.status}}}
+		cells := s.resizeCells(spec, info)
+
+		output := []string{cells[0].text, cells[1].text, cells[2].text}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("%s: Output %v not equal to expected %v", test.name, output, test.expected)
+		}
+		if len(test.expected[1]) > 0 && cells[1].colour != test.allocatedColour {
+			t.Errorf("%s: ALLOCATED colour %v not equal to expected %v", test.name, cells[1].colour, test.allocatedColour)
+		}
+	}
+
+}
+
+// *****************
+// burstCell
+// *****************
+func TestBurstCell(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		spec         v1.ResourceRequirements
+		expected     string
+		raw          int64
+	}{
+		{"cpu", v1.ResourceRequirements{Requests: resourceList("250m", ""), Limits: resourceList("1", "")}, "750m", 750},
+		// guaranteed containers cant burst
+		{"cpu", v1.ResourceRequirements{Requests: resourceList("500m", ""), Limits: resourceList("0.5", "")}, "0m", 0},
+		{"cpu", v1.ResourceRequirements{Limits: resourceList("500m", "")}, "0m", 0},
+		{"cpu", v1.ResourceRequirements{Requests: resourceList("250m", "")}, "unbounded", burstUnbounded},
+		{"memory", v1.ResourceRequirements{Requests: resourceList("", "128Mi"), Limits: resourceList("", "512Mi")}, "384Mi", 384 * 1024 * 1024},
+		{"memory", v1.ResourceRequirements{}, "unbounded", burstUnbounded},
+	}
+
+	for _, test := range tests {
+		s := resource{ResourceType: test.resourceType}
+		cell := s.burstCell(test.spec)
+		if cell.text != test.expected || cell.number != test.raw {
+			t.Errorf("%s: Output %s (%d) not equal to expected %s (%d)", test.resourceType, cell.text, cell.number, test.expected, test.raw)
+		}
+	}
+
+}
+
+// *****************
+// markMissingLimits
+// *****************
+func TestMarkMissingLimits(t *testing.T) {
+	table := Table{}
+	table.SetHeader("T", "NAMESPACE", "NODE", "PODNAME", "CONTAINER", "LIMIT")
+	table.AddRow(NewCellText("C"), NewCellText("default"), NewCellText(""), NewCellText("web-0"), NewCellText("web"), NewCellInt("500m", 500))
+	table.AddRow(NewCellText("C"), NewCellText("default"), NewCellText(""), NewCellText("web-0"), NewCellText("proxy"), NewCellInt("0m", 0))
+	table.AddRow(NewCellText("I"), NewCellText("default"), NewCellText(""), NewCellText("web-0"), NewCellText("migrate"), Cell{})
+	// the pod footprint row only repeats the containers
+	table.AddRow(NewCellText("P"), NewCellText("default"), NewCellText(""), NewCellText("web-0"), NewCellText("-"), NewCellInt("0m", 0))
+
+	output := markMissingLimits(&table, 5)
+	expected := []string{"default/web-0/proxy", "default/web-0/migrate"}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+
+	limits := []string{}
+	for _, row := range table.GetRows() {
+		limits = append(limits, row[5].text)
+	}
+	expectedLimits := []string{"500m", "missing", "missing", "0m"}
+	if !reflect.DeepEqual(limits, expectedLimits) {
+		t.Errorf("LIMIT column %v not equal to expected %v", limits, expectedLimits)
+	}
+
+}
+
+// *****************
+// top
+// *****************
+type topRowsTest struct {
+	top      int
+	expected []string
+}
+
+var topRowsTests = []topRowsTest{
+	{1, []string{"db"}},
+	// the raw value is sorted on so 1Gi beats 900Mi
+	{2, []string{"db", "web"}},
+	// the row hidden by the oddities isnt counted
+	{4, []string{"db", "web", "cache"}},
+}
+
+func TestTopRows(t *testing.T) {
+
+	for _, test := range topRowsTests {
+		table := Table{}
+		table.SetHeader("T", "CONTAINER", "USED")
+		table.AddRow(NewCellText("C"), NewCellText("web"), NewCellInt("900Mi", 943718400))
+		table.AddRow(NewCellText("C"), NewCellText("proxy"), NewCellInt("30Mi", 31457280))
+		table.AddRow(NewCellText("C"), NewCellText("db"), NewCellInt("1Gi", 1073741824))
+		table.AddRow(NewCellText("C"), NewCellText("cache"), NewCellInt("64Mi", 67108864))
+		table.HideRows([]int{1})
+
+		if err := table.SortByNames("!USED"); err != nil {
+			t.Fatal(err)
+		}
+		table.LimitRows(test.top)
+
+		output := []string{}
+		for _, row := range table.getVisibleRows() {
+			output = append(output, row[1].text)
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v for --top %d", output, test.expected, test.top)
+		}
+	}
+
+}
@@ -1,7 +1,10 @@
 package plugin
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
@@ -13,6 +16,15 @@ var restartsShort = "Show restart counts for each container in a named pod"
 var restartsDescription = ` Prints container name and restart count for individual containers. If no name is specified the
 container restart counts of all pods in the current namespace are shown.
 
+The --cause flag adds a CAUSE column with the likely reason for the last restart: OOM when the container was
+killed for using too much memory, ProbeKill when the liveness probe was failing before it stopped, Crash when it
+exited with a non-zero exit code and Exited when it stopped cleanly. The liveness probe events are only kept by
+the cluster for a short time, so older probe kills and pods read from a file are shown as Crash.
+
+With --repeat the restart count is sampled several times, the change between the first and last sample is shown
+in the RESTART-DELTA column and the TREND column draws the count from each sample as a small bar chart so a
+container that keeps restarting stands out. --ascii draws the chart with . : and | instead.
+
 The T column in the table output denotes S for Standard, I for init and E for Ephemerial containers`
 
 var restartsExample = `  # List individual container restart count from pods
@@ -24,6 +36,28 @@ var restartsExample = `  # List individual container restart count from pods
   # List restart count from all containers in a single pod
   %[1]s restarts my-pod-4jh36
 
+  # Check if containers are restarting right now by sampling the restart count 5 times, 30 seconds apart,
+  # the TREND column charts the count from each sample
+  %[1]s restarts --repeat 5 --interval 30s
+
+  # List restart count along with the number of restarts per hour, sorted with the fastest restarting first
+  %[1]s restarts --rate --sort '!RATE'
+
+  # List restart count along with the likely cause of the last restart, worked out from how the container
+  # stopped and the liveness probe events of the pod
+  %[1]s restarts --cause
+
+  # List restart count of all containers, highlighting the ones that have restarted more than 5 times
+  %[1]s restarts --max-restarts 5
+
+  # List restart count along with the node each pod is on and the nodes accelerator label, to see if the
+  # restarts are all on the same kind of node
+  %[1]s restarts --show-node --node-label accelerator
+
+  # List restart count with the most restarts first, --sort-default-desc makes every --sort column
+  # descending so a ! is only needed for the columns that should be ascending
+  %[1]s restarts --sort RESTARTS --sort-default-desc
+
   # List restart count of all containers named web-container searching all 
   # pods in the current namespace
   %[1]s restarts -c web-container
@@ -73,59 +107,228 @@ func Restarts(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args
 	builder.Table = &table
 	builder.ShowTreeView = commonFlagList.showTreeView
 
-	builder.Build(loopinfo)
+	repeat, err := cmd.Flags().GetInt("repeat")
+	if err != nil {
+		return err
+	}
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flag("rate").Value.String() == "true" {
+		log.Debug("loopinfo.ShowRate = true")
+		loopinfo.ShowRate = true
+	}
+
+	stdinChanged, err := builder.HasStdinChanged()
+	if err != nil {
+		return err
+	}
+	liveData := len(commonFlagList.inputFilename) == 0 && !stdinChanged
+
+	if cmd.Flag("cause").Value.String() == "true" {
+		log.Debug("loopinfo.ShowCause = true")
+		loopinfo.ShowCause = true
+		// events are only kept by the cluster, without them the cause comes from how the container stopped
+		if liveData {
+			loopinfo.Connection = &connect
+		}
+	}
+
+	if repeat > 1 {
+		// a file only ever holds a single sample so theres nothing to compare against
+		if !liveData {
+			return errors.New("--repeat can only be used with live pod data, it can not be combined with a file or stdin")
+		}
+
+		loopinfo.ShowDelta = true
+		loopinfo.ASCII = commonFlagList.useASCII
+		loopinfo.samples, err = restartsTakeSamples(&connect, args, repeat, interval)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := builder.Build(loopinfo); err != nil {
+		return err
+	}
 
 	if err := table.SortByNames(commonFlagList.sortList...); err != nil {
 		return err
 	}
 
+	if cmd.Flag("max-restarts").Changed {
+		if err := highlightRestarts(cmd, &table, commonFlagList); err != nil {
+			return err
+		}
+	}
+
 	// do we need to find the outliers, we have enough data to compute a range
 	if commonFlagList.showOddities {
-		row2Remove, err := table.ListOutOfRange(4) //3 = restarts column
-		if err != nil {
+		if err := hideInRangeRows(&table, builder.DefaultHeaderLen, commonFlagList); err != nil { // 0 = restarts column
 			return err
 		}
-		table.HideRows(row2Remove)
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	return outputTableAs(table, commonFlagList)
+
+}
+
+// highlightRestarts flags the containers that have restarted more times than the --max-restarts flag allows, unlike
+//
+//	the filters the rows are still shown so the rest of the pods are there for context
+func highlightRestarts(cmd *cobra.Command, table *Table, flags commonFlags) error {
+	maxRestarts, err := cmd.Flags().GetInt("max-restarts")
+	if err != nil {
+		return err
+	}
+	if maxRestarts < 0 {
+		return errors.New("--max-restarts must be zero or more")
+	}
+
+	// the * marker is only useful to someone reading the table, it would break the numbers in the other formats
+	withMarker := len(flags.outputAs) == 0
+	return table.HighlightAbove("RESTARTS", int64(maxRestarts), withMarker)
+}
+
+// restartsTakeSamples loads the pods repeat times waiting interval between each load, the restart counts from
+//
+//	every load are returned in order keyed by namespace/podname/container. The pods from the final load are left
+//	in the connector ready for the builder to use
+func restartsTakeSamples(connect *Connector, podNameList []string, repeat int, interval time.Duration) (map[string][]int32, error) {
+	log := logger{location: "restartsTakeSamples"}
+	log.Debug("Start")
+
+	samples := make(map[string][]int32)
+
+	for i := 0; i < repeat; i++ {
+		if i > 0 {
+			log.Debug("sleeping for", interval)
+			time.Sleep(interval)
+		}
+
+		if err := connect.LoadPods(podNameList); err != nil {
+			return samples, err
+		}
+
+		podList, err := connect.GetPods(podNameList)
+		if err != nil {
+			return samples, err
+		}
+
+		for _, pod := range podList {
+			key := pod.Namespace + "/" + pod.Name + "/"
+			for _, container := range pod.Status.InitContainerStatuses {
+				samples[key+container.Name] = append(samples[key+container.Name], container.RestartCount)
+			}
+			for _, container := range pod.Status.ContainerStatuses {
+				samples[key+container.Name] = append(samples[key+container.Name], container.RestartCount)
+			}
+			for _, container := range pod.Status.EphemeralContainerStatuses {
+				samples[key+container.Name] = append(samples[key+container.Name], container.RestartCount)
+			}
+		}
+	}
+
+	return samples, nil
+}
 
+type restarts struct {
+	Connection *Connector         // used to look up the liveness probe events for --cause, nil when reading from a file
+	ShowCause  bool               // show the likely cause of the last restart
+	ShowDelta  bool               // show the change in restart count between the first and last sample and the trend
+	ShowRate   bool               // show the number of restarts per hour since the pod started
+	ASCII      bool               // draw the trend with ascii characters
+	samples    map[string][]int32 // restart counts from each sample keyed by namespace/podname/container
 }
 
-type restarts struct{}
+// the likely causes of a restart shown in the CAUSE column
+const (
+	restartCauseOOM       = "OOM"
+	restartCauseProbeKill = "ProbeKill"
+	restartCauseCrash     = "Crash"
+	restartCauseExited    = "Exited"
+)
+
+// restart rates at or above this many restarts per hour are shown as bad
+const restartRateBad = 1.0
+
+// the bars used to draw the TREND column from the lowest to the highest restart count
+var (
+	sparklineBars      = []rune("▁▂▃▄▅▆▇█")
+	sparklineASCIIBars = []rune(".:|")
+)
 
 func (s restarts) Headers() []string {
 	return []string{
 		"RESTARTS",
+		"RESTART-DELTA",
+		"MESSAGE",
+		"RATE",
+		"CAUSE",
+		"TREND",
 	}
 }
 
 func (s restarts) BuildContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
 	out := make([][]Cell, 1)
-	out[0] = s.restartsBuildRow(info, container.RestartCount)
+	cause, err := s.restartsCauseCell(info, container)
+	if err != nil {
+		return [][]Cell{}, err
+	}
+	out[0] = append(s.restartsBuildRow(info, container), cause, s.restartsTrendCell(info))
 	return out, nil
 }
 
 func (s restarts) BuildEphemeralContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
 	out := make([][]Cell, 1)
-	out[0] = s.restartsBuildRow(info, container.RestartCount)
+	cause, err := s.restartsCauseCell(info, container)
+	if err != nil {
+		return [][]Cell{}, err
+	}
+	out[0] = append(s.restartsBuildRow(info, container), cause, s.restartsTrendCell(info))
 	return out, nil
 }
 
 func (s restarts) HideColumns(info BuilderInformation) []int {
-	return []int{}
+	var hideColumns []int
+
+	if !s.ShowDelta {
+		hideColumns = append(hideColumns, 1)
+	}
+
+	if !s.ShowRate {
+		hideColumns = append(hideColumns, 3)
+	}
+
+	if !s.ShowCause {
+		hideColumns = append(hideColumns, 4)
+	}
+
+	if !s.ShowDelta {
+		hideColumns = append(hideColumns, 5)
+	}
+
+	return hideColumns
 }
 
 func (s restarts) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
-	rowOut := make([]Cell, 1)
+	rowOut := make([]Cell, 6)
 
 	switch info.TypeName {
 	case "Pod":
 		for _, r := range rows {
-			rowOut[0].number += r[0].number // ready
+			rowOut[0].number += r[0].number // restarts
+			rowOut[1].number += r[1].number // restart delta
+			rowOut[3].float += r[3].float   // restart rate
 		}
 		rowOut[0].text = fmt.Sprintf("%d", rowOut[0].number)
+		rowOut[1].text = fmt.Sprintf("%d", rowOut[1].number)
+		rowOut[3].typ = 2
+		if s.ShowRate {
+			rowOut[3].text = fmt.Sprintf("%.2f/h", rowOut[3].float)
+		}
 	}
 
 	return rowOut, nil
@@ -141,16 +344,217 @@ func (s restarts) BuildEphemeralContainerSpec(container v1.EphemeralContainer, i
 	return out, nil
 }
 
-func (s restarts) restartsBuildRow(info BuilderInformation, restartCount int32) []Cell {
+func (s restarts) restartsBuildRow(info BuilderInformation, container v1.ContainerStatus) []Cell {
 	var cellList []Cell
+	var delta Cell
+	var message string
+
+	restartCount := container.RestartCount
+
+	if s.ShowDelta {
+		samples := s.samples[info.Namespace+"/"+info.PodName+"/"+info.Name]
+		if len(samples) > 0 {
+			change := restartCount - samples[0]
+			if change < 0 {
+				// the pod was replaced between samples so the count started again from zero
+				change = restartCount
+			}
+			colour := colourOk
+			if change > 0 {
+				colour = colourBad
+			}
+			delta = NewCellColourInt(colour, fmt.Sprintf("%d", change), int64(change))
+		} else {
+			// the container isnt in any of the samples, one that appeared part way through is compared against the
+			//  first sample it was in
+			delta = NewCellInt("", 0)
+		}
+	} else {
+		delta = NewCellInt("", 0)
+	}
+
+	// the message from the last time the container stopped explains why it was restarted
+	if container.LastTerminationState.Terminated != nil {
+		message = trimStatusMessage(container.LastTerminationState.Terminated.Message, info.PodName, info.Name)
+	}
+
+	var rate Cell
+	started := podStartTime(info.Data.pod)
+	if s.ShowRate && !started.IsZero() {
+		perHour := restartRate(restartCount, started, time.Now())
+		colour := colourOk
+		if perHour >= restartRateBad {
+			colour = colourBad
+		} else if perHour > 0 {
+			colour = colourWarn
+		}
+		rate = NewCellColourFloat(colour, fmt.Sprintf("%.2f/h", perHour), perHour)
+	} else {
+		// without a start time theres nothing to work out the rate from
+		rate = NewCellFloat("", 0)
+	}
 
 	cellList = append(cellList,
 		NewCellInt(fmt.Sprintf("%d", restartCount), int64(restartCount)),
+		delta,
+		NewCellText(message),
+		rate,
 	)
 
 	return cellList
 }
 
+// restartsTrendCell returns the TREND cell drawn from the restart count of each sample, the bar is coloured when
+//
+//	the container restarted while it was being sampled
+func (s restarts) restartsTrendCell(info BuilderInformation) Cell {
+	if !s.ShowDelta {
+		return NewCellText("")
+	}
+
+	samples := s.samples[info.Namespace+"/"+info.PodName+"/"+info.Name]
+	bars := sparklineBars
+	if s.ASCII {
+		bars = sparklineASCIIBars
+	}
+
+	trend := sparkline(samples, bars)
+	if len(samples) > 1 && samples[len(samples)-1] != samples[0] {
+		return NewCellColourText(colourBad, trend)
+	}
+	return NewCellText(trend)
+}
+
+// sparkline draws one bar for each value, scaled between the lowest and highest value so even a single restart shows
+//
+//	as a step. when every value is the same the lowest bar is used
+func sparkline(values []int32, bars []rune) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		if v < low {
+			low = v
+		}
+		if v > high {
+			high = v
+		}
+	}
+
+	line := make([]rune, len(values))
+	for i, v := range values {
+		level := 0
+		if high > low {
+			level = int(v-low) * (len(bars) - 1) / int(high-low)
+		}
+		line[i] = bars[level]
+	}
+
+	return string(line)
+}
+
+// podStartTime returns the time the pod was started by the kubelet, falling back to the creation time for pods that
+//
+//	havent been started yet
+func podStartTime(pod v1.Pod) time.Time {
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// restartRate returns the number of restarts per hour between started and now, containers that have been running for
+//
+//	less than a minute are treated as a minute old so a single early restart doesnt report a huge rate
+func restartRate(restartCount int32, started time.Time, now time.Time) float64 {
+	if restartCount <= 0 || started.IsZero() {
+		return 0
+	}
+
+	age := now.Sub(started)
+	if age < time.Minute {
+		age = time.Minute
+	}
+
+	return float64(restartCount) / age.Hours()
+}
+
+// restartsCauseCell returns the CAUSE cell for the container, the events of the pod are only looked up when the
+//
+//	container has restarted so pods without restarts dont cost an api call
+func (s restarts) restartsCauseCell(info BuilderInformation, container v1.ContainerStatus) (Cell, error) {
+	if !s.ShowCause || container.RestartCount == 0 {
+		return NewCellText(""), nil
+	}
+
+	var eventList []v1.Event
+	if s.Connection != nil {
+		var err error
+		eventList, err = s.Connection.GetEvents(info.Data.pod.Namespace)
+		if err != nil {
+			return Cell{}, err
+		}
+	}
+
+	cause := restartCause(container, containerEvents(eventList, info.Data.pod, container.Name))
+	colour := colourBad
+	if cause == restartCauseExited {
+		colour = colourWarn
+	}
+	return NewCellColourText(colour, cause), nil
+}
+
+// restartCause classifies the last restart of the container by how it stopped. OOMKilled always wins as the kernel
+//
+//	kill is certain, a failing liveness probe seen while the container was running beats the exit code as the
+//	kubelet kills the container itself, leaving any other non-zero exit code as a crash
+func restartCause(container v1.ContainerStatus, containerEvents []v1.Event) string {
+	terminated := container.LastTerminationState.Terminated
+	if container.RestartCount == 0 || terminated == nil {
+		return ""
+	}
+
+	if terminated.Reason == "OOMKilled" {
+		return restartCauseOOM
+	}
+
+	for _, event := range containerEvents {
+		if !isLivenessFailure(event) {
+			continue
+		}
+		seen := eventLastSeen(event)
+		// the probe has to have failed while the container that stopped was running
+		if !terminated.StartedAt.IsZero() && seen.Before(terminated.StartedAt.Time) {
+			continue
+		}
+		if !terminated.FinishedAt.IsZero() && seen.After(terminated.FinishedAt.Add(time.Minute)) {
+			continue
+		}
+		return restartCauseProbeKill
+	}
+
+	if terminated.ExitCode != 0 {
+		return restartCauseCrash
+	}
+
+	return restartCauseExited
+}
+
+// isLivenessFailure returns true for the events the kubelet records when a liveness probe fails, either the Unhealthy
+//
+//	probe failure or the Killing event when it gives up and restarts the container
+func isLivenessFailure(event v1.Event) bool {
+	switch event.Reason {
+	case "Unhealthy":
+		return strings.HasPrefix(event.Message, "Liveness probe")
+	case "Killing":
+		return strings.Contains(event.Message, "failed liveness probe")
+	}
+	return false
+}
+
 func (s restarts) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
 	return [][]Cell{}, nil
 }
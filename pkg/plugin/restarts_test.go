@@ -0,0 +1,377 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// *****************
+// restartRate
+// *****************
+type restartRateTest struct {
+	restarts int32
+	age      time.Duration
+	expected float64
+}
+
+var restartRateTests = []restartRateTest{
+	{0, time.Hour, 0},
+	{10, 5 * time.Minute, 120},
+	{10, 30 * 24 * time.Hour, 10.0 / 720},
+	{3, 2 * time.Hour, 1.5},
+	// anything under a minute counts as a minute
+	{1, 10 * time.Second, 60},
+}
+
+func TestRestartRate(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, test := range restartRateTests {
+		if output := restartRate(test.restarts, now.Add(-test.age), now); output != test.expected {
+			t.Errorf("Output %f not equal to expected %f", output, test.expected)
+		}
+	}
+
+	if output := restartRate(5, time.Time{}, now); output != 0 {
+		t.Errorf("Output %f not equal to expected 0 when there is no start time", output)
+	}
+
+}
+
+// *****************
+// restarts with node columns
+// *****************
+func TestRestartsNodeColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/pods":
+			pod := func(name string, node string, restarts int32) v1.Pod {
+				return v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec:       v1.PodSpec{NodeName: node, Containers: []v1.Container{{Name: "web"}}},
+					Status:     v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "web", RestartCount: restarts}}},
+				}
+			}
+			json.NewEncoder(w).Encode(v1.PodList{
+				TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+				Items:    []v1.Pod{pod("web-1", "gpu-node", 7), pod("web-2", "cpu-node", 0)},
+			})
+		case "/api/v1/nodes/gpu-node", "/api/v1/nodes/cpu-node":
+			name := strings.TrimPrefix(r.URL.Path, "/api/v1/nodes/")
+			json.NewEncoder(w).Encode(v1.Node{
+				TypeMeta:   metav1.TypeMeta{Kind: "Node", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"accelerator": strings.TrimSuffix(name, "-node")}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	connect := Connector{}
+	if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	flags := commonFlags{showNodeName: true, labelNodeName: "accelerator"}
+	connect.Flags = flags
+
+	table := Table{}
+	builder := RowBuilder{LoopStatus: true, Connection: &connect, Table: &table}
+	builder.SetFlagsFrom(flags)
+	if err := builder.Build(restarts{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := map[string][3]string{
+		"web-1": {"gpu-node", "gpu", "7"},
+		"web-2": {"cpu-node", "cpu", "0"},
+	}
+	columns := map[string]int{}
+	for idx, head := range table.head {
+		if !head.hidden {
+			columns[head.title] = idx
+		}
+	}
+	for _, name := range []string{"NODE", "accelerator", "RESTARTS"} {
+		if _, ok := columns[name]; !ok {
+			t.Fatalf("column %s is missing from %v", name, columns)
+		}
+	}
+
+	if rows := table.getVisibleRows(); len(rows) != len(expected) {
+		t.Fatalf("Output %d rows not equal to expected %d", len(rows), len(expected))
+	}
+	for _, row := range table.getVisibleRows() {
+		want := expected[row[columns["PODNAME"]].text]
+		output := [3]string{row[columns["NODE"]].text, row[columns["accelerator"]].text, row[columns["RESTARTS"]].text}
+		if output != want {
+			t.Errorf("Output %v not equal to expected %v", output, want)
+		}
+	}
+
+}
+
+// *****************
+// restartCause
+// *****************
+type restartCauseTest struct {
+	reason   string
+	exitCode int32
+	events   []v1.Event
+	expected string
+}
+
+var causeStarted = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func livenessEvent(reason string, message string, seen time.Time) v1.Event {
+	return v1.Event{Reason: reason, Message: message, LastTimestamp: metav1.NewTime(seen)}
+}
+
+var restartCauseTests = []restartCauseTest{
+	{"OOMKilled", 137, nil, restartCauseOOM},
+	// the kernel kill wins even when the probe was failing at the same time
+	{"OOMKilled", 137, []v1.Event{livenessEvent("Unhealthy", "Liveness probe failed: timeout", causeStarted.Add(time.Minute))}, restartCauseOOM},
+	{"Error", 137, []v1.Event{livenessEvent("Unhealthy", "Liveness probe failed: HTTP probe failed with statuscode: 500", causeStarted.Add(time.Minute))}, restartCauseProbeKill},
+	{"Error", 143, []v1.Event{livenessEvent("Killing", "Container web failed liveness probe, will be restarted", causeStarted.Add(time.Minute))}, restartCauseProbeKill},
+	// readiness failures dont restart the container
+	{"Error", 1, []v1.Event{livenessEvent("Unhealthy", "Readiness probe failed: timeout", causeStarted.Add(time.Minute))}, restartCauseCrash},
+	// probe failures from before the container started belong to an earlier restart
+	{"Error", 1, []v1.Event{livenessEvent("Unhealthy", "Liveness probe failed: timeout", causeStarted.Add(-time.Hour))}, restartCauseCrash},
+	{"Completed", 0, nil, restartCauseExited},
+}
+
+func TestRestartCause(t *testing.T) {
+
+	for _, test := range restartCauseTests {
+		container := v1.ContainerStatus{
+			Name:         "web",
+			RestartCount: 2,
+			LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+				Reason:     test.reason,
+				ExitCode:   test.exitCode,
+				StartedAt:  metav1.NewTime(causeStarted),
+				FinishedAt: metav1.NewTime(causeStarted.Add(2 * time.Minute)),
+			}},
+		}
+		if output := restartCause(container, test.events); output != test.expected {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+	// containers that never restarted have no cause
+	if output := restartCause(v1.ContainerStatus{Name: "web"}, nil); output != "" {
+		t.Errorf("Output %q not equal to expected %q", output, "")
+	}
+
+}
+
+// *****************
+// restarts oddities column
+// *****************
+func TestRestartsOdditiesColumn(t *testing.T) {
+	content := ""
+	for i, restarts := range []int{0, 1, 1, 2, 1, 0, 42} {
+		content += fmt.Sprintf("apiVersion: v1\nkind: Pod\nmetadata:\n  name: api-%d\n  namespace: default\nspec:\n  containers:\n  - name: api\nstatus:\n  containerStatuses:\n  - name: api\n    restartCount: %d\n---\n", i, restarts)
+	}
+	filename := filepath.Join(t.TempDir(), "pods.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	table := Table{}
+	builder := RowBuilder{LoopStatus: true, Connection: &Connector{}, Table: &table}
+	builder.SetFlagsFrom(commonFlags{inputFilename: filename})
+	if err := builder.Build(restarts{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// --oddities ranges over the first column after the default columns
+	if title := table.head[builder.DefaultHeaderLen].title; title != "RESTARTS" {
+		t.Fatalf("Output column %s not equal to expected RESTARTS", title)
+	}
+	inRange, _, err := table.ListOutOfRange(builder.DefaultHeaderLen)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(inRange) != 6 {
+		t.Errorf("Output %d rows in range not equal to expected 6", len(inRange))
+	}
+
+}
+
+// *****************
+// restarts message column
+// *****************
+type restartsMessageTest struct {
+	lastState string
+	expected  string
+}
+
+var restartsMessageTests = []restartsMessageTest{
+	{"", ""},
+	{"    lastState:\n      terminated:\n        exitCode: 137\n        message: out of memory\n", "out of memory"},
+	// the pod and container names are already in the row so they are trimmed from the message
+	{"    lastState:\n      terminated:\n        exitCode: 1\n        message: failed to start container=api pod=api-0_default\n", "failed to start"},
+}
+
+func TestRestartsMessage(t *testing.T) {
+
+	for _, test := range restartsMessageTests {
+		pods := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: api-0\n  namespace: default\nspec:\n  containers:\n  - name: api\nstatus:\n  containerStatuses:\n  - name: api\n    restartCount: 1\n" + test.lastState
+		tbl, _ := buildTestTable(t, RowBuilder{LoopStatus: true}, restarts{}, commonFlags{}, pods)
+
+		if output := columnText(tbl, "MESSAGE"); !reflect.DeepEqual(output, []string{test.expected}) {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// restarts delta column
+// *****************
+type restartsDeltaTest struct {
+	restarts int32
+	samples  []int32
+	expected string
+}
+
+var restartsDeltaTests = []restartsDeltaTest{
+	{5, []int32{5, 5, 5}, "0"},
+	{5, []int32{2, 3, 5}, "3"},
+	// the pod was replaced between samples so the count started again from zero
+	{2, []int32{7, 0, 2}, "2"},
+	// the container isnt in any of the samples
+	{4, nil, ""},
+}
+
+func TestRestartsDelta(t *testing.T) {
+
+	for _, test := range restartsDeltaTests {
+		pods := fmt.Sprintf("apiVersion: v1\nkind: Pod\nmetadata:\n  name: api-0\n  namespace: default\nspec:\n  containers:\n  - name: api\nstatus:\n  containerStatuses:\n  - name: api\n    restartCount: %d\n", test.restarts)
+		loop := restarts{ShowDelta: true, samples: map[string][]int32{}}
+		if test.samples != nil {
+			loop.samples["default/api-0/api"] = test.samples
+		}
+		tbl, _ := buildTestTable(t, RowBuilder{LoopStatus: true}, loop, commonFlags{}, pods)
+
+		if output := columnText(tbl, "RESTART-DELTA"); !reflect.DeepEqual(output, []string{test.expected}) {
+			t.Errorf("Output %q not equal to expected %q (samples %v)", output, test.expected, test.samples)
+		}
+	}
+
+}
+
+// *****************
+// restarts BuildBranch
+// *****************
+func TestRestartsBuildBranch(t *testing.T) {
+	rows := [][]Cell{
+		{NewCellInt("3", 3), NewCellInt("1", 1), NewCellText(""), NewCellFloat("0.50/h", 0.5)},
+		{NewCellInt("4", 4), NewCellInt("2", 2), NewCellText(""), NewCellFloat("0.25/h", 0.25)},
+	}
+
+	// the pod row adds up the restarts, delta and rate of its containers
+	row, err := restarts{ShowRate: true}.BuildBranch(BuilderInformation{TypeName: "Pod"}, rows)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	output := []string{row[0].text, row[1].text, row[3].text}
+	expected := []string{"7", "3", "0.75/h"}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+
+}
+
+// *****************
+// restartsTakeSamples
+// *****************
+func TestRestartsTakeSamples(t *testing.T) {
+	// the sidecar is only added to the pod after the first sample
+	load := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		statuses := []v1.ContainerStatus{{Name: "web", RestartCount: int32(2 + load)}}
+		if load > 0 {
+			statuses = append(statuses, v1.ContainerStatus{Name: "sidecar", RestartCount: int32(load)})
+		}
+		load++
+		json.NewEncoder(w).Encode(v1.PodList{
+			TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+			Items: []v1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+				Status:     v1.PodStatus{ContainerStatuses: statuses},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	connect := Connector{}
+	if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	samples, err := restartsTakeSamples(&connect, []string{}, 3, 0)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := map[string][]int32{
+		"default/web-1/web":     {2, 3, 4},
+		"default/web-1/sidecar": {1, 2},
+	}
+	if !reflect.DeepEqual(samples, expected) {
+		t.Errorf("Output %v not equal to expected %v", samples, expected)
+	}
+
+}
+
+// *****************
+// sparkline
+// *****************
+type sparklineTest struct {
+	values   []int32
+	ascii    bool
+	expected string
+}
+
+var sparklineTests = []sparklineTest{
+	{[]int32{}, false, ""},
+	{[]int32{4, 4, 4, 4}, false, "▁▁▁▁"},
+	{[]int32{0, 1, 2, 3, 4, 5, 6, 7}, false, "▁▂▃▄▅▆▇█"},
+	{[]int32{10, 10, 11, 14}, false, "▁▁▂█"},
+	// the pod was replaced so the count started again from zero
+	{[]int32{6, 7, 0, 1}, false, "▇█▁▂"},
+	{[]int32{0, 1, 2, 4}, true, "..:|"},
+}
+
+func TestSparkline(t *testing.T) {
+
+	for _, test := range sparklineTests {
+		bars := sparklineBars
+		if test.ascii {
+			bars = sparklineASCIIBars
+		}
+		if output := sparkline(test.values, bars); output != test.expected {
+			t.Errorf("Output %q not equal to expected %q (values %v)", output, test.expected, test.values)
+		}
+	}
+
+}
@@ -1,7 +1,9 @@
 package plugin
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
@@ -12,6 +14,12 @@ var securityShort = "Shows details of configured container security settings"
 
 var securityDescription = ` View SecurityContext configuration that has been applied to the containers. Shows 
 runAsUser and runAsGroup fields among others.
+
+Using --profiles shows the seccomp profile of each container, taken from the container or pod securityContext
+or the older seccomp annotations, along with the AppArmor profile from the pods
+container.apparmor.security.beta.kubernetes.io annotations. Adding --lint flags profiles that are unconfined and
+volume mounts with Bidirectional mount propagation, which lets the container mount filesystems on the host, these
+fail --fail-on-warn. Containers without a read only root filesystem are listed as a warning that doesnt fail.
 `
 
 var securityExample = `  # List container security info from pods
@@ -35,6 +43,13 @@ var securityExample = `  # List container security info from pods
   # namespace sorted by pod name in ascending order
   %[1]s security -c web-container --sort PODNAME
 
+  # List the seccomp and AppArmor profile of each container
+  %[1]s security --profiles
+
+  # Flag containers that run without a seccomp or AppArmor profile or have a Bidirectional mount, exiting with a
+  # non zero exit code
+  %[1]s security --lint --fail-on-warn
+
   # List container security info from all pods where label app matches web
   %[1]s security -l app=web
 
@@ -78,6 +93,27 @@ func Security(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args
 		loopinfo.ShowSELinuxOptions = true
 	}
 
+	if cmd.Flag("profiles").Value.String() == "true" {
+		log.Debug("loopinfo.ShowProfiles = true")
+		loopinfo.ShowProfiles = true
+	}
+
+	if cmd.Flag("lint").Value.String() == "true" {
+		log.Debug("loopinfo.ShowLint = true")
+		// the lint checks are only run against the profiles
+		loopinfo.ShowLint = true
+		loopinfo.ShowProfiles = true
+	}
+
+	if loopinfo.ShowProfiles && loopinfo.ShowSELinuxOptions {
+		return errors.New("you may not use the selinux flag with the profiles or lint flags")
+	}
+
+	failOnWarn := cmd.Flag("fail-on-warn").Value.String() == "true"
+	if failOnWarn && !loopinfo.ShowLint {
+		return errors.New("--fail-on-warn can only be used with --lint")
+	}
+
 	if err := builder.Build(&loopinfo); err != nil {
 		return err
 	}
@@ -86,16 +122,42 @@ func Security(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	if err := outputTableAs(table, commonFlagList); err != nil {
+		return err
+	}
+
+	if failOnWarn && len(loopinfo.lintFailures) > 0 {
+		return newIceError(ErrLintFailed, fmt.Errorf("security lint failed:\n  %s", strings.Join(loopinfo.lintFailures, "\n  ")))
+	}
 
+	return nil
 }
 
 type security struct {
 	ShowSELinuxOptions bool
+	ShowProfiles       bool // show the seccomp and apparmor profiles in place of the security context
+	ShowLint           bool // flag unconfined profiles, bidirectional mounts and writable root filesystems in the WARN column
+
+	lintFailures []string // namespace/pod/container: warning, for every container with an unconfined profile or bidirectional mount
 }
 
+// annotations used to set the seccomp and apparmor profiles before they were added to the securityContext
+const (
+	annotationSeccompPod        = "seccomp.security.alpha.kubernetes.io/pod"
+	annotationSeccompContainer  = "container.seccomp.security.alpha.kubernetes.io/" // followed by the container name
+	annotationAppArmorContainer = "container.apparmor.security.beta.kubernetes.io/" // followed by the container name
+)
+
 func (s *security) Headers() []string {
+	if s.ShowProfiles {
+		return []string{
+			"SECCOMP",
+			"SECCOMP_PROFILE",
+			"APPARMOR",
+			"WARN",
+		}
+	}
+
 	if s.ShowSELinuxOptions {
 		return []string{
 			"USER",
@@ -124,13 +186,18 @@ func (s *security) BuildEphemeralContainerStatus(container v1.ContainerStatus, i
 }
 
 func (s *security) HideColumns(info BuilderInformation) []int {
+	if s.ShowProfiles && !s.ShowLint {
+		return []int{3}
+	}
 	return []int{}
 }
 
 func (s *security) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
 	var rowOut []Cell
 
-	if s.ShowSELinuxOptions {
+	if s.ShowProfiles {
+		rowOut = make([]Cell, 4)
+	} else if s.ShowSELinuxOptions {
 		rowOut = make([]Cell, 4)
 	} else {
 		rowOut = make([]Cell, 6)
@@ -140,7 +207,9 @@ func (s *security) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell,
 
 func (s *security) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
 	out := make([][]Cell, 1)
-	if s.ShowSELinuxOptions {
+	if s.ShowProfiles {
+		out[0] = s.profilesBuildRow(info, container.Name, container.SecurityContext, container.VolumeMounts, info.Data.pod)
+	} else if s.ShowSELinuxOptions {
 		out[0] = s.seLinuxBuildRow(info, container.SecurityContext, info.Data.pod.Spec.SecurityContext)
 	} else {
 		out[0] = s.securityBuildRow(info, container.SecurityContext, info.Data.pod.Spec.SecurityContext)
@@ -150,7 +219,9 @@ func (s *security) BuildContainerSpec(container v1.Container, info BuilderInform
 
 func (s *security) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
 	out := make([][]Cell, 1)
-	if s.ShowSELinuxOptions {
+	if s.ShowProfiles {
+		out[0] = s.profilesBuildRow(info, container.Name, container.SecurityContext, container.VolumeMounts, info.Data.pod)
+	} else if s.ShowSELinuxOptions {
 		out[0] = s.seLinuxBuildRow(info, container.SecurityContext, info.Data.pod.Spec.SecurityContext)
 	} else {
 		out[0] = s.securityBuildRow(info, container.SecurityContext, info.Data.pod.Spec.SecurityContext)
@@ -169,7 +240,7 @@ func (s *security) securityBuildRow(info BuilderInformation, csc *v1.SecurityCon
 
 	if psc != nil {
 		if psc.RunAsNonRoot != nil {
-			ranr = NewCellText(fmt.Sprintf("%t", *psc.RunAsNonRoot))
+			ranr = NewCellBool(*psc.RunAsNonRoot)
 		}
 
 		if psc.RunAsUser != nil {
@@ -183,19 +254,19 @@ func (s *security) securityBuildRow(info BuilderInformation, csc *v1.SecurityCon
 
 	if csc != nil {
 		if csc.AllowPrivilegeEscalation != nil {
-			ape = NewCellText(fmt.Sprintf("%t", *csc.AllowPrivilegeEscalation))
+			ape = NewCellBool(*csc.AllowPrivilegeEscalation)
 		}
 
 		if csc.Privileged != nil {
-			p = NewCellText(fmt.Sprintf("%t", *csc.Privileged))
+			p = NewCellBool(*csc.Privileged)
 		}
 
 		if csc.ReadOnlyRootFilesystem != nil {
-			rorfs = NewCellText(fmt.Sprintf("%t", *csc.ReadOnlyRootFilesystem))
+			rorfs = NewCellBool(*csc.ReadOnlyRootFilesystem)
 		}
 
 		if csc.RunAsNonRoot != nil {
-			ranr = NewCellText(fmt.Sprintf("%t", *csc.RunAsNonRoot))
+			ranr = NewCellBool(*csc.RunAsNonRoot)
 		}
 
 		if csc.RunAsUser != nil {
@@ -287,6 +358,114 @@ func (s *security) seLinuxBuildRow(info BuilderInformation, csc *v1.SecurityCont
 	return cellList
 }
 
+// seccompProfile returns the seccomp profile type and localhost profile name for the container, the containers
+//
+//	securityContext wins over the pods and both win over the older annotations
+func seccompProfile(containerName string, csc *v1.SecurityContext, pod v1.Pod) (string, string) {
+	var profile *v1.SeccompProfile
+
+	if pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.SeccompProfile != nil {
+		profile = pod.Spec.SecurityContext.SeccompProfile
+	}
+	if csc != nil && csc.SeccompProfile != nil {
+		profile = csc.SeccompProfile
+	}
+
+	if profile != nil {
+		localhost := ""
+		if profile.LocalhostProfile != nil {
+			localhost = *profile.LocalhostProfile
+		}
+		return string(profile.Type), localhost
+	}
+
+	annotation, ok := pod.Annotations[annotationSeccompContainer+containerName]
+	if !ok {
+		annotation, ok = pod.Annotations[annotationSeccompPod]
+	}
+	if !ok {
+		return "", ""
+	}
+
+	switch {
+	case annotation == "runtime/default" || annotation == "docker/default":
+		return string(v1.SeccompProfileTypeRuntimeDefault), ""
+	case annotation == "unconfined":
+		return string(v1.SeccompProfileTypeUnconfined), ""
+	case strings.HasPrefix(annotation, "localhost/"):
+		return string(v1.SeccompProfileTypeLocalhost), strings.TrimPrefix(annotation, "localhost/")
+	}
+	return annotation, ""
+}
+
+// bidirectionalMounts returns the mount path of each volume mount using Bidirectional mount propagation, mounts made
+//
+//	inside the container are passed back to the host so only privileged containers are allowed to use it
+func bidirectionalMounts(mounts []v1.VolumeMount) []string {
+	var paths []string
+	for _, mount := range mounts {
+		if mount.MountPropagation != nil && *mount.MountPropagation == v1.MountPropagationBidirectional {
+			paths = append(paths, mount.MountPath)
+		}
+	}
+	return paths
+}
+
+// readOnlyRootFilesystem returns true when the containers securityContext mounts its root filesystem read only, the
+//
+//	default is writable
+func readOnlyRootFilesystem(csc *v1.SecurityContext) bool {
+	return csc != nil && csc.ReadOnlyRootFilesystem != nil && *csc.ReadOnlyRootFilesystem
+}
+
+func (s *security) profilesBuildRow(info BuilderInformation, containerName string, csc *v1.SecurityContext, mounts []v1.VolumeMount, pod v1.Pod) []Cell {
+	var warnings []string
+	var notes []string // shown in the WARN column but dont fail --fail-on-warn
+
+	seccompType, seccompLocalhost := seccompProfile(containerName, csc, pod)
+	appArmor := pod.Annotations[annotationAppArmorContainer+containerName]
+
+	seccompColour := [2]int{-1, 0}
+	appArmorColour := [2]int{-1, 0}
+	if seccompType == string(v1.SeccompProfileTypeUnconfined) {
+		seccompColour = colourBad
+		warnings = append(warnings, "seccomp is Unconfined")
+	}
+	if appArmor == "unconfined" {
+		appArmorColour = colourBad
+		warnings = append(warnings, "apparmor is unconfined")
+	}
+
+	for _, path := range bidirectionalMounts(mounts) {
+		warnings = append(warnings, "mount "+path+" is Bidirectional")
+	}
+
+	if len(seccompType) == 0 {
+		// not a failure as the kubelet can be set to apply RuntimeDefault to every pod
+		notes = append(notes, "seccomp not set, unconfined unless the kubelet default is enabled")
+	}
+	if !readOnlyRootFilesystem(csc) {
+		notes = append(notes, "root filesystem is writable")
+	}
+
+	warnCell := NewCellText("")
+	if s.ShowLint {
+		if len(warnings) > 0 {
+			warnCell = NewCellColourText(colourBad, strings.Join(append(warnings, notes...), "; "))
+			s.lintFailures = append(s.lintFailures, fmt.Sprintf("%s/%s/%s: %s", info.Namespace, info.PodName, info.Name, strings.Join(warnings, ", ")))
+		} else if len(notes) > 0 {
+			warnCell = NewCellColourText(colourWarn, strings.Join(notes, "; "))
+		}
+	}
+
+	return []Cell{
+		NewCellColourText(seccompColour, seccompType),
+		NewCellText(seccompLocalhost),
+		NewCellColourText(appArmorColour, appArmor),
+		warnCell,
+	}
+}
+
 func (s *security) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
 	return [][]Cell{}, nil
 }
@@ -0,0 +1,133 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// *****************
+// seccompProfile
+// *****************
+type seccompProfileTest struct {
+	containerName     string
+	csc               *v1.SecurityContext
+	podProfile        *v1.SeccompProfile
+	annotations       map[string]string
+	expectedType      string
+	expectedLocalhost string
+}
+
+var localhostProfile = "profiles/audit.json"
+
+var seccompProfileTests = []seccompProfileTest{
+	{"web", nil, nil, nil, "", ""},
+	{"web", nil, &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}, nil, "RuntimeDefault", ""},
+	// the container wins over the pod
+	{"web", &v1.SecurityContext{SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeUnconfined}}, &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}, nil, "Unconfined", ""},
+	{"web", &v1.SecurityContext{SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeLocalhost, LocalhostProfile: &localhostProfile}}, nil, nil, "Localhost", "profiles/audit.json"},
+	// the securityContext wins over the annotations
+	{"web", nil, &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}, map[string]string{annotationSeccompPod: "unconfined"}, "RuntimeDefault", ""},
+	{"web", nil, nil, map[string]string{annotationSeccompPod: "docker/default"}, "RuntimeDefault", ""},
+	{"web", nil, nil, map[string]string{annotationSeccompPod: "runtime/default", annotationSeccompContainer + "web": "unconfined"}, "Unconfined", ""},
+	{"web", nil, nil, map[string]string{annotationSeccompContainer + "db": "unconfined"}, "", ""},
+	{"web", nil, nil, map[string]string{annotationSeccompContainer + "web": "localhost/profiles/audit.json"}, "Localhost", "profiles/audit.json"},
+}
+
+func TestSeccompProfile(t *testing.T) {
+
+	for _, test := range seccompProfileTests {
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}
+		if test.podProfile != nil {
+			pod.Spec.SecurityContext = &v1.PodSecurityContext{SeccompProfile: test.podProfile}
+		}
+
+		profileType, localhost := seccompProfile(test.containerName, test.csc, pod)
+		if profileType != test.expectedType || localhost != test.expectedLocalhost {
+			t.Errorf("Output %q %q not equal to expected %q %q", profileType, localhost, test.expectedType, test.expectedLocalhost)
+		}
+	}
+
+}
+
+// *****************
+// bidirectionalMounts
+// *****************
+func TestBidirectionalMounts(t *testing.T) {
+	bidirectional := v1.MountPropagationBidirectional
+	hostToContainer := v1.MountPropagationHostToContainer
+
+	tests := []struct {
+		mounts   []v1.VolumeMount
+		expected []string
+	}{
+		{nil, nil},
+		{[]v1.VolumeMount{{MountPath: "/data"}, {MountPath: "/host", MountPropagation: &hostToContainer}}, nil},
+		{[]v1.VolumeMount{{MountPath: "/data"}, {MountPath: "/var/lib/kubelet", MountPropagation: &bidirectional}}, []string{"/var/lib/kubelet"}},
+	}
+
+	for _, test := range tests {
+		output := bidirectionalMounts(test.mounts)
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// readOnlyRootFilesystem
+// *****************
+func TestReadOnlyRootFilesystem(t *testing.T) {
+	yes := true
+	no := false
+
+	tests := []struct {
+		csc      *v1.SecurityContext
+		expected bool
+	}{
+		{nil, false},
+		{&v1.SecurityContext{}, false},
+		{&v1.SecurityContext{ReadOnlyRootFilesystem: &no}, false},
+		{&v1.SecurityContext{ReadOnlyRootFilesystem: &yes}, true},
+	}
+
+	for _, test := range tests {
+		if output := readOnlyRootFilesystem(test.csc); output != test.expected {
+			t.Errorf("Output %v not equal to expected %v for %v", output, test.expected, test.csc)
+		}
+	}
+
+}
+
+// *****************
+// profilesBuildRow lint
+// *****************
+func TestProfilesBuildRowLint(t *testing.T) {
+	bidirectional := v1.MountPropagationBidirectional
+	yes := true
+	pod := v1.Pod{Spec: v1.PodSpec{SecurityContext: &v1.PodSecurityContext{SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}}}}
+
+	tests := []struct {
+		csc      *v1.SecurityContext
+		mounts   []v1.VolumeMount
+		expected string
+		failed   bool
+	}{
+		{&v1.SecurityContext{ReadOnlyRootFilesystem: &yes}, nil, "", false},
+		// a writable root filesystem is only a warning
+		{nil, nil, "root filesystem is writable", false},
+		{&v1.SecurityContext{ReadOnlyRootFilesystem: &yes}, []v1.VolumeMount{{MountPath: "/host", MountPropagation: &bidirectional}}, "mount /host is Bidirectional", true},
+	}
+
+	for _, test := range tests {
+		s := security{ShowProfiles: true, ShowLint: true}
+		cells := s.profilesBuildRow(BuilderInformation{Name: "web"}, "web", test.csc, test.mounts, pod)
+		if cells[3].text != test.expected || (len(s.lintFailures) > 0) != test.failed {
+			t.Errorf("Output %q (failed %v) not equal to expected %q (failed %v)", cells[3].text, len(s.lintFailures) > 0, test.expected, test.failed)
+		}
+	}
+
+}
@@ -1,7 +1,12 @@
 package plugin
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,6 +25,10 @@ are shown slong with current ready and running state. Pods and containers can al
 by name. If no name is specified the container state of all pods in the current namespace is
 shown.
 
+The UPTIME% column added by --uptime is an approximation, kubernetes only keeps the current run and the
+last terminated run of each container so time spent running before the last restart is not counted. For a
+container that has restarted more than once the real uptime may be higher than shown.
+
 The T column in the table output denotes S for Standard and I for init containers`
 
 var statusExample = `  # List individual container status from pods
@@ -28,16 +37,111 @@ var statusExample = `  # List individual container status from pods
   # List conttainers status from pods output in JSON format
   %[1]s status -o json
 
+  # List containers status from pods as JSON with the containers nested under each pod
+  %[1]s status -o json-nested
+
+  # Wait up to 2 minutes for all the containers of the web pods to be ready, useful in a deploy script
+  # straight after kubectl apply
+  %[1]s status --wait-ready --timeout 2m -l app=web
+
+  # Print a timestamped line each time a container restarts or changes state, checking every 10 seconds
+  %[1]s status --follow --interval 10s
+
+  # List only the ephemeral debug containers along with the container each one is attached to
+  %[1]s status --only-ephemeral --details
+
+  # Compare two pods from the same deployment and list only the values that are different
+  %[1]s status --diff web-7d4b9c-abcde web-7d4b9c-fghij
+
+  # Compare the pods in the staging and prod namespaces, pods are paired by their name with the
+  # generated suffix removed
+  %[1]s status --diff staging/ prod/
+
+  # Print the complete status object of one container as the api returns it, rather than the columns
+  # picked out by ice, useful when the table doesnt have the field you need
+  %[1]s status my-pod-4jh36 -c web-container -o json --single
+
   # List status from all container in a single pod
   %[1]s status my-pod-4jh36
 
   # List previous container status from a single pod
   %[1]s status -p my-pod-4jh36
 
+  # List container status showing every column including the node name, id and message
+  %[1]s status --wide
+
   # List status of all containers named web-container searching all 
   # pods in the current namespace
   %[1]s status -c web-container
 
+  # List status of all containers named either web-container or sidecar
+  %[1]s status -c web-container,sidecar
+
+  # List status of every container except the service mesh proxies
+  %[1]s status --exclude-container istio-proxy,linkerd-proxy
+
+  # List the short id and runtime of each container, to find the same containers with crictl on the node
+  %[1]s status --id --show-node
+
+  # List the status of the containers from job pods along with the pods restart policy, a failed container
+  # in a pod with a Never restart policy will not be retried
+  %[1]s status --completed --show-restart-policy
+
+  # List the status of the containers and mark the init containers that are native sidecars, they have a
+  # restartPolicy of Always so they keep running alongside the other containers
+  %[1]s status --show-sidecar
+
+  # List the container state the kubelet on each node is reporting next to the state held by the api server,
+  # useful when the api server is slow to catch up with a container that keeps restarting
+  %[1]s status --kubelet
+
+  # Show the tree view with each single container pod on one line, pods with sidecars are still expanded
+  %[1]s status --compact-tree
+
+  # List container status with shorter column names
+  %[1]s status --rename RESTARTS=RST,CONTAINER=C
+
+  # List the status of all containers, highlighting any that have restarted more than 5 times
+  %[1]s status --max-restarts 5
+
+  # List the status of all containers with the percentage of the pods lifetime each one has been running
+  %[1]s status --uptime --sort 'UPTIME%%'
+
+  # Save the state of every container at the start of a shift, then later list the restarts and state changes since
+  %[1]s status -A --save baseline.json
+  %[1]s status -A --compare baseline.json
+
+  # List one row per pod with the number of containers, how many are ready, the total restarts and the names
+  # of the containers that are not running, sorted with the most restarts first
+  %[1]s status --containers-summary-per-pod --sort '!RESTARTS'
+
+  # List container status drawn with borders around each cell
+  %[1]s status --style box
+
+  # Show the order each container started in, useful for finding the slow step of a pod start up
+  %[1]s status --timeline
+
+  # List status of containers from running pods only, leaving out completed jobs
+  %[1]s status --phase Running -l app=cron-worker
+
+  # List the containers from completed jobs along with their exit codes and finish times
+  %[1]s status --completed
+
+  # List container status leaving out the pods from completed jobs
+  %[1]s status --hide-completed
+
+  # List every container that is stuck waiting across all namespaces
+  %[1]s status --state waiting -A
+
+  # List the status of the containers running the 1.21 tag of the nginx image
+  %[1]s status --image-filter nginx --image-tag 1.21
+
+  # List only the init containers that are crashlooping and stopping their pod from starting
+  %[1]s status --init-problems
+
+  # Count the containers in the current namespace that are not ready
+  %[1]s status -m 'READY==false' --count-only
+
   # List status of containers called web-container searching all pods in current
   # namespace sorted by container name in descending order (notice the ! charator)
   %[1]s status -c web-container --sort '!CONTAINER'
@@ -46,6 +150,9 @@ var statusExample = `  # List individual container status from pods
   # namespace sorted by pod name in ascending order
   %[1]s status -c web-container --sort PODNAME
 
+  # List container status as seen by a service account, useful for checking RBAC rules
+  %[1]s status --as system:serviceaccount:my-namespace:my-sa
+
   # List container status from all pods where label app equals web
   %[1]s status -l app=web
 
@@ -71,12 +178,82 @@ func Status(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args [
 	if err != nil {
 		return err
 	}
-	connect.Flags = commonFlagList
 
 	loopinfo := status{}
+
+	// completed pods are selected by phase so the filter is set before the flags are passed on
+	showCompleted := cmd.Flag("completed").Value.String() == "true"
+	hideCompleted := cmd.Flag("hide-completed").Value.String() == "true"
+	if showCompleted || hideCompleted {
+		if showCompleted && hideCompleted {
+			return errors.New("--completed and --hide-completed can not be used together")
+		}
+		if len(commonFlagList.podPhase) > 0 {
+			return errors.New("--phase and --include-terminated-pods can not be used with --completed or --hide-completed")
+		}
+
+		if showCompleted {
+			log.Debug("loopinfo.ShowCompleted = true")
+			loopinfo.ShowCompleted = true
+			commonFlagList.podPhase = []string{"Succeeded", "Failed"}
+		} else {
+			commonFlagList.podPhase = []string{"Pending", "Running", "Unknown"}
+		}
+	}
+
+	// --follow returns early so the states are read first for it to filter on
+	stateList, err := getNameListFlag(cmd, "state")
+	if err != nil {
+		return err
+	}
+	for _, state := range stateList {
+		state = strings.ToLower(state)
+		switch state {
+		case "running", "waiting", "terminated":
+			loopinfo.StateList = append(loopinfo.StateList, state)
+		default:
+			return fmt.Errorf("unknown container state %s, only running, waiting and terminated are supported", state)
+		}
+	}
+
+	connect.Flags = commonFlagList
 	builder.Connection = &connect
 	builder.SetFlagsFrom(commonFlagList)
 
+	if cmd.Flag("single").Value.String() == "true" {
+		if len(args) != 1 || len(commonFlagList.container) != 1 || commonFlagList.outputAs != "json" {
+			return errors.New("--single needs one pod name, one container name (-c) and -o json")
+		}
+
+		return statusSingle(&builder, args[0], commonFlagList.container[0], commonFlagList, os.Stdout)
+	}
+
+	if cmd.Flag("follow").Value.String() == "true" {
+		if cmd.Flag("wait-ready").Value.String() == "true" {
+			return errors.New("--follow and --wait-ready can not be used together")
+		}
+
+		stdinChanged, err := builder.HasStdinChanged()
+		if err != nil {
+			return err
+		}
+
+		// a file never changes so there would be nothing to follow
+		if len(commonFlagList.inputFilename) > 0 || stdinChanged {
+			return errors.New("--follow can only be used with live pod data, it can not be combined with a file or stdin")
+		}
+
+		interval, err := cmd.Flags().GetDuration("interval")
+		if err != nil {
+			return err
+		}
+		if interval <= 0 {
+			return errors.New("--interval must be greater than zero")
+		}
+
+		return statusFollow(&connect, args, interval, &loopinfo, os.Stdout)
+	}
+
 	if cmd.Flag("previous").Value.String() == "true" {
 		log.Debug("loopinfo.ShowPrevious = true")
 		loopinfo.ShowPrevious = true
@@ -92,6 +269,130 @@ func Status(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args [
 		loopinfo.ShowID = true
 	}
 
+	if cmd.Flag("show-restart-policy").Value.String() == "true" {
+		loopinfo.ShowRestartPolicy = true
+	}
+
+	if cmd.Flag("show-sidecar").Value.String() == "true" {
+		loopinfo.ShowSidecar = true
+	}
+
+	if cmd.Flag("kubelet").Value.String() == "true" {
+		stdinChanged, err := builder.HasStdinChanged()
+		if err != nil {
+			return err
+		}
+		// the kubelets can only be reached through a live cluster
+		if len(commonFlagList.inputFilename) > 0 || stdinChanged {
+			return errors.New("--kubelet can only be used with live pod data, it can not be combined with a file or stdin")
+		}
+		log.Debug("loopinfo.ShowKubelet = true")
+		loopinfo.ShowKubelet = true
+		loopinfo.kubelet = &connect
+	}
+
+	if cmd.Flag("uptime").Value.String() == "true" {
+		log.Debug("loopinfo.ShowUptime = true")
+		loopinfo.ShowUptime = true
+	}
+
+	saveFile := cmd.Flag("save").Value.String()
+	if len(saveFile) > 0 {
+		loopinfo.snapshot = make(map[string]containerSnapshot)
+	}
+
+	if compareFile := cmd.Flag("compare").Value.String(); len(compareFile) > 0 {
+		baseline, err := loadStatusBaseline(compareFile)
+		if err != nil {
+			return err
+		}
+		log.Debug("comparing against baseline taken at", baseline.Taken)
+		loopinfo.baseline = baseline.Containers
+	}
+
+	if cmd.Flag("timeline").Value.String() == "true" {
+		log.Debug("loopinfo.ShowTimeline = true")
+		loopinfo.ShowTimeline = true
+		builder.ShowTimeline = true
+	}
+
+	if cmd.Flag("init-problems").Value.String() == "true" {
+		log.Debug("loopinfo.InitProblems = true")
+		loopinfo.InitProblems = true
+	}
+
+	if cmd.Flag("only-ephemeral").Value.String() == "true" {
+		if loopinfo.InitProblems {
+			return errors.New("--only-ephemeral and --init-problems can not be used together")
+		}
+		log.Debug("loopinfo.OnlyEphemeral = true")
+		loopinfo.OnlyEphemeral = true
+	}
+
+	if cmd.Flag("diff").Value.String() == "true" {
+		if len(args) != 2 {
+			return errors.New("--diff needs two pod names or two namespace/pod-prefix pairs to compare")
+		}
+		if builder.ShowTreeView || loopinfo.ShowTimeline {
+			return errors.New("--diff can not be used with the tree view or --timeline")
+		}
+
+		stdinChanged, err := builder.HasStdinChanged()
+		if err != nil {
+			return err
+		}
+		if len(commonFlagList.inputFilename) > 0 || stdinChanged {
+			return errors.New("--diff can only be used with live pod data, it can not be combined with a file or stdin")
+		}
+
+		return statusDiff(kubeFlags, commonFlagList, loopinfo, args[0], args[1])
+	}
+
+	// waitErr is returned once the table has been printed, so a timed out script can still see what wasnt ready
+	var waitErr error
+	if cmd.Flag("wait-ready").Value.String() == "true" {
+		stdinChanged, err := builder.HasStdinChanged()
+		if err != nil {
+			return err
+		}
+		// a file never changes so the containers would never become ready
+		if len(commonFlagList.inputFilename) > 0 || stdinChanged {
+			return errors.New("--wait-ready can only be used with live pod data, it can not be combined with a file or stdin")
+		}
+
+		interval, err := cmd.Flags().GetDuration("interval")
+		if err != nil {
+			return err
+		}
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+		if interval <= 0 || timeout <= 0 {
+			return errors.New("--interval and --timeout must be greater than zero")
+		}
+
+		ready, err := statusWaitReady(&connect, args, interval, timeout)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			waitErr = newIceError(ErrTimeout, fmt.Errorf("timed out after %s waiting for the containers to be ready", timeout))
+		}
+	}
+
+	if cmd.Flag("containers-summary-per-pod").Value.String() == "true" {
+		if loopinfo.ShowPrevious || loopinfo.ShowTimeline || loopinfo.ShowUptime || len(loopinfo.StateList) > 0 ||
+			loopinfo.InitProblems || loopinfo.OnlyEphemeral || len(saveFile) > 0 || loopinfo.baseline != nil || builder.ShowTreeView {
+			return errors.New("--containers-summary-per-pod can not be used with --previous, --timeline, --uptime, --state, --init-problems, --only-ephemeral, --save, --compare or the tree view")
+		}
+
+		if err := statusPodSummary(cmd, &builder, commonFlagList); err != nil && waitErr == nil {
+			return err
+		}
+		return waitErr
+	}
+
 	table := Table{}
 	table.ColourOutput = commonFlagList.outputAsColour
 	table.CustomColours = commonFlagList.useTheseColours
@@ -99,33 +400,67 @@ func Status(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args [
 	builder.Table = &table
 	log.Debug("commonFlagList.showTreeView =", commonFlagList.showTreeView)
 	builder.ShowTreeView = commonFlagList.showTreeView
+	if loopinfo.ShowTimeline {
+		// the timeline is drawn under each pod so it only makes sense in the tree view
+		builder.ShowTreeView = true
+	}
 
 	if err := builder.Build(&loopinfo); err != nil {
 		return err
 	}
 
+	if len(saveFile) > 0 {
+		if err := saveStatusBaseline(saveFile, loopinfo.snapshot, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flag("max-restarts").Changed {
+		if loopinfo.ShowPrevious {
+			return errors.New("--max-restarts can not be used with --previous as the restart count is not shown")
+		}
+		if err := highlightRestarts(cmd, &table, commonFlagList); err != nil {
+			return err
+		}
+	}
+
 	if !builder.ShowTreeView {
 		if !loopinfo.ShowPrevious { // restart count dosent show up when using previous flag
 			// do we need to find the outliers, we have enough data to compute a range
 			if commonFlagList.showOddities {
-				row2Remove, err := table.ListOutOfRange(builder.DefaultHeaderLen + 2) // 3 = restarts column
-				if err != nil {
+				if err := hideInRangeRows(&table, builder.DefaultHeaderLen+2, commonFlagList); err != nil { // 3 = restarts column
 					return err
 				}
-				table.HideRows(row2Remove)
 			}
 		}
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	if err := outputTableAs(table, commonFlagList); err != nil && waitErr == nil {
+		return err
+	}
+	return waitErr
 
 }
 
 type status struct {
-	ShowPrevious bool
-	ShowDetails  bool
-	ShowID       bool // container id
+	ShowPrevious      bool
+	ShowDetails       bool
+	ShowID            bool     // container id
+	InitProblems      bool     // only show init containers that are failing to start
+	ShowTimeline      bool     // show the order each container started in
+	StateList         []string // only show containers in one of these states (running, waiting or terminated)
+	ShowCompleted     bool     // show the finish time of containers from completed pods
+	OnlyEphemeral     bool     // only show ephemeral (debug) containers
+	ShowUptime        bool     // show the percentage of the pods lifetime the container has been running
+	ShowRestartPolicy bool     // show the pods restart policy on each container, a failed container in a Never pod wont be retried
+	ShowKubelet       bool     // show the container state reported by the kubelet on the pods node
+	ShowSidecar       bool     // show if each init container is a native sidecar (restartPolicy: Always)
+
+	kubelet       *Connector      // used to reach the kubelets through the node proxy when ShowKubelet is set
+	kubeletWarned map[string]bool // nodes we have already warned about, so each unreachable kubelet is reported once
+
+	baseline map[string]containerSnapshot // containers read from --compare, nil when not comparing
+	snapshot map[string]containerSnapshot // every container shown, written to the --save file
 
 	pNotReady     bool // Ready - we use the inverted term so the code makes more sense
 	pStopped      bool // Started - we use the inverted term so the code makes more sense
@@ -147,6 +482,18 @@ func (s *status) Headers() []string {
 		"TIMESTAMP",
 		"AGE",
 		"MESSAGE",
+		"SEQ",
+		"FINISHED",
+		"TARGET",
+		"UPTIME%",
+		"RESTART-DELTA",
+		"STATE-CHANGE",
+		"RUNTIME",
+		"RESTART-POLICY",
+		"KUBELET-READY",
+		"KUBELET-RESTARTS",
+		"KUBELET-STATE",
+		"SIDECAR",
 	}
 }
 
@@ -158,9 +505,19 @@ func (s *status) BuildEphemeralContainerSpec(container v1.EphemeralContainer, in
 }
 
 func (s *status) HideColumns(info BuilderInformation) []int {
-	// "READY","STARTED","RESTARTS","STATE","REASON","EXIT-CODE","SIGNAL","ID","TIMESTAMP","AGE","MESSAGE",
+	// "READY","STARTED","RESTARTS","STATE","REASON","EXIT-CODE","SIGNAL","ID","TIMESTAMP","AGE","MESSAGE","SEQ","FINISHED","TARGET","UPTIME%","RESTART-DELTA","STATE-CHANGE","RUNTIME","RESTART-POLICY","KUBELET-READY","KUBELET-RESTARTS","KUBELET-STATE","SIDECAR",
 	var hideColumns []int
 
+	if s.ShowCompleted {
+		// completed pods are not going to become ready, so we show when each container ran instead
+		hideColumns = append(hideColumns, 0, 1, 7, 9, 10)
+	}
+
+	if s.ShowTimeline {
+		// hide ID MESSAGE leaving the start sequence next to the timestamp it was worked out from
+		hideColumns = append(hideColumns, 7, 10)
+	}
+
 	if s.ShowDetails {
 		hideColumns = append(hideColumns, 7, 9)
 	}
@@ -171,11 +528,47 @@ func (s *status) HideColumns(info BuilderInformation) []int {
 		hideColumns = append(hideColumns, 0, 1, 2, 7, 9)
 	}
 
-	if len(hideColumns) == 0 {
+	if len(hideColumns) == 0 && !info.Wide {
 		// hide ID TIMESTAMP, MESSAGE
 		hideColumns = append(hideColumns, 7, 8, 10)
 	}
 
+	if !s.ShowTimeline {
+		hideColumns = append(hideColumns, 11)
+	}
+
+	if !s.ShowCompleted {
+		hideColumns = append(hideColumns, 12)
+	}
+
+	if !s.ShowDetails {
+		hideColumns = append(hideColumns, 13)
+	}
+
+	if !s.ShowUptime {
+		hideColumns = append(hideColumns, 14)
+	}
+
+	if s.baseline == nil {
+		hideColumns = append(hideColumns, 15, 16)
+	}
+
+	if !s.ShowID {
+		hideColumns = append(hideColumns, 17)
+	}
+
+	if !s.ShowRestartPolicy {
+		hideColumns = append(hideColumns, 18)
+	}
+
+	if !s.ShowKubelet {
+		hideColumns = append(hideColumns, 19, 20, 21)
+	}
+
+	if !s.ShowSidecar {
+		hideColumns = append(hideColumns, 22)
+	}
+
 	if s.ShowID {
 		tmpColumns := []int{}
 		for _, v := range hideColumns {
@@ -189,7 +582,7 @@ func (s *status) HideColumns(info BuilderInformation) []int {
 }
 
 func (s *status) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
-	rowOut := make([]Cell, 11)
+	rowOut := make([]Cell, 23)
 
 	// rowOut[0] // ready
 	// rowOut[1] // started
@@ -202,11 +595,25 @@ func (s *status) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, er
 	// rowOut[8] // timestamp
 	// rowOut[9] // age
 	// rowOut[10] // message
+	// rowOut[11] // seq
+	// rowOut[12] // finished
+	// rowOut[13] // target
+	// rowOut[14] // uptime%
+	// rowOut[15] // restart-delta
+	// rowOut[16] // state-change
+	// rowOut[17] // runtime
+	// rowOut[18] // restart-policy
+	// rowOut[19] // kubelet-ready
+	// rowOut[20] // kubelet-restarts
+	// rowOut[21] // kubelet-state
+	// rowOut[22] // sidecar
 
 	rowOut[0].text = "true"
 	rowOut[0].colour = colourOk
+	rowOut[0].boolean = true
 	rowOut[1].text = "true"
 	rowOut[1].colour = colourOk
+	rowOut[1].boolean = true
 
 	// loop through each row in podTotals and add the columns in each row
 	for _, r := range rows {
@@ -219,12 +626,18 @@ func (s *status) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, er
 			rowOut[1].text = "false" // started
 			rowOut[1].colour = colourBad
 		}
-		rowOut[2].number += r[2].number // restarts
+		rowOut[2].number += r[2].number   // restarts
+		rowOut[15].number += r[15].number // restart-delta
 
 	}
 
 	rowOut[2].typ = 1
 	rowOut[2].text = fmt.Sprintf("%d", rowOut[2].number)
+	rowOut[14].typ = 2
+	if s.baseline != nil {
+		rowOut[15].typ = 1
+		rowOut[15].text = fmt.Sprintf("%d", rowOut[15].number)
+	}
 
 	switch info.TypeName {
 	case "Pod":
@@ -239,6 +652,7 @@ func (s *status) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, er
 		rowOut[8].text = info.Data.pod.CreationTimestamp.Format(timestampFormat) // timestamp
 		rowOut[9].text = duration.HumanDuration(rawAge)                          // age
 		rowOut[10].text = info.Data.pod.Status.Message                           // message
+		rowOut[18].text = string(info.Data.pod.Spec.RestartPolicy)               // restart-policy
 	}
 
 	return rowOut, nil
@@ -251,9 +665,9 @@ func (s *status) BuildContainerStatus(container v1.ContainerStatus, info Builder
 	var signal string
 	var message string
 	var startedAt string
+	var finishedAt string
 	var startTime time.Time
 	var skipAgeCalculation bool
-	var started string
 	var strState string
 	var age string
 	var state v1.ContainerState
@@ -262,9 +676,16 @@ func (s *status) BuildContainerStatus(container v1.ContainerStatus, info Builder
 	log := logger{location: "Status:BuildContainerStatus"}
 	log.Debug("Start")
 
+	if s.InitProblems && !s.isInitProblem(container, info) {
+		return [][]Cell{}, nil
+	}
+
+	if s.OnlyEphemeral && info.ContainerType != TypeIDEphemeralContainer {
+		return [][]Cell{}, nil
+	}
+
 	colourcode := [2]int{-1, 0}
 	readyColour := [2]int{-1, 0}
-	startColour := [2]int{-1, 0}
 
 	if s.ShowPrevious {
 		state = container.LastTerminationState
@@ -289,6 +710,7 @@ func (s *status) BuildContainerStatus(container v1.ContainerStatus, info Builder
 		rawSignal = int64(state.Terminated.Signal)
 		startTime = state.Terminated.StartedAt.Time
 		startedAt = state.Terminated.StartedAt.Format(timestampFormat)
+		finishedAt = state.Terminated.FinishedAt.Format(timestampFormat)
 		reason = state.Terminated.Reason
 		message = state.Terminated.Message
 
@@ -299,6 +721,15 @@ func (s *status) BuildContainerStatus(container v1.ContainerStatus, info Builder
 		}
 	}
 
+	// a crashlooping init container is waiting so we take the exit code from the run that failed
+	if s.InitProblems && state.Terminated == nil && container.LastTerminationState.Terminated != nil {
+		exitCode = fmt.Sprintf("%d", container.LastTerminationState.Terminated.ExitCode)
+		rawExitCode = int64(container.LastTerminationState.Terminated.ExitCode)
+		signal = fmt.Sprintf("%d", container.LastTerminationState.Terminated.Signal)
+		rawSignal = int64(container.LastTerminationState.Terminated.Signal)
+		colourcode = colourBad
+	}
+
 	if state.Running != nil {
 		strState = "Running"
 		startedAt = state.Running.StartedAt.Format(timestampFormat)
@@ -306,16 +737,19 @@ func (s *status) BuildContainerStatus(container v1.ContainerStatus, info Builder
 		colourcode = colourOk
 	}
 
+	if len(s.StateList) > 0 && !s.matchState(strState) {
+		return [][]Cell{}, nil
+	}
+
+	startedCell := NewCellText("")
 	if container.Started != nil {
-		started = fmt.Sprintf("%t", *container.Started)
 		if !*container.Started {
 			s.pStopped = true
 		}
 		// set started colour true = green and false = red
-		startColour = setColourBoolean(*container.Started)
+		startedCell = NewCellColourBool(setColourBoolean(*container.Started), *container.Started)
 	}
 
-	ready := fmt.Sprintf("%t", container.Ready)
 	if !container.Ready {
 		s.pNotReady = true
 	}
@@ -329,7 +763,7 @@ func (s *status) BuildContainerStatus(container v1.ContainerStatus, info Builder
 	s.pRestartsText = fmt.Sprintf("%d", s.pRestarts)
 
 	// remove pod and container name from the message string
-	message = s.trimStatusMessage(message, info.PodName, info.Name)
+	message = trimStatusMessage(message, info.PodName, info.Name)
 
 	// we can only show the age if we have a start time some states dont have said starttime so we have to skip them
 	if skipAgeCalculation {
@@ -341,19 +775,83 @@ func (s *status) BuildContainerStatus(container v1.ContainerStatus, info Builder
 
 	// container.ContainerID
 
-	// READY STARTED RESTARTS STATE REASON EXIT-CODE SIGNAL TIMESTAMP AGE MESSAGE
+	// native sidecars are marked so its clear why an init container is still running
+	sidecar := NewCellText("")
+	if info.ContainerType == TypeIDInitContainer {
+		sidecar = NewCellBool(isSidecar(info.Data.pod, container.Name))
+	}
+
+	seq := ""
+	if info.StartOrder > 0 {
+		seq = fmt.Sprintf("%d", info.StartOrder)
+	}
+
+	// debug containers can be attached to the process namespace of one of the pods containers
+	target := ""
+	if info.ContainerType == TypeIDEphemeralContainer {
+		for _, ephemeral := range info.Data.pod.Spec.EphemeralContainers {
+			if ephemeral.Name == container.Name {
+				target = ephemeral.TargetContainerName
+			}
+		}
+	}
+
+	uptime := NewCellFloat("", 0)
+	if s.ShowUptime {
+		if percent, ok := containerUptime(container, podStartTime(info.Data.pod), time.Now()); ok {
+			uptimeColour := colourOk
+			if percent < uptimeBad {
+				uptimeColour = colourBad
+			} else if percent < uptimeWarn {
+				uptimeColour = colourWarn
+			}
+			uptime = NewCellColourFloat(uptimeColour, fmt.Sprintf("%.1f%%", percent), percent)
+		}
+	}
+
+	current := newContainerSnapshot(container)
+	if s.snapshot != nil {
+		s.snapshot[info.Namespace+"/"+info.PodName+"/"+info.Name] = current
+	}
+
+	restartDelta := NewCellInt("", 0)
+	stateChange := NewCellText("")
+	if s.baseline != nil {
+		restartDelta, stateChange = s.baselineCells(info.Namespace+"/"+info.PodName+"/"+info.Name, current)
+	}
+
+	runtime, shortID := splitContainerID(container.ContainerID)
+
+	kubeletReady, kubeletRestarts, kubeletState := NewCellText(""), NewCellInt("", 0), NewCellText("")
+	if s.ShowKubelet {
+		kubeletReady, kubeletRestarts, kubeletState = s.kubeletCells(container, info)
+	}
+
+	// READY STARTED RESTARTS STATE REASON EXIT-CODE SIGNAL ID TIMESTAMP AGE MESSAGE SEQ FINISHED TARGET UPTIME% RESTART-DELTA STATE-CHANGE RUNTIME RESTART-POLICY KUBELET-READY KUBELET-RESTARTS KUBELET-STATE SIDECAR
 	cellList = append(cellList,
-		NewCellColourText(readyColour, ready),
-		NewCellColourText(startColour, started),
+		NewCellColourBool(readyColour, container.Ready),
+		startedCell,
 		NewCellInt(restarts, rawRestarts),
 		NewCellColourText(colourcode, strState),
 		NewCellText(reason),
 		NewCellColourInt(colourcode, exitCode, rawExitCode),
 		NewCellInt(signal, rawSignal),
-		NewCellText(container.ContainerID),
+		NewCellText(shortID),
 		NewCellText(startedAt),
 		NewCellText(age),
 		NewCellText(message),
+		NewCellInt(seq, int64(info.StartOrder)),
+		NewCellText(finishedAt),
+		NewCellText(target),
+		uptime,
+		restartDelta,
+		stateChange,
+		NewCellText(runtime),
+		NewCellText(string(info.Data.pod.Spec.RestartPolicy)),
+		kubeletReady,
+		kubeletRestarts,
+		kubeletState,
+		sidecar,
 	)
 
 	log.Debug("len(cellList) =", len(cellList))
@@ -363,8 +861,189 @@ func (s *status) BuildContainerStatus(container v1.ContainerStatus, info Builder
 	return out, nil
 }
 
+// isSidecar returns true when the named init container has a restartPolicy of Always, it is started before the pods
+//
+//	containers like any other init container but keeps running alongside them rather than running to completion
+func isSidecar(pod v1.Pod, name string) bool {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == name {
+			return container.RestartPolicy != nil && *container.RestartPolicy == v1.ContainerRestartPolicyAlways
+		}
+	}
+	return false
+}
+
+// kubeletCells returns the ready, restarts and state of the container as reported by the kubelet, the cells are
+//
+//	highlighted when the kubelet is ahead of the api server. when the kubelet cant be reached the cells are left
+//	empty and a warning is printed once for the node, so the rest of the table is still shown
+func (s *status) kubeletCells(container v1.ContainerStatus, info BuilderInformation) (Cell, Cell, Cell) {
+	log := logger{location: "Status:kubeletCells"}
+
+	kubeletPod, found, err := s.kubelet.GetKubeletPod(info.Data.pod.Spec.NodeName, info.Namespace, info.PodName)
+	if err != nil {
+		if !s.kubeletWarned[info.Data.pod.Spec.NodeName] {
+			if s.kubeletWarned == nil {
+				s.kubeletWarned = make(map[string]bool)
+			}
+			s.kubeletWarned[info.Data.pod.Spec.NodeName] = true
+			log.Tell(err)
+		}
+		return NewCellText(""), NewCellInt("", 0), NewCellText("")
+	}
+
+	kubeletContainer := containerStatus(kubeletPod, container.Name)
+	if !found || kubeletContainer == nil {
+		return NewCellText(""), NewCellInt("", 0), NewCellText("")
+	}
+
+	readyCell := NewCellColourBool(setColourBoolean(kubeletContainer.Ready), kubeletContainer.Ready)
+	if kubeletContainer.Ready != container.Ready {
+		readyCell.colour = colourWarn
+	}
+
+	restartColour := [2]int{-1, 0}
+	if kubeletContainer.RestartCount != container.RestartCount {
+		restartColour = colourWarn
+	}
+	restartCell := NewCellColourInt(restartColour, fmt.Sprintf("%d", kubeletContainer.RestartCount), int64(kubeletContainer.RestartCount))
+
+	stateColour := [2]int{-1, 0}
+	state := containerStateName(kubeletContainer.State)
+	if state != containerStateName(container.State) {
+		stateColour = colourWarn
+	}
+
+	return readyCell, restartCell, NewCellColourText(stateColour, state)
+}
+
+// shortContainerIDLength is the number of characters of the container id shown, the same as crictl ps
+const shortContainerIDLength = 13
+
+// splitContainerID splits a container id in the runtime://id form used by the kubelet into the runtime name and the
+//
+//	short id, the full id is still returned by status --single
+func splitContainerID(containerID string) (string, string) {
+	runtime, id, found := strings.Cut(containerID, "://")
+	if !found {
+		runtime, id = "", containerID
+	}
+
+	if len(id) > shortContainerIDLength {
+		id = id[:shortContainerIDLength]
+	}
+
+	return runtime, id
+}
+
+// baselineCells compares the container against the --compare baseline, containers that were not in the baseline are
+//
+//	marked as new. a restart count lower than the baseline means the pod was replaced so the whole count is new
+func (s *status) baselineCells(key string, current containerSnapshot) (Cell, Cell) {
+	before, ok := s.baseline[key]
+	if !ok {
+		return NewCellColourInt(colourWarn, fmt.Sprintf("%d", current.Restarts), int64(current.Restarts)), NewCellColourText(colourWarn, "new")
+	}
+
+	delta := current.Restarts - before.Restarts
+	if delta < 0 {
+		delta = current.Restarts
+	}
+	deltaColour := colourOk
+	if delta > 0 {
+		deltaColour = colourBad
+	}
+
+	change := NewCellText("")
+	if current.State != before.State {
+		change = NewCellColourText(colourWarn, before.State+" -> "+current.State)
+	}
+
+	return NewCellColourInt(deltaColour, fmt.Sprintf("%d", delta), int64(delta)), change
+}
+
+// uptime percentages below these are shown as bad and warn
+const uptimeBad = 50.0
+const uptimeWarn = 90.0
+
+// containerUptime returns the percentage of the time since the pod started that the container has spent running, false
+//
+//	is returned when the pod has no start time. The api only keeps the current and the last terminated run so any
+//	earlier runs are not counted, for a container that has restarted more than once this is the lowest the uptime
+//	could be rather than the exact value
+func containerUptime(container v1.ContainerStatus, podStart time.Time, now time.Time) (float64, bool) {
+	if podStart.IsZero() {
+		return 0, false
+	}
+
+	lifetime := now.Sub(podStart)
+	if lifetime <= 0 {
+		return 0, false
+	}
+
+	var running time.Duration
+	if container.State.Running != nil {
+		running += now.Sub(container.State.Running.StartedAt.Time)
+	}
+	if container.State.Terminated != nil {
+		running += runDuration(container.State.Terminated)
+	}
+	// the last run is only kept while the current one is going, a terminated container has no previous run
+	if container.LastTerminationState.Terminated != nil {
+		running += runDuration(container.LastTerminationState.Terminated)
+	}
+
+	percent := float64(running) / float64(lifetime) * 100
+	if percent > 100 {
+		// clocks on the node and the control plane can disagree slightly
+		percent = 100
+	}
+	if percent < 0 {
+		percent = 0
+	}
+
+	return percent, true
+}
+
+// runDuration returns how long a terminated run of a container lasted
+func runDuration(state *v1.ContainerStateTerminated) time.Duration {
+	if state.StartedAt.IsZero() || state.FinishedAt.IsZero() {
+		return 0
+	}
+	return state.FinishedAt.Sub(state.StartedAt.Time)
+}
+
+// matchState returns true when the containers state is one of the states requested with --state
+func (s *status) matchState(state string) bool {
+	state = strings.ToLower(state)
+	for _, v := range s.StateList {
+		if v == state {
+			return true
+		}
+	}
+	return false
+}
+
+// isInitProblem returns true when the container is an init container that is waiting to be restarted after failing
+func (s *status) isInitProblem(container v1.ContainerStatus, info BuilderInformation) bool {
+	if info.ContainerType != TypeIDInitContainer {
+		return false
+	}
+
+	if container.State.Waiting == nil {
+		return false
+	}
+
+	switch container.State.Waiting.Reason {
+	case "CrashLoopBackOff", "Error":
+		return true
+	}
+
+	return false
+}
+
 // Removes the pod name and container name from the status message as its already in the output table
-func (s *status) trimStatusMessage(message string, podName string, containerName string) string {
+func trimStatusMessage(message string, podName string, containerName string) string {
 
 	if len(message) <= 0 {
 		return ""
@@ -393,3 +1072,681 @@ func (s *status) trimStatusMessage(message string, podName string, containerName
 func (s *status) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
 	return [][]Cell{}, nil
 }
+
+// containerSnapshot holds the values of a container that are compared when following the pod status, it is also the
+//
+//	record written to the --save baseline file so the json names must not change
+type containerSnapshot struct {
+	Restarts int32  `json:"restarts"`
+	State    string `json:"state"`
+}
+
+// statusBaseline is the file written by --save and read by --compare, containers are keyed by namespace/podname/container
+type statusBaseline struct {
+	Version    string                       `json:"version"`
+	Taken      time.Time                    `json:"taken"`
+	Containers map[string]containerSnapshot `json:"containers"`
+}
+
+// statusBaselineVersion is the layout of the baseline file, bump it if the record changes
+const statusBaselineVersion = "v1"
+
+// containerStateName returns the current state of the container along with the reason when there is one
+func containerStateName(state v1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return "Running"
+	case state.Waiting != nil:
+		if len(state.Waiting.Reason) > 0 {
+			return "Waiting(" + state.Waiting.Reason + ")"
+		}
+		return "Waiting"
+	case state.Terminated != nil:
+		if len(state.Terminated.Reason) > 0 {
+			return "Terminated(" + state.Terminated.Reason + ")"
+		}
+		return "Terminated"
+	}
+	return "Unknown"
+}
+
+// containerStateType returns running, waiting or terminated without the reason, the names used by --state
+func containerStateType(state v1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return "running"
+	case state.Waiting != nil:
+		return "waiting"
+	case state.Terminated != nil:
+		return "terminated"
+	}
+	return ""
+}
+
+// newContainerSnapshot records the restart count and current state of a single container
+func newContainerSnapshot(container v1.ContainerStatus) containerSnapshot {
+	return containerSnapshot{
+		Restarts: container.RestartCount,
+		State:    containerStateName(container.State),
+	}
+}
+
+// statusSnapshot records the restart count and state of every container keyed by namespace/podname/container
+func statusSnapshot(podList []v1.Pod) map[string]containerSnapshot {
+	snapshot := make(map[string]containerSnapshot)
+
+	for _, pod := range podList {
+		key := pod.Namespace + "/" + pod.Name + "/"
+		for _, statusList := range [][]v1.ContainerStatus{
+			pod.Status.InitContainerStatuses,
+			pod.Status.ContainerStatuses,
+			pod.Status.EphemeralContainerStatuses,
+		} {
+			for _, container := range statusList {
+				snapshot[key+container.Name] = newContainerSnapshot(container)
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// statusSnapshotDiff compares two snapshots and returns a line for each container that was added, removed, restarted
+//
+//	or changed state, the lines are sorted by container key so the output is stable
+func statusSnapshotDiff(previous map[string]containerSnapshot, current map[string]containerSnapshot) []string {
+	var keyList []string
+	for key := range current {
+		keyList = append(keyList, key)
+	}
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			keyList = append(keyList, key)
+		}
+	}
+	sort.Strings(keyList)
+
+	out := []string{}
+	for _, key := range keyList {
+		before, existed := previous[key]
+		after, exists := current[key]
+
+		switch {
+		case !existed:
+			out = append(out, fmt.Sprintf("%s added state %s", key, after.State))
+		case !exists:
+			out = append(out, fmt.Sprintf("%s removed", key))
+		default:
+			if after.Restarts != before.Restarts {
+				out = append(out, fmt.Sprintf("%s restarts %d -> %d", key, before.Restarts, after.Restarts))
+			}
+			if after.State != before.State {
+				out = append(out, fmt.Sprintf("%s state %s -> %s", key, before.State, after.State))
+			}
+		}
+	}
+
+	return out
+}
+
+// saveStatusBaseline writes the snapshot to filename as json so it can be compared against later with --compare
+func saveStatusBaseline(filename string, snapshot map[string]containerSnapshot, taken time.Time) error {
+	baseline := statusBaseline{
+		Version:    statusBaselineVersion,
+		Taken:      taken,
+		Containers: snapshot,
+	}
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filename, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("unable to save baseline: %w", err)
+	}
+	return nil
+}
+
+// loadStatusBaseline reads a baseline file written by --save
+func loadStatusBaseline(filename string) (statusBaseline, error) {
+	baseline := statusBaseline{}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return baseline, fmt.Errorf("unable to read baseline: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return baseline, fmt.Errorf("unable to read baseline %s: %w", filename, err)
+	}
+
+	if baseline.Version != statusBaselineVersion {
+		return baseline, fmt.Errorf("unsupported baseline version %q in %s, only %s is supported", baseline.Version, filename, statusBaselineVersion)
+	}
+
+	if baseline.Containers == nil {
+		baseline.Containers = make(map[string]containerSnapshot)
+	}
+
+	return baseline, nil
+}
+
+// followSnapshot is statusSnapshot limited to the containers the table would show, containers left out by -c,
+//
+//	--exclude-container, the image flags or --state are not recorded
+func (s *status) followSnapshot(podList []v1.Pod, flags commonFlags) map[string]containerSnapshot {
+	snapshot := statusSnapshot(podList)
+
+	for _, pod := range podList {
+		key := pod.Namespace + "/" + pod.Name + "/"
+		for _, statusList := range [][]v1.ContainerStatus{
+			pod.Status.InitContainerStatuses,
+			pod.Status.ContainerStatuses,
+			pod.Status.EphemeralContainerStatuses,
+		} {
+			for _, container := range statusList {
+				if skipContainer(flags, pod, container.Name) {
+					delete(snapshot, key+container.Name)
+					continue
+				}
+				if len(s.StateList) > 0 && !s.matchState(containerStateType(container.State)) {
+					delete(snapshot, key+container.Name)
+				}
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// statusFollow reloads the pods every interval and writes a timestamped line to out for each container change, only
+//
+//	the changes are written so the output can be redirected to a file or piped to grep. runs until interrupted
+func statusFollow(connect *Connector, podNameList []string, interval time.Duration, s *status, out io.Writer) error {
+	log := logger{location: "statusFollow"}
+	log.Debug("Start")
+
+	var previous map[string]containerSnapshot
+
+	for {
+		if err := connect.LoadPods(podNameList); err != nil {
+			return err
+		}
+
+		podList, err := connect.GetPods(podNameList)
+		if err != nil {
+			return err
+		}
+
+		current := s.followSnapshot(podList, connect.Flags)
+		// the first snapshot is only used as the starting point to compare against
+		if previous != nil {
+			now := time.Now().Format(time.RFC3339)
+			for _, line := range statusSnapshotDiff(previous, current) {
+				fmt.Fprintln(out, now, line)
+			}
+		}
+		previous = current
+
+		log.Debug("sleeping for", interval)
+		time.Sleep(interval)
+	}
+}
+
+// statusSingle prints the raw v1.ContainerStatus of a single container as json, every field the api returns is kept
+//
+//	unlike the table output which only has the columns ice picks out. init and ephemeral containers are searched too
+func statusSingle(builder *RowBuilder, podName string, containerName string, flags commonFlags, out io.Writer) error {
+	var podList []v1.Pod
+	var err error
+
+	builder.StdinChanged, err = builder.HasStdinChanged()
+	if err != nil {
+		return err
+	}
+
+	if len(builder.InputFilename) > 0 || builder.StdinChanged {
+		podList, err = builder.loadYaml(builder.InputFilename)
+	} else {
+		podList, err = builder.Connection.GetPods([]string{podName})
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range podList {
+		if pod.Name != podName {
+			continue
+		}
+
+		for _, statusList := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses} {
+			for _, container := range statusList {
+				if container.Name != containerName {
+					continue
+				}
+
+				var data []byte
+				if flags.outputLayout == LAYOUT_PRETTY {
+					data, err = json.MarshalIndent(container, "", "  ")
+				} else {
+					data, err = json.Marshal(container)
+				}
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(out, string(data))
+				return err
+			}
+		}
+
+		return newIceError(ErrNoMatch, fmt.Errorf("container %s has no status in pod %s", containerName, podName))
+	}
+
+	return newIceError(ErrNoPods, fmt.Errorf("pod %s not found", podName))
+}
+
+// statusWaitReady loads the pods every interval until all of their containers are ready, false is returned if timeout
+//
+//	is reached first. no pods is treated as not ready yet as they may not have been created when run straight after
+//	kubectl apply. the pods from the last load are left in the connector ready for the builder to use
+func statusWaitReady(connect *Connector, podNameList []string, interval time.Duration, timeout time.Duration) (bool, error) {
+	log := logger{location: "statusWaitReady"}
+	log.Debug("Start")
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		err := connect.LoadPods(podNameList)
+		if err != nil && !errors.Is(err, ErrNoPods) {
+			return false, err
+		}
+		if err == nil && podsReady(connect.podList, connect.Flags) {
+			return true, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false, nil
+		}
+		if remaining > interval {
+			remaining = interval
+		}
+		log.Debug("sleeping for", remaining)
+		time.Sleep(remaining)
+	}
+}
+
+// podsReady returns true when every standard container of every pod is ready, the containers left out by -c and
+//
+//	--exclude-container are not checked. pods that have already succeeded are skipped as their containers have
+//	finished and will never be ready again
+func podsReady(podList []v1.Pod, flags commonFlags) bool {
+	for _, pod := range podList {
+		if pod.Status.Phase == v1.PodSucceeded {
+			continue
+		}
+
+		readyList := make(map[string]bool)
+		for _, container := range pod.Status.ContainerStatuses {
+			readyList[container.Name] = container.Ready
+		}
+
+		for _, container := range pod.Spec.Containers {
+			if skipContainer(flags, pod, container.Name) {
+				continue
+			}
+			if !readyList[container.Name] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// statusDiffSide holds one side of a --diff, either a single pod in the current namespace or every pod in namespace
+//
+//	whose name starts with prefix
+type statusDiffSide struct {
+	label     string
+	namespace string
+	podName   string
+	prefix    string
+}
+
+// parseStatusDiffArg turns a --diff argument into a statusDiffSide, arguments containing a / are namespace/pod-prefix
+func parseStatusDiffArg(arg string) statusDiffSide {
+	side := statusDiffSide{label: arg}
+	if i := strings.Index(arg, "/"); i >= 0 {
+		side.namespace = arg[:i]
+		side.prefix = arg[i+1:]
+	} else {
+		side.podName = arg
+	}
+	return side
+}
+
+// podNamePrefix returns the pod name with the generated suffix removed, so pods from the same deployment or daemonset
+//
+//	share a prefix. statefulset and bare pods dont have a generated name so the full name is returned
+func podNamePrefix(pod v1.Pod) string {
+	if len(pod.GenerateName) == 0 {
+		return pod.Name
+	}
+
+	prefix := strings.TrimSuffix(pod.GenerateName, "-")
+	if hash, ok := pod.Labels["pod-template-hash"]; ok {
+		prefix = strings.TrimSuffix(prefix, "-"+hash)
+	}
+	return prefix
+}
+
+// statusDiffValues holds the visible status values of one side of a diff keyed by pod prefix/container then column
+type statusDiffValues struct {
+	keys   []string
+	values map[string]map[string]string
+}
+
+// statusDiffBuild builds the status table for one side of the diff and returns its visible values along with the
+//
+//	column names, when several pods share a prefix only the first one is used
+func statusDiffBuild(kubeFlags *genericclioptions.ConfigFlags, commonFlagList commonFlags, loopinfo status, side statusDiffSide) (statusDiffValues, []string, error) {
+	out := statusDiffValues{values: make(map[string]map[string]string)}
+
+	// each side needs its own connection as the connector caches the pods it loads
+	connect := Connector{}
+	if err := connect.LoadConfig(kubeFlags); err != nil {
+		return out, []string{}, err
+	}
+	connect.Flags = commonFlagList
+	connect.SetNamespace(side.namespace)
+
+	builder := RowBuilder{}
+	builder.LoopStatus = true
+	builder.ShowInitContainers = true
+	if len(side.podName) > 0 {
+		builder.PodName = []string{side.podName}
+	}
+	builder.Connection = &connect
+	builder.SetFlagsFrom(commonFlagList)
+	// the pod name column is needed to pair up the pods
+	builder.ShowPodName = true
+
+	table := Table{}
+	builder.Table = &table
+
+	if err := builder.Build(&loopinfo); err != nil {
+		return out, []string{}, err
+	}
+
+	prefixList := make(map[string]string)
+	for _, pod := range connect.podList {
+		prefixList[pod.Name] = podNamePrefix(pod)
+	}
+
+	podColumn := -1
+	containerColumn := -1
+	var columns []string
+	var columnIndex []int
+	for idx, head := range table.head {
+		switch head.title {
+		case "PODNAME":
+			podColumn = idx
+		case "CONTAINER":
+			containerColumn = idx
+		case "T", "NAMESPACE", "NODE":
+		default:
+			if !head.hidden {
+				columns = append(columns, head.title)
+				columnIndex = append(columnIndex, idx)
+			}
+		}
+	}
+
+	if podColumn == -1 || containerColumn == -1 {
+		return out, []string{}, errors.New("unable to compare pods, the pod and container name columns are missing")
+	}
+
+	seenPod := make(map[string]string)
+	for _, row := range table.getVisibleRows() {
+		podName := row[podColumn].text
+		prefix := prefixList[podName]
+		if len(side.prefix) > 0 && !strings.HasPrefix(prefix, side.prefix) {
+			continue
+		}
+
+		key := row[containerColumn].text
+		if len(side.podName) == 0 {
+			// only the first pod with each prefix is compared
+			if first, ok := seenPod[prefix]; ok && first != podName {
+				continue
+			}
+			seenPod[prefix] = podName
+			key = prefix + "/" + key
+		}
+
+		if _, ok := out.values[key]; !ok {
+			out.keys = append(out.keys, key)
+			out.values[key] = make(map[string]string)
+		}
+		for i, idx := range columnIndex {
+			out.values[key][columns[i]] = row[idx].text
+		}
+	}
+
+	return out, columns, nil
+}
+
+// statusDiffRows returns a row of key, column, left value and right value for every value that is different between
+//
+//	the two sides, containers that only exist on one side are listed with the column name CONTAINER
+func statusDiffRows(left statusDiffValues, right statusDiffValues, columns []string) [][4]string {
+	var rows [][4]string
+
+	keyList := append([]string{}, left.keys...)
+	for _, key := range right.keys {
+		if _, ok := left.values[key]; !ok {
+			keyList = append(keyList, key)
+		}
+	}
+
+	for _, key := range keyList {
+		leftValues, inLeft := left.values[key]
+		rightValues, inRight := right.values[key]
+
+		if !inLeft || !inRight {
+			row := [4]string{key, "CONTAINER", "missing", "missing"}
+			if inLeft {
+				row[2] = "present"
+			} else {
+				row[3] = "present"
+			}
+			rows = append(rows, row)
+			continue
+		}
+
+		for _, column := range columns {
+			if leftValues[column] != rightValues[column] {
+				rows = append(rows, [4]string{key, column, leftValues[column], rightValues[column]})
+			}
+		}
+	}
+
+	return rows
+}
+
+// statusDiff builds the status table for both sides and prints only the values that are different, the left and right
+//
+//	columns are named after the arguments that were passed
+func statusDiff(kubeFlags *genericclioptions.ConfigFlags, commonFlagList commonFlags, loopinfo status, leftArg string, rightArg string) error {
+	log := logger{location: "statusDiff"}
+	log.Debug("Start")
+
+	leftSide := parseStatusDiffArg(leftArg)
+	rightSide := parseStatusDiffArg(rightArg)
+	if (len(leftSide.podName) > 0) != (len(rightSide.podName) > 0) {
+		return errors.New("--diff can not compare a pod name with a namespace/pod-prefix pair")
+	}
+
+	left, columns, err := statusDiffBuild(kubeFlags, commonFlagList, loopinfo, leftSide)
+	if err != nil {
+		return err
+	}
+	right, _, err := statusDiffBuild(kubeFlags, commonFlagList, loopinfo, rightSide)
+	if err != nil {
+		return err
+	}
+
+	table := Table{}
+	table.ColourOutput = commonFlagList.outputAsColour
+	table.CustomColours = commonFlagList.useTheseColours
+	table.SetHeader("CONTAINER", "COLUMN", leftSide.label, rightSide.label)
+
+	for _, row := range statusDiffRows(left, right, columns) {
+		table.AddRow(
+			NewCellText(row[0]),
+			NewCellText(row[1]),
+			NewCellColourText(colourWarn, row[2]),
+			NewCellColourText(colourWarn, row[3]),
+		)
+	}
+
+	return outputTableAs(table, commonFlagList)
+}
+
+// statusPodSummary prints one row per pod, the container statuses of each pod are added up into a single row rather than
+//
+//	listing every container
+func statusPodSummary(cmd *cobra.Command, builder *RowBuilder, commonFlagList commonFlags) error {
+	log := logger{location: "statusPodSummary"}
+	log.Debug("Start")
+
+	loopinfo := podSummary{Flags: commonFlagList}
+	builder.DontListContainers = true
+	builder.LoopStatus = false
+	builder.ShowInitContainers = false
+
+	table := Table{}
+	table.ColourOutput = commonFlagList.outputAsColour
+	table.CustomColours = commonFlagList.useTheseColours
+	builder.Table = &table
+
+	if err := builder.Build(&loopinfo); err != nil {
+		return err
+	}
+
+	if err := table.SortByNames(commonFlagList.sortList...); err != nil {
+		return err
+	}
+
+	if cmd.Flag("max-restarts").Changed {
+		if err := highlightRestarts(cmd, &table, commonFlagList); err != nil {
+			return err
+		}
+	}
+
+	if commonFlagList.showOddities {
+		if err := hideInRangeRows(&table, builder.DefaultHeaderLen+2, commonFlagList); err != nil { // 2 = restarts column
+			return err
+		}
+	}
+
+	return outputTableAs(table, commonFlagList)
+}
+
+// podSummary adds up the standard containers of each pod, init containers are left out as they are expected to have
+//
+//	stopped once the pod is running
+type podSummary struct {
+	Flags commonFlags // used to leave out the containers removed by -c and --exclude-container
+}
+
+func (s *podSummary) Headers() []string {
+	return []string{
+		"CONTAINERS", "READY", "RESTARTS", "NOT-RUNNING",
+	}
+}
+
+func (s *podSummary) BuildContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *podSummary) BuildEphemeralContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *podSummary) HideColumns(info BuilderInformation) []int {
+	return []int{}
+}
+
+func (s *podSummary) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
+	return []Cell{
+		NewCellInt("", 0),
+		NewCellInt("", 0),
+		NewCellInt("", 0),
+		NewCellText(""),
+	}, nil
+}
+
+func (s *podSummary) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *podSummary) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *podSummary) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{s.podSummaryBuildRow(pod)}, nil
+}
+
+// podSummaryBuildRow counts the containers from the pod spec so containers that dont have a status yet are still
+//
+//	counted, they show up as not running
+func (s *podSummary) podSummaryBuildRow(pod v1.Pod) []Cell {
+	var total, ready, restarts int64
+	var notRunning []string
+
+	statusList := make(map[string]v1.ContainerStatus)
+	for _, container := range pod.Status.ContainerStatuses {
+		statusList[container.Name] = container
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if skipContainer(s.Flags, pod, container.Name) {
+			continue
+		}
+		total++
+
+		status, ok := statusList[container.Name]
+		if !ok {
+			notRunning = append(notRunning, container.Name)
+			continue
+		}
+		if status.Ready {
+			ready++
+		}
+		restarts += int64(status.RestartCount)
+		if status.State.Running == nil {
+			notRunning = append(notRunning, container.Name)
+		}
+	}
+
+	readyColour := colourOk
+	if ready < total {
+		readyColour = colourBad
+	}
+
+	restartsColour := colourOk
+	if restarts > 0 {
+		restartsColour = colourWarn
+	}
+
+	return []Cell{
+		NewCellInt(fmt.Sprintf("%d", total), total),
+		NewCellColourInt(readyColour, fmt.Sprintf("%d", ready), ready),
+		NewCellColourInt(restartsColour, fmt.Sprintf("%d", restarts), restarts),
+		NewCellColourText(colourBad, strings.Join(notRunning, ",")),
+	}
+}
@@ -0,0 +1,991 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+)
+
+// *****************
+// statusSnapshotDiff
+// *****************
+type statusSnapshotDiffTest struct {
+	previous map[string]containerSnapshot
+	current  map[string]containerSnapshot
+	expected []string
+}
+
+var statusSnapshotDiffTests = []statusSnapshotDiffTest{
+	{
+		map[string]containerSnapshot{"ns/pod/web": {0, "Running"}},
+		map[string]containerSnapshot{"ns/pod/web": {0, "Running"}},
+		[]string{},
+	},
+	{
+		map[string]containerSnapshot{"ns/pod/web": {0, "Running"}},
+		map[string]containerSnapshot{"ns/pod/web": {1, "Running"}},
+		[]string{"ns/pod/web restarts 0 -> 1"},
+	},
+	{
+		map[string]containerSnapshot{"ns/pod/web": {1, "Running"}},
+		map[string]containerSnapshot{"ns/pod/web": {2, "Waiting(CrashLoopBackOff)"}},
+		[]string{"ns/pod/web restarts 1 -> 2", "ns/pod/web state Running -> Waiting(CrashLoopBackOff)"},
+	},
+	{
+		map[string]containerSnapshot{"ns/pod/web": {0, "Running"}},
+		map[string]containerSnapshot{"ns/pod/api": {0, "Waiting(ContainerCreating)"}},
+		[]string{"ns/pod/api added state Waiting(ContainerCreating)", "ns/pod/web removed"},
+	},
+}
+
+func TestStatusSnapshotDiff(t *testing.T) {
+
+	for _, test := range statusSnapshotDiffTests {
+		if output := statusSnapshotDiff(test.previous, test.current); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// followSnapshot
+// *****************
+type followSnapshotTest struct {
+	flags    commonFlags
+	states   []string
+	expected []string
+}
+
+var followSnapshotTests = []followSnapshotTest{
+	{commonFlags{}, nil, []string{"ns/pod/init", "ns/pod/proxy", "ns/pod/web"}},
+	{commonFlags{container: []string{"web"}}, nil, []string{"ns/pod/web"}},
+	{commonFlags{excludeContainer: []string{"proxy"}}, nil, []string{"ns/pod/init", "ns/pod/web"}},
+	{commonFlags{}, []string{"waiting"}, []string{"ns/pod/proxy"}},
+	{commonFlags{excludeContainer: []string{"init"}}, []string{"running", "terminated"}, []string{"ns/pod/web"}},
+}
+
+func TestFollowSnapshot(t *testing.T) {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "init", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}}},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				{Name: "proxy", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+
+	for _, test := range followSnapshotTests {
+		loop := status{StateList: test.states}
+		output := []string{}
+		for key := range loop.followSnapshot([]v1.Pod{pod}, test.flags) {
+			output = append(output, key)
+		}
+		sort.Strings(output)
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// podNamePrefix
+// *****************
+type podNamePrefixTest struct {
+	name         string
+	generateName string
+	hash         string
+	expected     string
+}
+
+var podNamePrefixTests = []podNamePrefixTest{
+	{"web-7d4b9c-abcde", "web-7d4b9c-", "7d4b9c", "web"},
+	{"agent-x2k9p", "agent-", "", "agent"},
+	{"db-0", "", "", "db-0"},
+}
+
+func TestPodNamePrefix(t *testing.T) {
+
+	for _, test := range podNamePrefixTests {
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: test.name, GenerateName: test.generateName}}
+		if len(test.hash) > 0 {
+			pod.Labels = map[string]string{"pod-template-hash": test.hash}
+		}
+
+		if output := podNamePrefix(pod); output != test.expected {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// statusDiffRows
+// *****************
+func TestStatusDiffRows(t *testing.T) {
+	left := statusDiffValues{
+		keys: []string{"web", "proxy"},
+		values: map[string]map[string]string{
+			"web":   {"READY": "true", "RESTARTS": "0"},
+			"proxy": {"READY": "true", "RESTARTS": "1"},
+		},
+	}
+	right := statusDiffValues{
+		keys: []string{"web", "cache"},
+		values: map[string]map[string]string{
+			"web":   {"READY": "false", "RESTARTS": "0"},
+			"cache": {"READY": "true", "RESTARTS": "0"},
+		},
+	}
+
+	expected := [][4]string{
+		{"web", "READY", "true", "false"},
+		{"proxy", "CONTAINER", "present", "missing"},
+		{"cache", "CONTAINER", "missing", "present"},
+	}
+
+	if output := statusDiffRows(left, right, []string{"READY", "RESTARTS"}); !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %q not equal to expected %q", output, expected)
+	}
+
+}
+
+// *****************
+// containerUptime
+// *****************
+type containerUptimeTest struct {
+	state      v1.ContainerState
+	lastState  v1.ContainerState
+	podStarted time.Duration // how long ago the pod started, zero for no start time
+	expected   float64
+	expectOk   bool
+}
+
+func TestContainerUptime(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) metav1.Time { return metav1.NewTime(now.Add(-d)) }
+
+	tests := []containerUptimeTest{
+		{v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: ago(10 * time.Hour)}}, v1.ContainerState{}, 10 * time.Hour, 100, true},
+		// restarted an hour ago after running for 7 of the previous 9 hours
+		{
+			v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: ago(time.Hour)}},
+			v1.ContainerState{Terminated: &v1.ContainerStateTerminated{StartedAt: ago(9 * time.Hour), FinishedAt: ago(2 * time.Hour)}},
+			10 * time.Hour, 80, true,
+		},
+		{v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}, v1.ContainerState{}, 10 * time.Hour, 0, true},
+		{v1.ContainerState{Terminated: &v1.ContainerStateTerminated{StartedAt: ago(4 * time.Hour), FinishedAt: ago(3 * time.Hour)}}, v1.ContainerState{}, 4 * time.Hour, 25, true},
+		// the node clock is ahead of the control plane
+		{v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: ago(11 * time.Hour)}}, v1.ContainerState{}, 10 * time.Hour, 100, true},
+		{v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: ago(time.Hour)}}, v1.ContainerState{}, 0, 0, false},
+	}
+
+	for _, test := range tests {
+		var podStart time.Time
+		if test.podStarted > 0 {
+			podStart = now.Add(-test.podStarted)
+		}
+
+		container := v1.ContainerStatus{State: test.state, LastTerminationState: test.lastState}
+		output, ok := containerUptime(container, podStart, now)
+		if ok != test.expectOk || math.Abs(output-test.expected) > 0.01 {
+			t.Errorf("Output %.2f %t not equal to expected %.2f %t", output, ok, test.expected, test.expectOk)
+		}
+	}
+
+}
+
+// *****************
+// baselineCells
+// *****************
+type baselineCellsTest struct {
+	key           string
+	current       containerSnapshot
+	expectedDelta string
+	expectedState string
+}
+
+var baselineCellsTests = []baselineCellsTest{
+	{"ns/pod/web", containerSnapshot{2, "Running"}, "0", ""},
+	{"ns/pod/web", containerSnapshot{5, "Waiting(CrashLoopBackOff)"}, "3", "Running -> Waiting(CrashLoopBackOff)"},
+	// the pod was replaced so the count started again
+	{"ns/pod/web", containerSnapshot{1, "Running"}, "1", ""},
+	{"ns/pod/api", containerSnapshot{1, "Running"}, "1", "new"},
+}
+
+func TestBaselineCells(t *testing.T) {
+	s := status{baseline: map[string]containerSnapshot{"ns/pod/web": {2, "Running"}}}
+
+	for _, test := range baselineCellsTests {
+		delta, state := s.baselineCells(test.key, test.current)
+		if delta.text != test.expectedDelta || state.text != test.expectedState {
+			t.Errorf("Output %q %q not equal to expected %q %q", delta.text, state.text, test.expectedDelta, test.expectedState)
+		}
+	}
+
+}
+
+func TestStatusBaselineSaveLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "baseline.json")
+	snapshot := map[string]containerSnapshot{
+		"ns/pod/web":  {3, "Running"},
+		"ns/pod/init": {0, "Terminated(Completed)"},
+	}
+	taken := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+
+	if err := saveStatusBaseline(filename, snapshot, taken); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	baseline, err := loadStatusBaseline(filename)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(baseline.Containers, snapshot) || !baseline.Taken.Equal(taken) {
+		t.Errorf("Output %+v not equal to expected %+v taken %v", baseline, snapshot, taken)
+	}
+
+	if err := os.WriteFile(filename, []byte(`{"version":"v9","containers":{}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadStatusBaseline(filename); err == nil {
+		t.Errorf("expected an error for an unknown baseline version")
+	}
+
+}
+
+// *****************
+// podSummaryBuildRow
+// *****************
+type podSummaryTest struct {
+	flags    commonFlags
+	expected []string
+}
+
+var podSummaryTests = []podSummaryTest{
+	{commonFlags{}, []string{"3", "1", "5", "proxy,cache"}},
+	{commonFlags{excludeContainer: []string{"proxy"}}, []string{"2", "1", "0", "cache"}},
+	{commonFlags{container: []string{"web"}}, []string{"1", "1", "0", ""}},
+}
+
+func TestPodSummaryBuildRow(t *testing.T) {
+	pod := v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init"}},
+			Containers:     []v1.Container{{Name: "web"}, {Name: "proxy"}, {Name: "cache"}},
+		},
+		Status: v1.PodStatus{
+			// init containers are left out of the summary
+			InitContainerStatuses: []v1.ContainerStatus{{Name: "init", RestartCount: 9}},
+			// cache has no status yet so is counted as not running
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", Ready: true, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				{Name: "proxy", RestartCount: 5, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+
+	for _, test := range podSummaryTests {
+		summary := podSummary{Flags: test.flags}
+		row := summary.podSummaryBuildRow(pod)
+		output := []string{row[0].text, row[1].text, row[2].text, row[3].text}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// podsReady
+// *****************
+type podsReadyTest struct {
+	pods     []v1.Pod
+	flags    commonFlags
+	expected bool
+}
+
+func readyPod(phase v1.PodPhase, ready map[string]bool) v1.Pod {
+	pod := v1.Pod{Status: v1.PodStatus{Phase: phase}}
+	for _, name := range []string{"web", "proxy"} {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name})
+		if value, ok := ready[name]; ok {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{Name: name, Ready: value})
+		}
+	}
+	return pod
+}
+
+var podsReadyTests = []podsReadyTest{
+	{[]v1.Pod{readyPod(v1.PodRunning, map[string]bool{"web": true, "proxy": true})}, commonFlags{}, true},
+	{[]v1.Pod{readyPod(v1.PodRunning, map[string]bool{"web": true, "proxy": false})}, commonFlags{}, false},
+	// containers without a status yet are not ready
+	{[]v1.Pod{readyPod(v1.PodPending, map[string]bool{"web": true})}, commonFlags{}, false},
+	{[]v1.Pod{readyPod(v1.PodRunning, map[string]bool{"web": true, "proxy": false})}, commonFlags{excludeContainer: []string{"proxy"}}, true},
+	{[]v1.Pod{readyPod(v1.PodRunning, map[string]bool{"web": true, "proxy": false})}, commonFlags{container: []string{"web"}}, true},
+	// finished pods never become ready again
+	{[]v1.Pod{readyPod(v1.PodSucceeded, map[string]bool{"web": false, "proxy": false})}, commonFlags{}, true},
+	{[]v1.Pod{
+		readyPod(v1.PodRunning, map[string]bool{"web": true, "proxy": true}),
+		readyPod(v1.PodRunning, map[string]bool{"web": false, "proxy": true}),
+	}, commonFlags{}, false},
+}
+
+func TestPodsReady(t *testing.T) {
+
+	for i, test := range podsReadyTests {
+		if output := podsReady(test.pods, test.flags); output != test.expected {
+			t.Errorf("test %d: Output %t not equal to expected %t", i, output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// statusWaitReady
+// *****************
+func TestStatusWaitReady(t *testing.T) {
+	var requests, neverReady int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		count := atomic.AddInt32(&requests, 1)
+		// the first load finds no pods, the second finds the pod starting and it is ready from the third
+		items := []v1.Pod{}
+		if count > 1 {
+			pod := readyPod(v1.PodRunning, map[string]bool{"web": count > 2 && atomic.LoadInt32(&neverReady) == 0, "proxy": true})
+			pod.ObjectMeta = metav1.ObjectMeta{Name: "web-1", Namespace: "default"}
+			items = append(items, pod)
+		}
+		json.NewEncoder(w).Encode(v1.PodList{TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"}, Items: items})
+	}))
+	defer server.Close()
+
+	useTestServer(t, server.URL)
+
+	connect := Connector{}
+	if err := connect.LoadConfig(genericclioptions.NewConfigFlags(false)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	ready, err := statusWaitReady(&connect, []string{}, time.Millisecond, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !ready || requests != 3 {
+		t.Errorf("Output ready %t after %d loads, expected ready after 3", ready, requests)
+	}
+
+	// the pod is left not ready so the wait gives up
+	atomic.StoreInt32(&neverReady, 1)
+	ready, err = statusWaitReady(&connect, []string{}, time.Millisecond, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if ready {
+		t.Errorf("expected the wait to time out")
+	}
+
+}
+
+// *****************
+// statusSingle
+// *****************
+type statusSingleTest struct {
+	podName       string
+	containerName string
+	expected      string
+	expectedErr   error
+}
+
+var statusSingleTests = []statusSingleTest{
+	{"web-1", "web", `{"name":"web","state":{"waiting":{"reason":"CrashLoopBackOff","message":"back-off"}},"lastState":{"terminated":{"exitCode":137,"reason":"OOMKilled","startedAt":null,"finishedAt":null}},"ready":false,"restartCount":3,"image":"nginx:1.25","imageID":"docker-pullable://nginx@sha256:abc","containerID":"containerd://0123"}` + "\n", nil},
+	// init containers are searched as well
+	{"web-1", "init", `{"name":"init","state":{},"lastState":{},"ready":true,"restartCount":0,"image":"busybox","imageID":""}` + "\n", nil},
+	{"web-1", "missing", "", ErrNoMatch},
+	{"web-2", "web", "", ErrNoPods},
+}
+
+func TestStatusSingle(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "pod.yaml")
+	manifest := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+spec:
+  containers:
+  - name: web
+    image: nginx:1.25
+status:
+  initContainerStatuses:
+  - name: init
+    image: busybox
+    ready: true
+  containerStatuses:
+  - name: web
+    image: nginx:1.25
+    imageID: docker-pullable://nginx@sha256:abc
+    containerID: containerd://0123
+    restartCount: 3
+    state:
+      waiting:
+        reason: CrashLoopBackOff
+        message: back-off
+    lastState:
+      terminated:
+        exitCode: 137
+        reason: OOMKilled
+`
+	if err := os.WriteFile(filename, []byte(manifest), 0644); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, test := range statusSingleTests {
+		builder := RowBuilder{InputFilename: filename}
+		out := bytes.Buffer{}
+		err := statusSingle(&builder, test.podName, test.containerName, commonFlags{}, &out)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s/%s: error %v not equal to expected %v", test.podName, test.containerName, err, test.expectedErr)
+		}
+		if output := out.String(); output != test.expected {
+			t.Errorf("Output %s not equal to expected %s", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// splitContainerID
+// *****************
+type splitContainerIDTest struct {
+	containerID string
+	runtime     string
+	shortID     string
+}
+
+var splitContainerIDTests = []splitContainerIDTest{
+	{"containerd://4f3a9c1d2b7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9012345678abcdef01", "containerd", "4f3a9c1d2b7e8"},
+	{"docker://0123456789ab", "docker", "0123456789ab"},
+	{"cri-o://abcdef0123456789", "cri-o", "abcdef0123456"},
+	// containers that havent been created yet have no id
+	{"", "", ""},
+	{"4f3a9c1d2b7e8f90", "", "4f3a9c1d2b7e8"},
+}
+
+func TestSplitContainerID(t *testing.T) {
+
+	for _, test := range splitContainerIDTests {
+		runtime, shortID := splitContainerID(test.containerID)
+		if runtime != test.runtime || shortID != test.shortID {
+			t.Errorf("Output %q %q not equal to expected %q %q", runtime, shortID, test.runtime, test.shortID)
+		}
+	}
+
+}
+
+// *****************
+// restart policy
+// *****************
+func TestStatusRestartPolicy(t *testing.T) {
+	info := BuilderInformation{}
+	info.Data.pod = v1.Pod{Spec: v1.PodSpec{RestartPolicy: v1.RestartPolicyNever}}
+	container := v1.ContainerStatus{
+		Name:  "job",
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1}},
+	}
+
+	for _, show := range []bool{false, true} {
+		loop := status{ShowRestartPolicy: show}
+		rows, err := loop.BuildContainerStatus(container, info)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if output := rows[0][18].text; output != "Never" {
+			t.Errorf("Output %s not equal to expected Never", output)
+		}
+
+		hidden := false
+		for _, col := range loop.HideColumns(info) {
+			if col == 18 {
+				hidden = true
+			}
+		}
+		if hidden == show {
+			t.Errorf("show %t: RESTART-POLICY column hidden is %t", show, hidden)
+		}
+	}
+
+}
+
+// *****************
+// wide columns
+// *****************
+type statusWideTest struct {
+	loop     status
+	args     []string
+	expected []string
+}
+
+var statusWideTests = []statusWideTest{
+	{status{}, []string{}, []string{"PODNAME", "CONTAINER", "READY", "STARTED", "RESTARTS", "STATE", "REASON", "EXIT-CODE", "SIGNAL", "AGE"}},
+	// only the detail columns are shown, the columns that need their own flag stay hidden. --wide also implies --show-node
+	{status{}, []string{"--wide"}, []string{"NODE", "PODNAME", "CONTAINER", "READY", "STARTED", "RESTARTS", "STATE", "REASON", "EXIT-CODE", "SIGNAL", "ID", "TIMESTAMP", "AGE", "MESSAGE"}},
+	{status{ShowRestartPolicy: true}, []string{"--wide"}, []string{"NODE", "PODNAME", "CONTAINER", "READY", "STARTED", "RESTARTS", "STATE", "REASON", "EXIT-CODE", "SIGNAL", "ID", "TIMESTAMP", "AGE", "MESSAGE", "RESTART-POLICY"}},
+}
+
+func TestStatusWideColumns(t *testing.T) {
+	pods := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web-1\n  namespace: default\nspec:\n  nodeName: node-1\n  containers:\n  - name: web\n"
+
+	for _, test := range statusWideTests {
+		cmd := &cobra.Command{Use: "status"}
+		addCommonFlags(cmd)
+		if err := cmd.ParseFlags(test.args); err != nil {
+			t.Fatal(err)
+		}
+		flags, err := processCommonFlags(cmd)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		loop := test.loop
+		tbl, _ := buildTestTable(t, RowBuilder{LoopStatus: true}, &loop, flags, pods)
+		if output := visibleHeaders(tbl); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v (args %v)", output, test.expected, test.args)
+		}
+	}
+
+}
+
+// *****************
+// timeline
+// *****************
+type statusTimelineTest struct {
+	container string
+	seq       string
+}
+
+var statusTimelineTests = []statusTimelineTest{
+	{"InitContainer/migrate", "1"},
+	// sorted by start time rather than the order in the spec
+	{"Container/proxy", "2"},
+	{"Container/web", "3"},
+	// not started yet so it goes last without a sequence
+	{"Container/worker", ""},
+}
+
+func TestStatusTimeline(t *testing.T) {
+	pods := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+spec:
+  initContainers:
+  - name: migrate
+  containers:
+  - name: web
+  - name: worker
+  - name: proxy
+status:
+  initContainerStatuses:
+  - name: migrate
+    state:
+      terminated:
+        startedAt: "2024-01-01T10:00:00Z"
+        finishedAt: "2024-01-01T10:00:05Z"
+  containerStatuses:
+  - name: web
+    state:
+      running:
+        startedAt: "2024-01-01T10:00:30Z"
+  - name: worker
+    state:
+      waiting:
+        reason: ContainerCreating
+  - name: proxy
+    state:
+      running:
+        startedAt: "2024-01-01T10:00:10Z"
+`
+
+	// the tree view is built from the pods held by the connector rather than the file
+	pod := v1.Pod{}
+	if err := yaml.Unmarshal([]byte(pods), &pod); err != nil {
+		t.Fatal(err)
+	}
+
+	loop := status{ShowTimeline: true}
+	builder := RowBuilder{LoopStatus: true, ShowInitContainers: true, ShowTimeline: true, Connection: &Connector{podList: []v1.Pod{pod}}}
+	tbl, _ := buildTestTable(t, builder, &loop, commonFlags{showTreeView: true}, pods)
+
+	nameCol, seqCol := -1, -1
+	for i, head := range tbl.head {
+		switch head.title {
+		case "NAME":
+			nameCol = i
+		case "SEQ":
+			seqCol = i
+			if head.hidden {
+				t.Errorf("SEQ column should be shown with --timeline")
+			}
+		}
+	}
+
+	output := []statusTimelineTest{}
+	for _, row := range tbl.getVisibleRows() {
+		if row[0].text == TypeIDContainer || row[0].text == TypeIDInitContainer {
+			output = append(output, statusTimelineTest{strings.TrimSpace(row[nameCol].text), row[seqCol].text})
+		}
+	}
+	if !reflect.DeepEqual(output, statusTimelineTests) {
+		t.Errorf("Output %v not equal to expected %v", output, statusTimelineTests)
+	}
+
+}
+
+// *****************
+// state
+// *****************
+type statusStateTest struct {
+	states   []string
+	expected []string
+}
+
+var statusStateTests = []statusStateTest{
+	{nil, []string{"web", "worker", "job"}},
+	{[]string{"waiting"}, []string{"worker"}},
+	{[]string{"running", "terminated"}, []string{"web", "job"}},
+}
+
+func TestStatusState(t *testing.T) {
+	pods := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+spec:
+  containers:
+  - name: web
+  - name: worker
+  - name: job
+status:
+  containerStatuses:
+  - name: web
+    state:
+      running: {}
+  - name: worker
+    state:
+      waiting:
+        reason: CrashLoopBackOff
+  - name: job
+    state:
+      terminated:
+        reason: Completed
+`
+
+	for _, test := range statusStateTests {
+		loop := status{StateList: test.states}
+		tbl, _ := buildTestTable(t, RowBuilder{LoopStatus: true}, &loop, commonFlags{}, pods)
+
+		output := columnText(tbl, "CONTAINER")
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v for %v", output, test.expected, test.states)
+		}
+	}
+
+}
+
+// *****************
+// completed
+// *****************
+type statusCompletedTest struct {
+	loop     status
+	phases   []string
+	headers  []string
+	expected [][]string
+}
+
+var statusCompletedTests = []statusCompletedTest{
+	{status{ShowCompleted: true}, []string{"Succeeded", "Failed"},
+		[]string{"PODNAME", "CONTAINER", "RESTARTS", "STATE", "REASON", "EXIT-CODE", "SIGNAL", "TIMESTAMP", "FINISHED"},
+		[][]string{{"job-1", "0", "2024-01-01T10:05:00Z"}, {"job-2", "1", "2024-01-01T11:05:00Z"}}},
+	// --hide-completed only keeps the pods that could still be running
+	{status{}, []string{"Pending", "Running", "Unknown"},
+		[]string{"PODNAME", "CONTAINER", "READY", "STARTED", "RESTARTS", "STATE", "REASON", "EXIT-CODE", "SIGNAL", "AGE"},
+		[][]string{{"web-1", "", ""}}},
+}
+
+func TestStatusCompleted(t *testing.T) {
+	pods := `apiVersion: v1
+kind: Pod
+metadata:
+  name: job-1
+  namespace: default
+spec:
+  containers:
+  - name: job
+status:
+  phase: Succeeded
+  containerStatuses:
+  - name: job
+    state:
+      terminated:
+        exitCode: 0
+        reason: Completed
+        startedAt: "2024-01-01T10:00:00Z"
+        finishedAt: "2024-01-01T10:05:00Z"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: job-2
+  namespace: default
+spec:
+  containers:
+  - name: job
+status:
+  phase: Failed
+  containerStatuses:
+  - name: job
+    state:
+      terminated:
+        exitCode: 1
+        reason: Error
+        startedAt: "2024-01-01T11:00:00Z"
+        finishedAt: "2024-01-01T11:05:00Z"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+spec:
+  containers:
+  - name: web
+status:
+  phase: Running
+  containerStatuses:
+  - name: web
+    state:
+      running:
+        startedAt: "2024-01-01T09:00:00Z"
+`
+
+	for _, test := range statusCompletedTests {
+		loop := test.loop
+		tbl, _ := buildTestTable(t, RowBuilder{LoopStatus: true}, &loop, commonFlags{podPhase: test.phases}, pods)
+		if output := visibleHeaders(tbl); !reflect.DeepEqual(output, test.headers) {
+			t.Errorf("Headers %v not equal to expected %v", output, test.headers)
+		}
+
+		podNames := columnText(tbl, "PODNAME")
+		exitCodes := columnText(tbl, "EXIT-CODE")
+		finished := columnText(tbl, "FINISHED")
+		output := [][]string{}
+		for i := range podNames {
+			output = append(output, []string{podNames[i], exitCodes[i], finished[i]})
+		}
+		// the finish time is printed in the local time zone
+		expected := [][]string{}
+		for _, row := range test.expected {
+			if when, err := time.Parse(time.RFC3339, row[2]); err == nil {
+				row = []string{row[0], row[1], when.Local().Format(timestampFormat)}
+			}
+			expected = append(expected, row)
+		}
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("Output %v not equal to expected %v", output, expected)
+		}
+	}
+
+}
+
+// *****************
+// only-ephemeral
+// *****************
+type statusOnlyEphemeralTest struct {
+	loop       status
+	showTarget bool
+	expected   [][]string
+}
+
+var statusOnlyEphemeralTests = []statusOnlyEphemeralTest{
+	{status{}, false, [][]string{{"C", "web"}, {"E", "debugger"}}},
+	{status{OnlyEphemeral: true}, false, [][]string{{"E", "debugger"}}},
+	// --details adds the container the debug container was attached to
+	{status{OnlyEphemeral: true, ShowDetails: true}, true, [][]string{{"E", "debugger"}}},
+}
+
+func TestStatusOnlyEphemeral(t *testing.T) {
+	pods := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+spec:
+  containers:
+  - name: web
+  ephemeralContainers:
+  - name: debugger
+    image: busybox
+    targetContainerName: web
+status:
+  containerStatuses:
+  - name: web
+    state:
+      running: {}
+  ephemeralContainerStatuses:
+  - name: debugger
+    state:
+      running: {}
+`
+
+	for _, test := range statusOnlyEphemeralTests {
+		loop := test.loop
+		tbl, _ := buildTestTable(t, RowBuilder{LoopStatus: true}, &loop, commonFlags{}, pods)
+
+		types := columnText(tbl, "T")
+		names := columnText(tbl, "CONTAINER")
+		output := [][]string{}
+		for i := range names {
+			output = append(output, []string{types[i], names[i]})
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v", output, test.expected)
+		}
+
+		showTarget := false
+		for _, head := range visibleHeaders(tbl) {
+			showTarget = showTarget || head == "TARGET"
+		}
+		if showTarget != test.showTarget {
+			t.Errorf("Output TARGET shown %t not equal to expected %t", showTarget, test.showTarget)
+		} else if showTarget && columnText(tbl, "TARGET")[0] != "web" {
+			t.Errorf("Output TARGET %v not equal to expected web", columnText(tbl, "TARGET"))
+		}
+	}
+
+}
+
+// *****************
+// init-problems
+// *****************
+type statusInitProblemsTest struct {
+	container string
+	state     string
+	shown     bool
+	exitCode  string
+	signal    string
+}
+
+var statusInitProblemsTests = []statusInitProblemsTest{
+	// the exit code and signal come from the run that failed as the container is waiting to be restarted
+	{"crashing", "waiting:\n        reason: CrashLoopBackOff\n    lastState:\n      terminated:\n        exitCode: 137\n        signal: 9\n", true, "137", "9"},
+	{"erroring", "waiting:\n        reason: Error\n", true, "", ""},
+	{"running", "running: {}\n", false, "", ""},
+	{"finished", "terminated:\n        exitCode: 0\n        reason: Completed\n", false, "", ""},
+	{"pulling", "waiting:\n        reason: PodInitializing\n", false, "", ""},
+}
+
+func TestStatusInitProblems(t *testing.T) {
+	pods := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web-1\n  namespace: default\nspec:\n  initContainers:\n"
+	for _, test := range statusInitProblemsTests {
+		pods += "  - name: " + test.container + "\n"
+	}
+	pods += "  containers:\n  - name: web\nstatus:\n  initContainerStatuses:\n"
+	for _, test := range statusInitProblemsTests {
+		pods += "  - name: " + test.container + "\n    state:\n      " + test.state
+	}
+	// a standard container that is crashlooping isnt an init problem
+	pods += "  containerStatuses:\n  - name: web\n    state:\n      waiting:\n        reason: CrashLoopBackOff\n"
+
+	loop := status{InitProblems: true}
+	tbl, _ := buildTestTable(t, RowBuilder{LoopStatus: true, ShowInitContainers: true}, &loop, commonFlags{}, pods)
+
+	expected := [][]string{}
+	for _, test := range statusInitProblemsTests {
+		if test.shown {
+			expected = append(expected, []string{test.container, test.exitCode, test.signal})
+		}
+	}
+
+	names := columnText(tbl, "CONTAINER")
+	exitCodes := columnText(tbl, "EXIT-CODE")
+	signals := columnText(tbl, "SIGNAL")
+	output := [][]string{}
+	for i := range names {
+		output = append(output, []string{names[i], exitCodes[i], signals[i]})
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+
+}
+
+// *****************
+// sidecar
+// *****************
+type isSidecarTest struct {
+	name     string
+	expected bool
+}
+
+var isSidecarTests = []isSidecarTest{
+	{"proxy", true},
+	{"migrate", false},
+	{"setup", false},
+	// not an init container
+	{"web", false},
+}
+
+func TestIsSidecar(t *testing.T) {
+	always := v1.ContainerRestartPolicyAlways
+	pod := v1.Pod{Spec: v1.PodSpec{
+		InitContainers: []v1.Container{
+			{Name: "proxy", RestartPolicy: &always},
+			{Name: "migrate"},
+			{Name: "setup", RestartPolicy: new(v1.ContainerRestartPolicy)},
+		},
+		Containers: []v1.Container{{Name: "web"}},
+	}}
+
+	for _, test := range isSidecarTests {
+		if output := isSidecar(pod, test.name); output != test.expected {
+			t.Errorf("Output %t not equal to expected %t for %s", output, test.expected, test.name)
+		}
+	}
+
+	info := BuilderInformation{ContainerType: TypeIDInitContainer}
+	info.Data.pod = pod
+	loop := status{ShowSidecar: true}
+	rows, err := loop.BuildContainerStatus(v1.ContainerStatus{Name: "proxy"}, info)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if output := rows[0][22].text; output != "true" {
+		t.Errorf("Output %s not equal to expected true in the SIDECAR column", output)
+	}
+
+}
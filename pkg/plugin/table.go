@@ -1,10 +1,18 @@
 package plugin
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
+	"os"
 	"strings"
+	"text/template"
+	"unicode"
+
+	"golang.org/x/text/width"
 )
 
 // sets the maximum number of spaces allowed in a column, spaces are clipped to this number
@@ -19,13 +27,48 @@ type headerRow struct {
 }
 
 type Cell struct {
-	text   string
-	number int64
-	float  float64
-	typ    int // 0=string, 1=int64, 2=float64, 3=placeholder
-	phRef  int // placeholder reference id, used to track the row thats used as a placeholder
-	indent int // the number of indents required in the output
-	colour [2]int
+	text    string
+	number  int64
+	float   float64
+	typ     int // 0=string, 1=int64, 2=float64, 3=placeholder
+	phRef   int // placeholder reference id, used to track the row thats used as a placeholder
+	indent  int // the number of indents required in the output
+	colour  [2]int
+	boolean bool // text is true or false, printed as a symbol when the table has Symbols set
+}
+
+// symbolSet holds the characters used to draw the table, the ascii set is for terminals that cant show unicode
+type symbolSet struct {
+	treeBranch    string // drawn in front of each indented row in the tree view
+	boolTrue      string
+	boolFalse     string
+	boxVertical   string // the box style borders, the corners are the left, join and right of each border line
+	boxHorizontal string
+	boxTop        [3]string
+	boxMiddle     [3]string
+	boxBottom     [3]string
+}
+
+var unicodeSymbols = symbolSet{
+	treeBranch:    "└─",
+	boolTrue:      "✓",
+	boolFalse:     "✗",
+	boxVertical:   "│",
+	boxHorizontal: "─",
+	boxTop:        [3]string{"┌", "┬", "┐"},
+	boxMiddle:     [3]string{"├", "┼", "┤"},
+	boxBottom:     [3]string{"└", "┴", "┘"},
+}
+
+var asciiSymbols = symbolSet{
+	treeBranch:    "`-",
+	boolTrue:      "Y",
+	boolFalse:     "N",
+	boxVertical:   "|",
+	boxHorizontal: "-",
+	boxTop:        [3]string{"+", "+", "+"},
+	boxMiddle:     [3]string{"+", "+", "+"},
+	boxBottom:     [3]string{"+", "+", "+"},
 }
 
 type Table struct {
@@ -40,6 +83,27 @@ type Table struct {
 	placeHolderID int
 	ColourOutput  int
 	CustomColours [][2]int
+	Style         int               // STYLE_DEFAULT, STYLE_COMPACT or STYLE_BOX, only used by Print
+	HeaderAlias   map[string]string // header names to show in place of the column title, only applied when printing
+	Symbols       bool              // print boolean cells as symbols instead of true and false, only used by Print
+	ASCII         bool              // use the ascii symbol set for the tree and booleans when printing
+	Layout        int               // LAYOUT_DEFAULT, LAYOUT_COMPACT or LAYOUT_PRETTY, used by the json and yaml output
+	NoHeader      bool              // leave the header line out of the table and csv output
+	Out           io.Writer         // where the table is printed, defaults to stdout
+
+	truncateLength int          // maximum number of characters to print in the truncated columns
+	truncateColumn map[int]bool // column numbers that are truncated when printed
+	reflowColumn   map[int]bool // column numbers printed on their own lines under each row, only used by Print
+	filteredRows   int          // rows that were built but left out of the table by the match filter
+	PodColumns     []string     // columns that hold the same value on every row of a pod, used to group -o json-nested
+}
+
+// out returns the writer the table is printed to
+func (t *Table) out() io.Writer {
+	if t.Out == nil {
+		return os.Stdout
+	}
+	return t.Out
 }
 
 // SetHeader sets the header row to the specified array of strings
@@ -76,7 +140,7 @@ func (t *Table) AddRow(row ...Cell) {
 	}
 
 	for i := 0; i < t.headCount; i++ {
-		strLen := len([]rune(row[i].text))
+		strLen := textWidth(row[i].text)
 		if row[i].indent > 0 {
 			strLen += t.indentLen(row[i].indent)
 		}
@@ -177,10 +241,338 @@ func (t *Table) HideOnlyNamedColumns(columnName []string) error {
 // Print outputs the table on the terminal, taking the column order and visibiliy into account
 func (t *Table) Print() {
 	var cellcolour [2]int
-	var withColour bool
 	var visibleColumns int
 
 	headLine := ""
+	colourArray, withColour := t.columnColours()
+
+	if t.Style == STYLE_COMPACT || t.Style == STYLE_BOX {
+		t.printStyled(colourArray, withColour)
+		return
+	}
+
+	if t.NoHeader {
+		t.resizeColumnsToRows()
+	} else {
+		t.resizeTruncatedColumns()
+
+		// make room for any renamed headers that are longer than the column
+		for idx := range t.head {
+			titleLen := textWidth(t.headerTitle(idx)) + 2
+			if titleLen > t.head[idx].columnLength {
+				t.head[idx].columnLength = titleLen
+			}
+		}
+
+		// loop through all headers and make a single line properly spaced
+		for col := 0; col < t.headCount; col++ {
+			// columnOrder contains the actual column number to use next
+			idx := t.columnOrder[col]
+			if t.head[idx].hidden || t.reflowColumn[idx] {
+				continue
+			}
+
+			cellcolour = colourArray[visibleColumns]
+			visibleColumns += 1
+
+			word := t.headerTitle(idx)
+			runelen := textWidth(word)
+
+			if len(word) == 0 {
+				word = "-"
+			}
+
+			if t.ColourOutput != COLOUR_NONE && t.ColourOutput != COLOUR_ERRORS {
+				word = fmt.Sprintf("\033[%d;%dm%s%s", cellcolour[1], cellcolour[0], word, colourEnd)
+			}
+			pad := strings.Repeat(" ", t.head[idx].columnLength-runelen)
+
+			headLine += fmt.Sprint(word, pad)
+		}
+		// print the header in one long line
+		fmt.Fprintln(t.out(), strings.TrimRight(headLine, " "))
+	}
+
+	// loop through each row
+	for r := 0; r < len(t.data); r++ {
+		var row []Cell
+
+		visibleColumns = 0
+		line := ""
+		excludeRow := false
+		rowNum := t.rowOrder[r]
+
+		if t.hideRow[rowNum] {
+			continue
+		}
+
+		if t.data[rowNum][0].typ == 3 {
+			row = t.placeHolder[t.data[rowNum][0].phRef]
+		} else {
+			row = t.data[rowNum]
+		}
+		// now loop through each column in the currentl selected row
+		for col := 0; col < t.headCount; col++ {
+			idx := t.columnOrder[col]
+			cell := row[idx]
+
+			if t.head[idx].hidden || t.reflowColumn[idx] {
+				// dont process the row if its hidden
+				continue
+			}
+
+			if withColour { // if colour wanted
+				cellcolour = t.cellColour(colourArray[visibleColumns], cell)
+			}
+
+			visibleColumns += 1
+
+			if len(cell.text) == 0 {
+				cell.text = "-"
+			}
+
+			cell.text = t.truncateText(idx, t.boolText(cell))
+			origtxt := t.indentText(cell.indent, cell.text)
+			celltxt := origtxt
+			spaceCount := t.head[idx].columnLength - textWidth(origtxt)
+			if spaceCount <= 0 {
+				spaceCount = maxLineLength
+			}
+			pad := strings.Repeat(" ", spaceCount)
+
+			// colour output has been set and the cell has data
+			if withColour && cellcolour[0] != -1 {
+				// so we add the colour codes and modifier
+				celltxt = fmt.Sprintf("\033[%d;%dm%s%s", cellcolour[1], cellcolour[0], origtxt, colourEnd)
+			}
+
+			line += fmt.Sprint(celltxt, pad)
+		}
+		if !excludeRow {
+			fmt.Fprintln(t.out(), strings.TrimRight(line, " "))
+			for _, reflowLine := range t.reflowLines(row) {
+				fmt.Fprintln(t.out(), reflowLine)
+			}
+		}
+	}
+
+}
+
+// ReflowColumns moves the named columns out of the table when printed, the text of each one is wrapped onto its own
+//
+//	indented lines under the row instead so long messages dont push the other columns out of line
+func (t *Table) ReflowColumns(columnName ...string) {
+	t.reflowColumn = make(map[int]bool)
+
+	for _, name := range columnName {
+		for i, h := range t.head {
+			if h.title == name && !h.hidden {
+				t.reflowColumn[i] = true
+			}
+		}
+	}
+}
+
+// reflowIndent is the number of spaces in front of each reflowed line
+const reflowIndent = 4
+
+// reflowLines returns the lines to print under a row for each reflowed column that has text, the column title is
+//
+//	printed in front of the first line and the rest are lined up with the text
+func (t *Table) reflowLines(row []Cell) []string {
+	var lines []string
+
+	for col := 0; col < t.headCount; col++ {
+		idx := t.columnOrder[col]
+		if !t.reflowColumn[idx] || len(strings.TrimSpace(row[idx].text)) == 0 {
+			continue
+		}
+
+		title := t.headerTitle(idx) + ": "
+		prefix := strings.Repeat(" ", reflowIndent) + title
+		for i, text := range wrapText(row[idx].text, maxLineLength-textWidth(title)) {
+			if i == 1 {
+				prefix = strings.Repeat(" ", reflowIndent+textWidth(title))
+			}
+			lines = append(lines, prefix+text)
+		}
+	}
+
+	return lines
+}
+
+// TruncateColumns shortens the text in the named columns to length characters when the table is printed, the
+//
+//	full text is still used for every other output type. only the first column with each name is shortened as the
+//	default columns come before the commands own columns, which can reuse the same name
+func (t *Table) TruncateColumns(length int, columnName ...string) {
+	t.truncateLength = length
+	t.truncateColumn = make(map[int]bool)
+
+	for _, name := range columnName {
+		for i, h := range t.head {
+			if h.title == name {
+				t.truncateColumn[i] = true
+				break
+			}
+		}
+	}
+}
+
+// truncateText shortens the text to the truncate length ending with an ellipsis, tree view names are made up
+//
+//	of the kind and name (Container/name) so only the name part is shortened
+func (t *Table) truncateText(columnNumber int, text string) string {
+	if t.truncateLength <= 0 || !t.truncateColumn[columnNumber] {
+		return text
+	}
+
+	prefix := ""
+	if i := strings.LastIndex(text, "/"); i >= 0 {
+		prefix = text[:i+1]
+		text = text[i+1:]
+	}
+
+	runes := []rune(text)
+	if len(runes) <= t.truncateLength {
+		return prefix + text
+	}
+
+	return prefix + string(runes[:t.truncateLength-1]) + "…"
+}
+
+// textWidth returns the number of terminal columns needed to print text, wide runes such as CJK and most emoji
+//
+//	take up two columns and combining marks take none, so rows line up whatever language the text is in
+func textWidth(text string) int {
+	count := 0
+	for _, r := range text {
+		switch {
+		case r < 0x80:
+			count++
+		case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || r == '\u200d':
+			// combining marks and the zero width joiner dont take up a column of their own
+		default:
+			switch width.LookupRune(r).Kind() {
+			case width.EastAsianWide, width.EastAsianFullwidth:
+				count += 2
+			default:
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// resizeTruncatedColumns works out the width of the truncated columns from the shortened text of the visible rows
+func (t *Table) resizeTruncatedColumns() {
+	if t.truncateLength <= 0 {
+		return
+	}
+
+	for idx := range t.truncateColumn {
+		t.head[idx].columnLength = textWidth(t.head[idx].title) + 2
+	}
+
+	for _, row := range t.getVisibleRows() {
+		for idx := range t.truncateColumn {
+			strLen := textWidth(t.truncateText(idx, row[idx].text)) + t.indentLen(row[idx].indent)
+			if strLen+2 > t.head[idx].columnLength {
+				t.head[idx].columnLength = strLen + 2
+			}
+		}
+	}
+}
+
+// resizeColumnsToRows works out the width of every column from the visible rows only, used when the header isnt
+//
+//	printed so the columns are no wider than the values in them
+func (t *Table) resizeColumnsToRows() {
+	for idx := range t.head {
+		// empty cells are printed as -
+		t.head[idx].columnLength = 3
+	}
+
+	for _, row := range t.getVisibleRows() {
+		for idx := range t.head {
+			strLen := textWidth(t.truncateText(idx, t.boolText(row[idx]))) + t.indentLen(row[idx].indent)
+			if strLen+2 > t.head[idx].columnLength {
+				t.head[idx].columnLength = strLen + 2
+			}
+		}
+	}
+
+	for idx := range t.head {
+		if t.head[idx].columnLength > maxLineLength {
+			t.head[idx].columnLength = maxLineLength
+		}
+	}
+}
+
+// headerTitle returns the name to print for the column, this is the renamed header if one was set otherwise its the column title
+func (t *Table) headerTitle(columnNumber int) string {
+	title := t.head[columnNumber].title
+	if alias, ok := t.HeaderAlias[title]; ok {
+		return alias
+	}
+	return title
+}
+
+// SetHeaderAlias validates and sets the header names used when printing, the original names are still used
+//
+//	for sorting and selecting columns
+func (t *Table) SetHeaderAlias(alias map[string]string) error {
+	var validNames []string
+
+	for _, h := range t.head {
+		validNames = append(validNames, h.title)
+	}
+
+	for name := range alias {
+		found := false
+		for _, h := range t.head {
+			if h.title == name {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("error: unable to rename invalid column \"%s\" current valid column names are as following %s", name, validNames)
+		}
+	}
+
+	t.HeaderAlias = alias
+
+	// the column widths were worked out from the original names, a shorter name should give a narrower column
+	for idx := range t.head {
+		name, ok := alias[t.head[idx].title]
+		if !ok {
+			continue
+		}
+		t.head[idx].columnLength = textWidth(name) + 2
+		for _, row := range t.data {
+			if row[0].typ == 3 {
+				row = t.placeHolder[row[0].phRef]
+			}
+			if idx >= len(row) {
+				continue
+			}
+			strLen := textWidth(row[idx].text) + t.indentLen(row[idx].indent)
+			if strLen+2 > t.head[idx].columnLength {
+				t.head[idx].columnLength = strLen + 2
+			}
+		}
+		if t.head[idx].columnLength > maxLineLength {
+			t.head[idx].columnLength = maxLineLength
+		}
+	}
+
+	return nil
+}
+
+// columnColours returns the colour to use for each column and if colour output is required at all
+func (t *Table) columnColours() ([][2]int, bool) {
+	var withColour bool
+
 	colourArray := make([][2]int, t.headCount)
 
 	switch t.ColourOutput {
@@ -225,126 +617,243 @@ func (t *Table) Print() {
 		}
 	}
 
-	// loop through all headers and make a single line properly spaced
-	for col := 0; col < t.headCount; col++ {
-		// columnOrder contains the actual column number to use next
-		idx := t.columnOrder[col]
-		if t.head[idx].hidden {
-			continue
-		}
-
-		cellcolour = colourArray[visibleColumns]
-		visibleColumns += 1
+	return colourArray, withColour
+}
 
-		word := t.head[idx].title
-		runelen := len([]rune(word))
+// cellColour picks the colour for a single cell, wheelColour is the columns colour which is
+//
+//	overridden by the cells own colour depending on the colour type in use
+func (t *Table) cellColour(wheelColour [2]int, cell Cell) [2]int {
+	cellcolour := wheelColour
 
-		if len(word) == 0 {
-			word = "-"
+	switch t.ColourOutput {
+	case COLOUR_ERRORS:
+		// override if we should only show error colours
+		if cell.colour[0] != -1 {
+			cellcolour = cell.colour
+		} else {
+			cellcolour[0] = -1
 		}
-
-		if t.ColourOutput != COLOUR_NONE && t.ColourOutput != COLOUR_ERRORS {
-			word = fmt.Sprintf("\033[%d;%dm%s%s", cellcolour[1], cellcolour[0], word, colourEnd)
+	case COLOUR_CUSTOMMIX:
+		fallthrough
+	case COLOUR_MIX:
+		// override if we should mix colours
+		if cell.colour[0] > 0 {
+			cellcolour = cell.colour
 		}
-		pad := strings.Repeat(" ", t.head[idx].columnLength-runelen)
-
-		headLine += fmt.Sprint(word, pad)
 	}
-	// print the header in one long line
-	fmt.Println(strings.TrimRight(headLine, " "))
 
-	// loop through each row
-	for r := 0; r < len(t.data); r++ {
-		var row []Cell
+	return cellcolour
+}
 
-		visibleColumns = 0
-		line := ""
-		excludeRow := false
-		rowNum := t.rowOrder[r]
+// printStyled outputs the table using the compact or box style, unlike the default style the column
+//
+//	widths are worked out from the visible rows so long cells are never clipped
+func (t *Table) printStyled(colourArray [][2]int, withColour bool) {
+	var columns []int
+	var widths []int
 
-		if t.hideRow[rowNum] {
+	// build a list of visible columns along with the width of each header
+	for col := 0; col < t.headCount; col++ {
+		idx := t.columnOrder[col]
+		if t.head[idx].hidden || t.reflowColumn[idx] {
 			continue
 		}
-
-		if t.data[rowNum][0].typ == 3 {
-			row = t.placeHolder[t.data[rowNum][0].phRef]
+		columns = append(columns, idx)
+		if t.NoHeader {
+			widths = append(widths, 0)
 		} else {
-			row = t.data[rowNum]
+			widths = append(widths, textWidth(t.headerTitle(idx)))
 		}
-		// now loop through each column in the currentl selected row
-		for col := 0; col < t.headCount; col++ {
-			idx := t.columnOrder[col]
-			cell := row[idx]
+	}
 
-			if t.head[idx].hidden {
-				// dont process the row if its hidden
-				continue
+	rows := t.getVisibleRows()
+	for _, row := range rows {
+		for i, idx := range columns {
+			cellLen := textWidth(t.indentText(row[idx].indent, t.truncateText(idx, t.boolText(row[idx]))))
+			if len(row[idx].text) == 0 {
+				cellLen = 1 // empty cells are shown as -
 			}
+			if cellLen > widths[i] {
+				widths[i] = cellLen
+			}
+		}
+	}
 
-			if withColour { // if colour wanted
-				cellcolour = colourArray[visibleColumns] //set colour from wheel as default colour
-				switch t.ColourOutput {
-				case COLOUR_ERRORS:
-					// override if we should only show error colours
-					if cell.colour[0] != -1 {
-						cellcolour = cell.colour
-					} else {
-						cellcolour[0] = -1
-					}
-				case COLOUR_CUSTOMMIX:
-					fallthrough
-				case COLOUR_MIX:
-					// override if we should mix colours
-					if cell.colour[0] > 0 {
-						cellcolour = cell.colour
-					}
+	// the last column is widened when a reflowed line wont fit inside the box
+	if t.Style == STYLE_BOX && len(widths) > 0 {
+		for _, row := range rows {
+			for _, reflowLine := range t.reflowLines(row) {
+				if extra := textWidth(reflowLine) - boxInnerWidth(widths); extra > 0 {
+					widths[len(widths)-1] += extra
 				}
 			}
+		}
+	}
 
-			visibleColumns += 1
+	// compact uses a single space between columns, box draws a border with one space either side of the text
+	separator := " "
+	lineStart := ""
+	lineEnd := ""
+	if t.Style == STYLE_BOX {
+		symbols := t.symbols()
+		separator = " " + symbols.boxVertical + " "
+		lineStart = symbols.boxVertical + " "
+		lineEnd = " " + symbols.boxVertical
+		fmt.Fprintln(t.out(), t.boxBorder(widths, symbols.boxTop))
+	}
+
+	if !t.NoHeader {
+		line := lineStart
+		for i, idx := range columns {
+			word := t.headerTitle(idx)
+			pad := strings.Repeat(" ", widths[i]-textWidth(word))
+
+			if t.ColourOutput != COLOUR_NONE && t.ColourOutput != COLOUR_ERRORS {
+				word = fmt.Sprintf("\033[%d;%dm%s%s", colourArray[i][1], colourArray[i][0], word, colourEnd)
+			}
+
+			if i > 0 {
+				line += separator
+			}
+			line += word + pad
+		}
+		line += lineEnd
+		fmt.Fprintln(t.out(), strings.TrimRight(line, " "))
+
+		if t.Style == STYLE_BOX {
+			fmt.Fprintln(t.out(), t.boxBorder(widths, t.symbols().boxMiddle))
+		}
+	}
+
+	for _, row := range rows {
+		line := lineStart
+		for i, idx := range columns {
+			cell := row[idx]
 
 			if len(cell.text) == 0 {
 				cell.text = "-"
 			}
 
-			origtxt := t.indentText(cell.indent, cell.text)
-			celltxt := origtxt
-			spaceCount := t.head[idx].columnLength - len([]rune(origtxt))
-			if spaceCount <= 0 {
-				spaceCount = maxLineLength
-			}
-			pad := strings.Repeat(" ", spaceCount)
+			celltxt := t.indentText(cell.indent, t.truncateText(idx, t.boolText(cell)))
+			pad := strings.Repeat(" ", widths[i]-textWidth(celltxt))
 
-			// colour output has been set and the cell has data
-			if withColour && cellcolour[0] != -1 {
-				// so we add the colour codes and modifier
-				celltxt = fmt.Sprintf("\033[%d;%dm%s%s", cellcolour[1], cellcolour[0], origtxt, colourEnd)
+			if withColour {
+				cellcolour := t.cellColour(colourArray[i], cell)
+				if cellcolour[0] != -1 {
+					celltxt = fmt.Sprintf("\033[%d;%dm%s%s", cellcolour[1], cellcolour[0], celltxt, colourEnd)
+				}
 			}
 
-			line += fmt.Sprint(celltxt, pad)
+			if i > 0 {
+				line += separator
+			}
+			line += celltxt + pad
 		}
-		if !excludeRow {
-			fmt.Println(strings.TrimRight(line, " "))
+		line += lineEnd
+		fmt.Fprintln(t.out(), strings.TrimRight(line, " "))
+
+		for _, reflowLine := range t.reflowLines(row) {
+			if t.Style == STYLE_BOX {
+				reflowLine = lineStart + reflowLine + strings.Repeat(" ", boxInnerWidth(widths)-textWidth(reflowLine)) + lineEnd
+			}
+			fmt.Fprintln(t.out(), reflowLine)
 		}
 	}
 
+	if t.Style == STYLE_BOX {
+		fmt.Fprintln(t.out(), t.boxBorder(widths, t.symbols().boxBottom))
+	}
+}
+
+// boxInnerWidth returns the width inside the box borders, the columns plus the " │ " between each of them
+func boxInnerWidth(widths []int) int {
+	inner := len(widths)*3 - 3
+	for _, w := range widths {
+		inner += w
+	}
+	return inner
+}
+
+// boxBorder returns a horizontal border line for the box style, corners holds the left, joining and right characters
+func (t *Table) boxBorder(widths []int, corners [3]string) string {
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		parts[i] = strings.Repeat(t.symbols().boxHorizontal, w+2)
+	}
+	return corners[0] + strings.Join(parts, corners[1]) + corners[2]
+}
+
+// PrintJson outputs the table on the terminal as json, all fileds are shown in the sorted order so the output can
+// be diffed, programs like jq can be used to filter
+func (t *Table) PrintJson() error {
+	out := strings.Builder{}
+	fmt.Fprintln(&out, "{\"data\":[")
+	t.printJsonRows(&out)
+	fmt.Fprintln(&out, "]}")
+	return t.writeJson(out.String())
+}
+
+// tableMetadata is printed ahead of the json data when --with-metadata is set
+type tableMetadata struct {
+	Total   int               `json:"total"`
+	Shown   int               `json:"shown"`
+	Hidden  int               `json:"hidden"`
+	Filters map[string]string `json:"filters"`
+}
+
+// metadata counts the rows that were built, shown and hidden by either the match filter or the oddities/tree checks
+func (t *Table) metadata(filters map[string]string) tableMetadata {
+	total := len(t.data) + t.filteredRows
+	shown := len(t.getVisibleRows())
+
+	if filters == nil {
+		filters = map[string]string{}
+	}
+
+	return tableMetadata{
+		Total:   total,
+		Shown:   shown,
+		Hidden:  total - shown,
+		Filters: filters,
+	}
+}
+
+// PrintJsonWithMetadata outputs the table as json in the same layout as PrintJson with a metadata object added that
+//
+//	holds the row counts and the filters that were used
+func (t *Table) PrintJsonWithMetadata(filters map[string]string) error {
+	// the filters are printed as typed so <, > and & are left unescaped
+	meta := strings.Builder{}
+	encoder := json.NewEncoder(&meta)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(t.metadata(filters)); err != nil {
+		return err
+	}
+
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "{\"metadata\":%s,\n", strings.TrimSpace(meta.String()))
+	fmt.Fprintln(&out, "\"data\":[")
+	t.printJsonRows(&out)
+	fmt.Fprintln(&out, "]}")
+	return t.writeJson(out.String())
 }
 
-// PrintJson outputs the table on the terminal as json, all fileds are shown and all are unsorted as
-// programs like jq can be used to filter and sort
-func (t *Table) PrintJson() {
+// printJsonRows prints each visible row as a json object to out in the sorted order, rows hidden by --oddities or
+//
+//	the row limit are left out the same as the table. the caller is expected to print the surrounding array
+func (t *Table) printJsonRows(out io.Writer) {
+	rows := t.getVisibleRows()
 	// loop through each row
-	fmt.Println("{\"data\":[")
-	for rowNum := 0; rowNum < len(t.data); rowNum++ {
+	for rowNum, row := range rows {
 		line := "{"
-		row := t.data[rowNum]
 		// now loop through each column for the currently selected row
 		for col := 0; col < t.headCount; col++ {
 			word := row[col].text
 			if len(word) == 0 {
 				word = ""
 			}
-			line += fmt.Sprintf("\"%s\": \"%s\"", t.head[col].title, word)
+			line += fmt.Sprintf("%s: %s", jsonString(t.headerTitle(col)), jsonString(word))
 			// add , to the end of every key/value except the last
 			if col+1 < t.headCount {
 				line += ", "
@@ -353,85 +862,234 @@ func (t *Table) PrintJson() {
 
 		line += "}"
 		// again add the , to end of every line except the last
-		if rowNum+1 < len(t.data) {
+		if rowNum+1 < len(rows) {
 			line += ", "
 		}
 
-		fmt.Println(line)
+		fmt.Fprintln(out, line)
 	}
-	fmt.Println("]}")
+}
 
+// PrintJsonNested outputs the table as json with one object per pod, the columns named in podColumns are printed
+//
+//	once on the pod object and the remaining columns are listed per row in the nested array childName.
+//	rows are grouped in the order the pods are first seen in the sorted rows, an error is returned if no podColumns exist
+func (t *Table) PrintJsonNested(childName string, podColumns ...string) error {
+	isPodColumn := make([]bool, t.headCount)
+	found := false
+	for col := 0; col < t.headCount; col++ {
+		for _, name := range podColumns {
+			if t.head[col].title == name {
+				isPodColumn[col] = true
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("unable to group rows by pod, none of the columns %s were found", strings.Join(podColumns, ", "))
+	}
+
+	// group the visible rows by the values of the pod columns
+	groupOrder := []string{}
+	groups := make(map[string][][]Cell)
+	for _, row := range t.getVisibleRows() {
+		key := ""
+		for col := 0; col < t.headCount; col++ {
+			if isPodColumn[col] {
+				key += row[col].text + "/"
+			}
+		}
+		if _, ok := groups[key]; !ok {
+			groupOrder = append(groupOrder, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+
+	out := strings.Builder{}
+	fmt.Fprintln(&out, "{\"data\":[")
+	for groupNum, key := range groupOrder {
+		rowList := groups[key]
+		line := "{"
+		// the pod columns are the same for every row in the group so we take them from the first row
+		for col := 0; col < t.headCount; col++ {
+			if isPodColumn[col] {
+				line += fmt.Sprintf("%s: %s, ", jsonString(t.headerTitle(col)), jsonString(rowList[0][col].text))
+			}
+		}
+		line += fmt.Sprintf("%s:[", jsonString(childName))
+		fmt.Fprintln(&out, line)
+
+		for i, row := range rowList {
+			line := "{"
+			sep := ""
+			for col := 0; col < t.headCount; col++ {
+				if isPodColumn[col] {
+					continue
+				}
+				line += fmt.Sprintf("%s%s: %s", sep, jsonString(t.headerTitle(col)), jsonString(row[col].text))
+				sep = ", "
+			}
+
+			line += "}"
+			// add the , to end of every line except the last
+			if i+1 < len(rowList) {
+				line += ", "
+			}
+			fmt.Fprintln(&out, line)
+		}
+
+		line = "]}"
+		if groupNum+1 < len(groupOrder) {
+			line += ", "
+		}
+		fmt.Fprintln(&out, line)
+	}
+	fmt.Fprintln(&out, "]}")
+
+	return t.writeJson(out.String())
+}
+
+// jsonString returns text as a quoted json string, any quotes or control characters in the text are escaped
+func jsonString(text string) string {
+	out := strings.Builder{}
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	// encoding a string cant fail
+	encoder.Encode(text)
+	return strings.TrimSuffix(out.String(), "\n")
 }
 
-// PrintYaml outputs the table on the terminal as yaml, all fileds are shown and all are unsorted as
-// other programs can be used to filter and sort
+// writeJson prints the json text using the selected Layout, compact puts everything on a single line and pretty
+//
+//	indents each object, the default keeps the layout of one row per line
+func (t *Table) writeJson(text string) error {
+	out := bytes.Buffer{}
+
+	switch t.Layout {
+	case LAYOUT_COMPACT:
+		if err := json.Compact(&out, []byte(text)); err != nil {
+			return err
+		}
+		out.WriteString("\n")
+	case LAYOUT_PRETTY:
+		if err := json.Indent(&out, []byte(text), "", "  "); err != nil {
+			return err
+		}
+	default:
+		out.WriteString(text)
+	}
+
+	fmt.Fprint(t.out(), out.String())
+	return nil
+}
+
+// PrintTemplate renders the table with tmpl, the template is passed every visible row as a map of column name to value
+//
+//	in the same way as the table output so hidden rows and columns are left out. nothing is printed if the template
+//	fails part way through
+func (t *Table) PrintTemplate(tmpl *template.Template) error {
+	out := bytes.Buffer{}
+	if err := tmpl.Execute(&out, t.templateRows()); err != nil {
+		return fmt.Errorf("unable to render template: %w", err)
+	}
+	_, err := out.WriteTo(t.out())
+	return err
+}
+
+// templateRows returns each visible row of the table as a map of column name to value, hidden columns are skipped
+func (t *Table) templateRows() []map[string]string {
+	visible := t.getVisibleRows()
+	rows := make([]map[string]string, 0, len(visible))
+
+	for _, row := range visible {
+		record := make(map[string]string, t.headCount)
+		for col := 0; col < t.headCount; col++ {
+			if t.head[col].hidden {
+				continue
+			}
+			record[t.headerTitle(col)] = row[col].text
+		}
+		rows = append(rows, record)
+	}
+
+	return rows
+}
+
+// PrintYaml outputs the table on the terminal as yaml, all fileds are shown in the sorted order so the output can
+// be diffed, other programs can be used to filter
 func (t *Table) PrintYaml() {
 	// loop through each row
-	fmt.Println("data:")
-	for rowNum := 0; rowNum < len(t.data); rowNum++ {
+	fmt.Fprintln(t.out(), "data:")
+	for _, row := range t.getVisibleRows() {
 		line := ""
 		sep := "-"
 
-		row := t.data[rowNum]
+		if t.Layout == LAYOUT_COMPACT {
+			// compact rows are written as a single flow mapping, a json string is also a valid yaml double quoted
+			//  string so the values are escaped the same way
+			fields := []string{}
+			for col := 0; col < t.headCount; col++ {
+				fields = append(fields, fmt.Sprintf("%s: %s", t.headerTitle(col), jsonString(row[col].text)))
+			}
+			fmt.Fprintf(t.out(), "- {%s}\n", strings.Join(fields, ", "))
+			continue
+		}
+
 		// now loop through each column for the currently selected row
 		for col := 0; col < t.headCount; col++ {
 			word := row[col].text
 			if len(word) == 0 {
 				word = ""
 			}
-			line += fmt.Sprintf("%s %s: \"%s\"\n", sep, t.head[col].title, word)
+			line += fmt.Sprintf("%s %s: %s\n", sep, t.headerTitle(col), jsonString(word))
 			sep = " "
 		}
-		fmt.Print(line)
+		fmt.Fprint(t.out(), line)
 	}
 
 }
 
-// PrintList outputs the key and value on a single line by its self. all fileds are shown and all are unsorted as
-// other programs can be used to filter and sort
+// PrintList outputs the key and value on a single line by its self. all fileds are shown in the sorted order,
+// other programs can be used to filter
 func (t *Table) PrintList() {
 	// loop through each row
-	for rowNum := 0; rowNum < len(t.data); rowNum++ {
-		row := t.data[rowNum]
+	for _, row := range t.getVisibleRows() {
 		// now loop through each column for the currently selected row
 		for col := 0; col < t.headCount; col++ {
 			word := row[col].text
 			if len(word) == 0 {
 				word = ""
 			}
-			fmt.Println(t.head[col].title+":", word)
+			fmt.Fprintln(t.out(), t.headerTitle(col)+":", word)
 		}
 	}
 }
 
-// PrintCsv outputs the table as a csv including the header row. all fileds are shown and all are unsorted as
-// other programs can be used to filter and sort
+// PrintCsv outputs the table as a csv including the header row. all fileds are shown in the sorted order, other
+// programs can be used to filter
 func (t *Table) PrintCsv() {
 
 	if len(t.data) <= 0 {
 		return
 	}
 
-	line := ""
-	row := t.data[0]
-	// now loop through each column for the currently selected row
-	for col := 0; col < t.headCount; col++ {
-		word := row[col].text
-		if len(word) == 0 {
-			word = ""
-		}
-		line += fmt.Sprintf("\"%s\"", t.head[col].title)
-		// add , to the end of every column name except the last
-		if col+1 < t.headCount {
-			line += ", "
+	if !t.NoHeader {
+		line := ""
+		// now loop through each column to get the column names
+		for col := 0; col < t.headCount; col++ {
+			line += fmt.Sprintf("\"%s\"", t.headerTitle(col))
+			// add , to the end of every column name except the last
+			if col+1 < t.headCount {
+				line += ", "
+			}
 		}
+		fmt.Fprintln(t.out(), line)
 	}
-	fmt.Println(line)
 
-	// loop through each column to get the column names
-	for rowNum := 0; rowNum < len(t.data); rowNum++ {
+	// loop through each row
+	for _, row := range t.getVisibleRows() {
 		line := ""
-		row := t.data[rowNum]
 		// now loop through each column for the currently selected row
 		for col := 0; col < t.headCount; col++ {
 			word := row[col].text
@@ -445,7 +1103,7 @@ func (t *Table) PrintCsv() {
 			}
 		}
 
-		fmt.Println(line)
+		fmt.Fprintln(t.out(), line)
 	}
 }
 
@@ -659,6 +1317,24 @@ func NewCellTextIndent(text string, indentLevel int) Cell {
 	}
 }
 
+// NewCellBool quick wrapper to return a cell object containing true or false
+func NewCellBool(value bool) Cell {
+	return Cell{
+		text:    fmt.Sprintf("%t", value),
+		colour:  [2]int{-1, 0},
+		boolean: true,
+	}
+}
+
+// NewCellColourBool quick wrapper to return a cell object containing true or false and the colour to be used
+func NewCellColourBool(colour [2]int, value bool) Cell {
+	return Cell{
+		text:    fmt.Sprintf("%t", value),
+		colour:  colour,
+		boolean: true,
+	}
+}
+
 // NewCellInt quick wrapper to return a cell object containing the given string and int
 func NewCellInt(text string, value int64) Cell {
 	return Cell{
@@ -713,16 +1389,78 @@ func NewCellColourFloat(colour [2]int, text string, value float64) Cell {
 	}
 }
 
+// rangeStats holds the range worked out by ListOutOfRange, the int columns are converted to floats so both types can
+//
+//	be reported the same way
+type rangeStats struct {
+	column int
+	q1     float64
+	q3     float64
+	lower  float64 // rows below this value are out of range
+	upper  float64 // rows above this value are out of range
+}
+
+// explain describes why the value in row is outside the range, eg. RESTARTS=42 outside expected -3 to 5 (q1 0, q3 2)
+func (r rangeStats) explain(t *Table, row []Cell) string {
+	cell := row[r.column]
+	value := float64(cell.number)
+	if cell.typ == 2 {
+		value = cell.float
+	}
+
+	return fmt.Sprintf("%s=%s outside expected %s to %s (q1 %s, q3 %s)", t.headerTitle(r.column), formatStat(value),
+		formatStat(r.lower), formatStat(r.upper), formatStat(r.q1), formatStat(r.q3))
+}
+
+// explainOutOfRange returns a line for each visible row explaining why it is outside the range, each line starts with
+//
+//	the pod and container name of the row when the table has those columns
+func (t *Table) explainOutOfRange(stats rangeStats) []string {
+	var nameColumns []int
+	for i, h := range t.head {
+		if h.title == "PODNAME" || h.title == "CONTAINER" {
+			nameColumns = append(nameColumns, i)
+		}
+	}
+
+	out := []string{}
+	for _, row := range t.getVisibleRows() {
+		var names []string
+		for _, col := range nameColumns {
+			if len(row[col].text) > 0 {
+				names = append(names, row[col].text)
+			}
+		}
+
+		line := stats.explain(t, row)
+		if len(names) > 0 {
+			line = strings.Join(names, "/") + ": " + line
+		}
+		out = append(out, line)
+	}
+
+	return out
+}
+
+// formatStat prints whole numbers without a decimal point and everything else to 2 decimal places
+func formatStat(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
 // ListOutOfRange when given a columnID to work with it will calculate a range and
-// returns a list of rows with values outside that range
-func (t *Table) ListOutOfRange(columnID int) ([]int, error) {
+// returns a list of rows with values inside that range, along with the range that was used
+func (t *Table) ListOutOfRange(columnID int) ([]int, rangeStats, error) {
 	var upperFenceInt, lowerFenceInt int64
 	var upperFenceFloat, lowerFenceFloat float64
 
+	stats := rangeStats{column: columnID}
 	cellType := t.data[0][columnID].typ
 
 	if cellType == 0 {
-		return []int{}, errors.New("error: unable to creaate a range with strings")
+		return []int{}, stats, errors.New("error: unable to creaate a range with strings")
 	}
 
 	orderList := make([]int, len(t.data))
@@ -732,7 +1470,7 @@ func (t *Table) ListOutOfRange(columnID int) ([]int, error) {
 		cell := v[columnID]
 		orderList[i] = i
 		if cellType != cell.typ {
-			return []int{}, errors.New("error: table cell types dont match")
+			return []int{}, stats, errors.New("error: table cell types dont match")
 		}
 		if !t.hideRow[i] {
 			visibleRows += 1
@@ -740,15 +1478,18 @@ func (t *Table) ListOutOfRange(columnID int) ([]int, error) {
 	}
 
 	if visibleRows <= 4 {
-		return []int{}, errors.New("error: not enough visible rows to calculate useful range")
+		return []int{}, stats, errors.New("error: not enough visible rows to calculate useful range")
 	}
 
 	t.sort(orderList, columnID, true)
 	if cellType == 1 {
 		upperFenceInt, lowerFenceInt = t.getFencesInt(orderList, columnID, t.data)
+		stats.upper, stats.lower = float64(upperFenceInt), float64(lowerFenceInt)
 	} else {
 		upperFenceFloat, lowerFenceFloat = t.getFencesFloat(orderList, columnID, t.data)
+		stats.upper, stats.lower = upperFenceFloat, lowerFenceFloat
 	}
+	stats.q1, stats.q3 = t.getQuartiles(orderList, columnID, t.data, cellType)
 
 	out := []int{}
 
@@ -775,7 +1516,81 @@ func (t *Table) ListOutOfRange(columnID int) ([]int, error) {
 		}
 	}
 
-	return out, nil
+	return out, stats, nil
+}
+
+// SplitRows returns a copy of the table for each unique set of values in the named columns, each copy only holds the
+//
+//	rows with those values. the copies are returned in the order their rows are printed along with the column values
+//	they were split on, groups where every row is hidden are left out. an error is returned if a column doesnt exist
+func (t *Table) SplitRows(columnName ...string) ([][]string, []Table, error) {
+	var columns []int
+	for _, name := range columnName {
+		found := false
+		for i, h := range t.head {
+			if h.title == name {
+				columns = append(columns, i)
+				found = true
+			}
+		}
+		if !found {
+			return [][]string{}, []Table{}, fmt.Errorf("unable to split rows, column %s was not found", name)
+		}
+	}
+
+	groupOrder := []string{}
+	groupValues := make(map[string][]string)
+	groupRows := make(map[string][]int)
+	for r := 0; r < len(t.data); r++ {
+		rowNum := t.rowOrder[r]
+		values := make([]string, 0, len(columns))
+		for _, col := range columns {
+			values = append(values, t.data[rowNum][col].text)
+		}
+		key := strings.Join(values, "/")
+		if _, ok := groupRows[key]; !ok {
+			groupOrder = append(groupOrder, key)
+			groupValues[key] = values
+		}
+		groupRows[key] = append(groupRows[key], rowNum)
+	}
+
+	var valueList [][]string
+	var tableList []Table
+	for _, key := range groupOrder {
+		rowList := groupRows[key]
+		visible := false
+		for _, rowNum := range rowList {
+			if !t.hideRow[rowNum] {
+				visible = true
+			}
+		}
+		if !visible {
+			continue
+		}
+
+		// the rows are added in the sorted order so the column widths are worked out again from just this group
+		part := *t
+		part.head = make([]headerRow, len(t.head))
+		for i, h := range t.head {
+			h.columnLength = len(h.title) + 2
+			part.head[i] = h
+		}
+		part.data = [][]Cell{}
+		part.rowOrder = []int{}
+		part.hideRow = []bool{}
+		part.currentRow = 0
+
+		for i, rowNum := range rowList {
+			part.AddRow(t.data[rowNum]...)
+			part.hideRow[i] = t.hideRow[rowNum]
+		}
+
+		valueList = append(valueList, groupValues[key])
+		tableList = append(tableList, part)
+	}
+
+	return valueList, tableList, nil
 }
 
 // GetRows does what it says on the tin
@@ -783,6 +1598,28 @@ func (t *Table) GetRows() [][]Cell {
 	return t.data
 }
 
+// getVisibleRows returns the rows that are not hidden in the order they would be printed, placeholder rows
+//
+//	are replaced with the row they hold
+func (t *Table) getVisibleRows() [][]Cell {
+	rows := [][]Cell{}
+
+	for r := 0; r < len(t.data); r++ {
+		rowNum := t.rowOrder[r]
+		if t.hideRow[rowNum] {
+			continue
+		}
+
+		if t.data[rowNum][0].typ == 3 {
+			rows = append(rows, t.placeHolder[t.data[rowNum][0].phRef])
+		} else {
+			rows = append(rows, t.data[rowNum])
+		}
+	}
+
+	return rows
+}
+
 // HideRows just sets the hide row flag, used by the print function to exclude the row from the output
 func (t *Table) HideRows(rowID []int) {
 	for _, v := range rowID {
@@ -790,6 +1627,62 @@ func (t *Table) HideRows(rowID []int) {
 	}
 }
 
+// LimitRows hides every visible row after the first count rows, the current sort order is used to decide which rows are kept
+func (t *Table) LimitRows(count int) {
+	visible := 0
+	for _, rowNum := range t.rowOrder {
+		if t.hideRow[rowNum] {
+			continue
+		}
+
+		visible++
+		if visible > count {
+			t.hideRow[rowNum] = true
+		}
+	}
+}
+
+// HighlightAbove colours every cell in the named column whose number is greater than limit as bad, rows are kept
+//
+//	in the table. When withMarker is set and the cell colours wont be shown a * is added to the end of the text
+//	instead. the tree views placeholder rows hold totals so they are left alone
+func (t *Table) HighlightAbove(columnName string, limit int64, withMarker bool) error {
+	columnID := -1
+	for i, h := range t.head {
+		if h.title == columnName {
+			columnID = i
+			break
+		}
+	}
+	if columnID == -1 {
+		return fmt.Errorf("unable to find column %s", columnName)
+	}
+
+	// only the errors and mix modes print the colour set on each cell
+	marker := withMarker
+	switch t.ColourOutput {
+	case COLOUR_ERRORS, COLOUR_MIX, COLOUR_CUSTOMMIX:
+		marker = false
+	}
+
+	for _, row := range t.data {
+		cell := &row[columnID]
+		if cell.typ == 3 || cell.number <= limit {
+			continue
+		}
+
+		cell.colour = colourBad
+		if marker {
+			cell.text += "*"
+			if textWidth(cell.text)+2 > t.head[columnID].columnLength {
+				t.head[columnID].columnLength = textWidth(cell.text) + 2
+			}
+		}
+	}
+
+	return nil
+}
+
 // getFencesInt given the current order and a list of rows caluclate the upper and lower boundy exclusion limit for the selected columnID
 func (t *Table) getFencesInt(orderList []int, columnID int, rows [][]Cell) (int64, int64) {
 	upper, lower := t.getFencesBoundarys(orderList, columnID, rows, 1)
@@ -802,11 +1695,20 @@ func (t *Table) getFencesFloat(orderList []int, columnID int, rows [][]Cell) (fl
 	return upper.(float64), lower.(float64)
 }
 
-// getFencesBoundarys the actual function to caluclate the upper and lower boundy exclusion limit
-func (t *Table) getFencesBoundarys(orderList []int, columnID int, rows [][]Cell, cellType int) (interface{}, interface{}) {
+// getQuartiles returns the 1st and 3rd quartile of the selected columnID as floats for both int and float columns
+func (t *Table) getQuartiles(orderList []int, columnID int, rows [][]Cell, cellType int) (float64, float64) {
+	q1, q3 := t.getQuartileBoundarys(orderList, columnID, rows, cellType)
+	if cellType == 1 {
+		return float64(q1.(int64)), float64(q3.(int64))
+	}
+	return q1.(float64), q3.(float64)
+}
+
+// getQuartileBoundarys works out the 1st and 3rd quartile of the selected columnID, orderList must already be sorted
+func (t *Table) getQuartileBoundarys(orderList []int, columnID int, rows [][]Cell, cellType int) (interface{}, interface{}) {
 	// find middle of the list
-	var q1Int, q3Int, iqrInt int64
-	var q1Float, q3Float, iqrFloat float64
+	var q1Int, q3Int int64
+	var q1Float, q3Float float64
 
 	// find the middle point in the list so we can split the list into 3
 	listLen := len(orderList) + 1
@@ -855,20 +1757,32 @@ func (t *Table) getFencesBoundarys(orderList []int, columnID int, rows [][]Cell,
 		}
 	}
 
+	if cellType == 1 {
+		return q1Int, q3Int
+	}
+	return q1Float, q3Float
+}
+
+// getFencesBoundarys the actual function to caluclate the upper and lower boundy exclusion limit
+func (t *Table) getFencesBoundarys(orderList []int, columnID int, rows [][]Cell, cellType int) (interface{}, interface{}) {
+	q1, q3 := t.getQuartileBoundarys(orderList, columnID, rows, cellType)
+
 	// now we can work out the distance between the 1st and 3rd third of the list
 	// we calculate 1.5% of that difference and use to create a lower and upper fence
 	// these can then be used to exclude everything in side of the 2 fences
 	if cellType == 1 {
-		iqrInt = q3Int - q1Int
+		q1Int, q3Int := q1.(int64), q3.(int64)
+		iqrInt := q3Int - q1Int
 		pc := int64((15 * iqrInt) / 10)
 		upperFenceInt := q3Int + pc
-		lowerFenceInt := pc - q1Int
+		lowerFenceInt := q1Int - pc
 		return upperFenceInt, lowerFenceInt
 	} else {
-		iqrFloat = q3Float - q1Float
+		q1Float, q3Float := q1.(float64), q3.(float64)
+		iqrFloat := q3Float - q1Float
 		pc := 1.5 * iqrFloat
 		upperFenceFloat := q3Float + pc
-		lowerFenceFloat := pc - q1Float
+		lowerFenceFloat := q1Float - pc
 		return upperFenceFloat, lowerFenceFloat
 	}
 }
@@ -897,11 +1811,29 @@ func (t *Table) AddPlaceHolderRow() int {
 	return id
 }
 
+// RemoveLastRow removes the row that was added last and returns it, column widths are left as they are
+func (t *Table) RemoveLastRow() []Cell {
+	last := len(t.data) - 1
+	row := t.data[last]
+
+	t.data = t.data[:last]
+	t.hideRow = t.hideRow[:last]
+	t.currentRow--
+	for i, rowNum := range t.rowOrder {
+		if rowNum == last {
+			t.rowOrder = append(t.rowOrder[:i], t.rowOrder[i+1:]...)
+			break
+		}
+	}
+
+	return row
+}
+
 // UpdatePlaceHolderRow - updates the given placeholder at id with the contents of cellList
 func (t *Table) UpdatePlaceHolderRow(id int, cellList []Cell) {
 
 	for i := 0; i < t.headCount; i++ {
-		strLen := len([]rune(cellList[i].text))
+		strLen := textWidth(cellList[i].text)
 		if cellList[i].indent > 0 {
 			strLen += t.indentLen(cellList[i].indent)
 		}
@@ -916,6 +1848,20 @@ func (t *Table) UpdatePlaceHolderRow(id int, cellList []Cell) {
 	t.placeHolder[id] = cellList
 }
 
+// ResolvePlaceHolders replaces each placeholder row with the row it holds, after this the rows can be sorted but
+//
+//	UpdatePlaceHolderRow and HidePlaceHolderRow can no longer find them
+func (t *Table) ResolvePlaceHolders() {
+	for rowNum, row := range t.data {
+		if row[0].typ != 3 {
+			continue
+		}
+		if cellList, ok := t.placeHolder[row[0].phRef]; ok && cellList[0].typ != 3 {
+			t.data[rowNum] = cellList
+		}
+	}
+}
+
 // HidePlaceHolderRow matches the placeholder id to an actual row number and calls HideRows to hide the row
 func (t *Table) HidePlaceHolderRow(id int) {
 	for r := 0; r < len(t.data); r++ {
@@ -927,7 +1873,30 @@ func (t *Table) HidePlaceHolderRow(id int) {
 	}
 }
 
-// indentText indents the text to the specified level adds └─ for every level above 0
+// symbols returns the symbol set to print the table with
+func (t *Table) symbols() symbolSet {
+	if t.ASCII {
+		return asciiSymbols
+	}
+	return unicodeSymbols
+}
+
+// boolText returns the text to print for the cell, boolean cells are swapped for a symbol when Symbols is set
+func (t *Table) boolText(cell Cell) string {
+	if !t.Symbols || !cell.boolean {
+		return cell.text
+	}
+
+	switch cell.text {
+	case "true":
+		return t.symbols().boolTrue
+	case "false":
+		return t.symbols().boolFalse
+	}
+	return cell.text
+}
+
+// indentText indents the text to the specified level adds the tree branch symbol for every level above 0
 func (t *Table) indentText(level int, data string) string {
 	var indent string
 
@@ -936,11 +1905,11 @@ func (t *Table) indentText(level int, data string) string {
 	}
 
 	if level == 1 {
-		indent = "└─"
+		indent = t.symbols().treeBranch
 	}
 
 	if level >= 2 {
-		indent = strings.Repeat(" ", level) + "└─"
+		indent = strings.Repeat(" ", level) + t.symbols().treeBranch
 	}
 
 	return fmt.Sprint(indent, data)
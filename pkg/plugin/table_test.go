@@ -1,8 +1,14 @@
 package plugin
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+
+	"sigs.k8s.io/yaml"
 )
 
 var table Table
@@ -50,10 +56,10 @@ type addRowTest struct {
 }
 
 var addRowTests = []addRowTest{
-	{[]Cell{NewCellText("one")}, 1, 5, [][]Cell{{Cell{"one", 0, 0, 0, 0, 0, [2]int{-1, 0}}}}},
-	{[]Cell{NewCellText("two")}, 2, 5, [][]Cell{{Cell{"one", 0, 0, 0, 0, 0, [2]int{-1, 0}}}, {Cell{"two", 0, 0, 0, 0, 0, [2]int{-1, 0}}}}},
-	{[]Cell{NewCellText("three")}, 3, 7, [][]Cell{{Cell{"one", 0, 0, 0, 0, 0, [2]int{-1, 0}}}, {Cell{"two", 0, 0, 0, 0, 0, [2]int{-1, 0}}}, {Cell{"three", 0, 0, 0, 0, 0, [2]int{-1, 0}}}}},
-	{[]Cell{NewCellText("four"), NewCellText("extra"), NewCellText("larger")}, 4, 7, [][]Cell{{Cell{"one", 0, 0, 0, 0, 0, [2]int{-1, 0}}}, {Cell{"two", 0, 0, 0, 0, 0, [2]int{-1, 0}}}, {Cell{"three", 0, 0, 0, 0, 0, [2]int{-1, 0}}}, {Cell{"four", 0, 0, 0, 0, 0, [2]int{-1, 0}}, Cell{"extra", 0, 0, 0, 0, 0, [2]int{-1, 0}}, Cell{"larger", 0, 0, 0, 0, 0, [2]int{-1, 0}}}}},
+	{[]Cell{NewCellText("one")}, 1, 5, [][]Cell{{Cell{"one", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}}},
+	{[]Cell{NewCellText("two")}, 2, 5, [][]Cell{{Cell{"one", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}, {Cell{"two", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}}},
+	{[]Cell{NewCellText("three")}, 3, 7, [][]Cell{{Cell{"one", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}, {Cell{"two", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}, {Cell{"three", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}}},
+	{[]Cell{NewCellText("four"), NewCellText("extra"), NewCellText("larger")}, 4, 7, [][]Cell{{Cell{"one", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}, {Cell{"two", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}, {Cell{"three", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}, {Cell{"four", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}, Cell{"extra", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}, Cell{"larger", 0, 0, 0, 0, 0, [2]int{-1, 0}, false}}}},
 }
 
 func TestAddRow(t *testing.T) {
@@ -140,3 +146,814 @@ func TestHideColumnPanic(t *testing.T) {
 	// The following is the code under test
 	table.HideColumn(4)
 }
+
+// *****************
+// truncateText
+// *****************
+type truncateTextTest struct {
+	arg1     string
+	expected string
+}
+
+var truncateTextTests = []truncateTextTest{
+	{"web", "web"},
+	{"istio", "istio"},
+	{"istio-proxy", "isti…"},
+	{"Container/istio-proxy", "Container/isti…"},
+	{"Pod/web-1", "Pod/web-1"},
+}
+
+func TestTruncateText(t *testing.T) {
+	truncTable := Table{}
+	truncTable.SetHeader("CONTAINER", "NAME", "OTHER", "NAME")
+	truncTable.TruncateColumns(5, "CONTAINER", "NAME")
+
+	for _, test := range truncateTextTests {
+		for _, column := range []int{0, 1} {
+			if output := truncTable.truncateText(column, test.arg1); output != test.expected {
+				t.Errorf("Output %s not equal to expected %s", output, test.expected)
+			}
+		}
+		// only the first NAME column is shortened, later ones belong to the command like the env var names
+		for _, column := range []int{2, 3} {
+			if output := truncTable.truncateText(column, test.arg1); output != test.arg1 {
+				t.Errorf("Output %s not equal to expected %s", output, test.arg1)
+			}
+		}
+	}
+}
+
+// *****************
+// boolText
+// *****************
+type boolTextTest struct {
+	symbols  bool
+	ascii    bool
+	cell     Cell
+	expected string
+}
+
+var boolTextTests = []boolTextTest{
+	{false, false, NewCellBool(true), "true"},
+	{true, false, NewCellBool(true), "✓"},
+	{true, false, NewCellBool(false), "✗"},
+	{true, true, NewCellBool(true), "Y"},
+	{true, true, NewCellColourBool(colourBad, false), "N"},
+	// only cells created as booleans are swapped
+	{true, false, NewCellText("true"), "true"},
+}
+
+func TestBoolText(t *testing.T) {
+
+	for _, test := range boolTextTests {
+		tbl := Table{Symbols: test.symbols, ASCII: test.ascii}
+		if output := tbl.boolText(test.cell); output != test.expected {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+	// the tree branch shares the ascii toggle with the booleans
+	tbl := Table{ASCII: true}
+	if output := tbl.indentText(1, "Pod/web-1"); output != "`-Pod/web-1" {
+		t.Errorf("Output %q not equal to expected %q", output, "`-Pod/web-1")
+	}
+
+}
+
+// *****************
+// metadata
+// *****************
+func TestMetadata(t *testing.T) {
+	tbl := Table{}
+	tbl.SetHeader("NAME", "RESTARTS")
+	for _, name := range []string{"web", "api", "cache", "proxy"} {
+		tbl.AddRow(NewCellText(name), NewCellInt("0", 0))
+	}
+	// rows removed by --match are never added to the table
+	tbl.filteredRows = 3
+	// rows hidden by --oddities stay in the table
+	tbl.HideRows([]int{1, 2})
+
+	expected := tableMetadata{Total: 7, Shown: 2, Hidden: 5, Filters: map[string]string{"match": "restarts>0"}}
+	if output := tbl.metadata(map[string]string{"match": "restarts>0"}); !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %+v not equal to expected %+v", output, expected)
+	}
+
+	// filters are always an object in the json so scripts dont need to check for null
+	if output := tbl.metadata(nil); output.Filters == nil {
+		t.Errorf("Output filters should not be nil")
+	}
+
+}
+
+// *****************
+// jsonString
+// *****************
+type jsonStringTest struct {
+	text     string
+	expected string
+}
+
+var jsonStringTests = []jsonStringTest{
+	{"Running", `"Running"`},
+	{"", `""`},
+	{`exec "/app" failed`, `"exec \"/app\" failed"`},
+	{"line1\nline2", `"line1\nline2"`},
+	{"a<b&c>d", `"a<b&c>d"`},
+}
+
+func TestJsonString(t *testing.T) {
+
+	for _, test := range jsonStringTests {
+		if output := jsonString(test.text); output != test.expected {
+			t.Errorf("Output %s not equal to expected %s", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// HighlightAbove
+// *****************
+type highlightAboveTest struct {
+	colourOutput int
+	withMarker   bool
+	expected     []string
+}
+
+var highlightAboveTests = []highlightAboveTest{
+	{COLOUR_NONE, true, []string{"0", "2", "5*"}},
+	{COLOUR_COLUMNS, true, []string{"0", "2", "5*"}},
+	// the colour is shown so the marker isnt needed
+	{COLOUR_ERRORS, true, []string{"0", "2", "5"}},
+	{COLOUR_NONE, false, []string{"0", "2", "5"}},
+}
+
+func TestHighlightAbove(t *testing.T) {
+
+	for _, test := range highlightAboveTests {
+		tbl := Table{ColourOutput: test.colourOutput}
+		tbl.SetHeader("CONTAINER", "RESTARTS")
+		for i, restarts := range []int64{0, 2, 5} {
+			tbl.AddRow(NewCellText(fmt.Sprintf("c%d", i)), NewCellInt(fmt.Sprintf("%d", restarts), restarts))
+		}
+
+		if err := tbl.HighlightAbove("RESTARTS", 2, test.withMarker); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		for i, row := range tbl.GetRows() {
+			if row[1].text != test.expected[i] {
+				t.Errorf("Output %q not equal to expected %q", row[1].text, test.expected[i])
+			}
+			if highlighted := row[1].colour == colourBad; highlighted != (row[1].number > 2) {
+				t.Errorf("Output row %d highlighted %t, expected %t", i, highlighted, row[1].number > 2)
+			}
+		}
+	}
+
+	tbl := Table{}
+	tbl.SetHeader("CONTAINER")
+	if err := tbl.HighlightAbove("RESTARTS", 2, true); err == nil {
+		t.Errorf("expected an error for a missing column")
+	}
+
+}
+
+// *****************
+// ListOutOfRange
+// *****************
+type listOutOfRangeTest struct {
+	values   []int64
+	expected []int // the rows inside the range
+}
+
+var listOutOfRangeTests = []listOutOfRangeTest{
+	// q1 0 and q3 2 give a range of -3 to 5
+	{[]int64{0, 1, 1, 2, 1, 0, 42}, []int{0, 1, 2, 3, 4, 5}},
+	// q1 10 and q3 12 give a range of 7 to 15
+	{[]int64{10, 11, 12, 11, 1, 10, 12}, []int{0, 1, 2, 3, 5, 6}},
+}
+
+func TestListOutOfRange(t *testing.T) {
+
+	for _, test := range listOutOfRangeTests {
+		tbl := Table{}
+		tbl.SetHeader("CONTAINER", "RESTARTS")
+		for i, restarts := range test.values {
+			tbl.AddRow(NewCellText(fmt.Sprintf("c%d", i)), NewCellInt(fmt.Sprintf("%d", restarts), restarts))
+		}
+
+		output, _, err := tbl.ListOutOfRange(1)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v for %v", output, test.expected, test.values)
+		}
+	}
+
+}
+
+// *****************
+// reflowLines
+// *****************
+func TestReflowLines(t *testing.T) {
+	tbl := Table{}
+	tbl.SetHeader("CONTAINER", "MESSAGE")
+	tbl.ReflowColumns("MESSAGE")
+	message := "back-off 5m0s restarting failed container because the database at postgres.default.svc.cluster.local:5432 refused the connection"
+	tbl.AddRow(NewCellText("web"), NewCellText(message))
+	tbl.AddRow(NewCellText("proxy"), NewCellText(""))
+
+	expected := []string{
+		"    MESSAGE: back-off 5m0s restarting failed container because the database at",
+		"             postgres.default.svc.cluster.local:5432 refused the connection",
+	}
+	if output := tbl.reflowLines(tbl.GetRows()[0]); !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %q not equal to expected %q", output, expected)
+	}
+
+	// empty messages dont add a line under the row
+	if output := tbl.reflowLines(tbl.GetRows()[1]); len(output) != 0 {
+		t.Errorf("Output %q should be empty", output)
+	}
+
+}
+
+// *****************
+// SplitRows
+// *****************
+func TestSplitRows(t *testing.T) {
+	tbl := Table{}
+	tbl.SetHeader("NAMESPACE", "PODNAME", "CONTAINER", "RESTARTS")
+	tbl.AddRow(NewCellText("default"), NewCellText("web-1"), NewCellText("web"), NewCellInt("1", 1))
+	tbl.AddRow(NewCellText("default"), NewCellText("web-2"), NewCellText("web"), NewCellInt("5", 5))
+	tbl.AddRow(NewCellText("default"), NewCellText("web-1"), NewCellText("istio-proxy"), NewCellInt("7", 7))
+	tbl.AddRow(NewCellText("kube-system"), NewCellText("dns"), NewCellText("coredns"), NewCellInt("0", 0))
+	if err := tbl.SortByNames("!RESTARTS"); err != nil {
+		t.Fatal(err)
+	}
+	// a pod with every row hidden doesnt get a table
+	tbl.HideRows([]int{3})
+
+	valueList, tableList, err := tbl.SplitRows("NAMESPACE", "PODNAME")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expectedValues := [][]string{{"default", "web-1"}, {"default", "web-2"}}
+	if !reflect.DeepEqual(valueList, expectedValues) {
+		t.Errorf("Values %v not equal to expected %v", valueList, expectedValues)
+	}
+
+	expectedRows := [][]string{{"istio-proxy", "web"}, {"web"}}
+	for i, part := range tableList {
+		var containers []string
+		for _, row := range part.getVisibleRows() {
+			containers = append(containers, row[2].text)
+		}
+		if !reflect.DeepEqual(containers, expectedRows[i]) {
+			t.Errorf("table %d: rows %v not equal to expected %v", i, containers, expectedRows[i])
+		}
+	}
+
+	// the column widths only cover the rows in the group
+	if width := tableList[1].head[2].columnLength; width != len("CONTAINER")+2 {
+		t.Errorf("column width %d not equal to expected %d", width, len("CONTAINER")+2)
+	}
+
+	if _, _, err := tbl.SplitRows("MISSING"); err == nil {
+		t.Error("expected an error for a missing column")
+	}
+
+}
+
+// *****************
+// explainOutOfRange
+// *****************
+func TestExplainOutOfRange(t *testing.T) {
+	tbl := Table{}
+	tbl.SetHeader("PODNAME", "CONTAINER", "RESTARTS")
+	for i, restarts := range []int64{0, 1, 1, 2, 1, 0, 42} {
+		tbl.AddRow(NewCellText(fmt.Sprintf("api-%d", i)), NewCellText("api"), NewCellInt(fmt.Sprint(restarts), restarts))
+	}
+
+	row2Remove, stats, err := tbl.ListOutOfRange(2)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	tbl.HideRows(row2Remove)
+
+	expected := []string{"api-6/api: RESTARTS=42 outside expected -3 to 5 (q1 0, q3 2)"}
+	if output := tbl.explainOutOfRange(stats); !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %q not equal to expected %q", output, expected)
+	}
+
+}
+
+// *****************
+// ResolvePlaceHolders
+// *****************
+func TestResolvePlaceHolders(t *testing.T) {
+	tbl := Table{}
+	tbl.SetHeader("NAME", "RESTARTS")
+	podRow := tbl.AddPlaceHolderRow()
+	tbl.AddRow(NewCellText("Container/web"), NewCellInt("3", 3))
+	tbl.AddRow(NewCellText("Container/proxy"), NewCellInt("1", 1))
+	tbl.UpdatePlaceHolderRow(podRow, []Cell{NewCellText("Pod/web-1"), NewCellInt("4", 4)})
+	// a placeholder that was never filled in is left alone
+	tbl.AddPlaceHolderRow()
+
+	tbl.ResolvePlaceHolders()
+	if err := tbl.SortByNames("!RESTARTS"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"Pod/web-1", "Container/web", "Container/proxy", ""}
+	var output []string
+	for _, row := range tbl.getVisibleRows() {
+		output = append(output, row[0].text)
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %q not equal to expected %q", output, expected)
+	}
+
+}
+
+// *****************
+// sorted json and yaml
+// *****************
+func TestPrintSortedRecords(t *testing.T) {
+	out := bytes.Buffer{}
+	tbl := Table{Out: &out}
+	tbl.SetHeader("CONTAINER", "RESTARTS")
+	tbl.AddRow(NewCellText("web"), NewCellInt("1", 1))
+	tbl.AddRow(NewCellText("proxy"), NewCellInt("7", 7))
+	tbl.AddRow(NewCellText("init"), NewCellInt("3", 3))
+	if err := tbl.SortByNames("!RESTARTS"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tbl.PrintJson(); err != nil {
+		t.Fatal(err)
+	}
+	var output struct {
+		Data []map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &output); err != nil {
+		t.Fatalf("invalid json %v: %s", err, out.String())
+	}
+	var containers []string
+	for _, record := range output.Data {
+		containers = append(containers, record["CONTAINER"])
+	}
+	expected := []string{"proxy", "init", "web"}
+	if !reflect.DeepEqual(containers, expected) {
+		t.Errorf("json order %v not equal to expected %v", containers, expected)
+	}
+
+	out.Reset()
+	tbl.PrintYaml()
+	expectedYaml := "data:\n" +
+		"- CONTAINER: \"proxy\"\n  RESTARTS: \"7\"\n" +
+		"- CONTAINER: \"init\"\n  RESTARTS: \"3\"\n" +
+		"- CONTAINER: \"web\"\n  RESTARTS: \"1\"\n"
+	if out.String() != expectedYaml {
+		t.Errorf("yaml %q not equal to expected %q", out.String(), expectedYaml)
+	}
+
+}
+
+// *****************
+// wide character widths
+// *****************
+type textWidthTest struct {
+	text     string
+	expected int
+}
+
+var textWidthTests = []textWidthTest{
+	{"", 0},
+	{"CrashLoopBackOff", 16},
+	{"é", 1},
+	{"é", 1},
+	{"容器", 4},
+	{"ｗｅｂ", 6},
+	{"ok 🚀", 5},
+}
+
+func TestTextWidth(t *testing.T) {
+	for _, test := range textWidthTests {
+		if output := textWidth(test.text); output != test.expected {
+			t.Errorf("Output %d not equal to expected %d for %q", output, test.expected, test.text)
+		}
+	}
+
+	out := bytes.Buffer{}
+	tbl := Table{Out: &out}
+	tbl.SetHeader("MESSAGE", "STATE")
+	tbl.AddRow(NewCellText("容器が起動しました"), NewCellText("Running"))
+	tbl.AddRow(NewCellText("started"), NewCellText("Running"))
+	tbl.Print()
+
+	// the STATE column should start at the same terminal column on every line
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	for _, line := range lines[1:] {
+		position := textWidth(line[:strings.Index(line, "Running")])
+		if expected := strings.Index(lines[0], "STATE"); position != expected {
+			t.Errorf("STATE starts at column %d not equal to expected %d in %q", position, expected, line)
+		}
+	}
+
+}
+
+// *****************
+// RemoveLastRow
+// *****************
+func TestRemoveLastRow(t *testing.T) {
+	tbl := Table{}
+	tbl.SetHeader("NAME")
+	tbl.AddRow(NewCellText("Pod/web-1"))
+	tbl.AddRow(NewCellText("Container/web"))
+
+	row := tbl.RemoveLastRow()
+	if row[0].text != "Container/web" {
+		t.Errorf("Output %s not equal to expected Container/web", row[0].text)
+	}
+
+	tbl.AddRow(NewCellText("Pod/web-2"))
+	var output []string
+	for _, row := range tbl.getVisibleRows() {
+		output = append(output, row[0].text)
+	}
+	expected := []string{"Pod/web-1", "Pod/web-2"}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Output %v not equal to expected %v", output, expected)
+	}
+
+}
+
+// *****************
+// json hidden rows
+// *****************
+func TestPrintJsonHiddenRows(t *testing.T) {
+	out := bytes.Buffer{}
+	tbl := Table{Out: &out}
+	tbl.SetHeader("PODNAME", "CONTAINER", "RESTARTS")
+	tbl.AddRow(NewCellText("web-1"), NewCellText("web"), NewCellInt("0", 0))
+	tbl.AddRow(NewCellText("web-1"), NewCellText("proxy"), NewCellInt("9", 9))
+	tbl.AddRow(NewCellText("web-2"), NewCellText("web"), NewCellInt("0", 0))
+	tbl.AddRow(NewCellText("web-2"), NewCellText("proxy"), NewCellInt("0", 0))
+	tbl.AddRow(NewCellText("web-3"), NewCellText("web"), NewCellInt("12", 12))
+	// --oddities hides every row that is in range, leaving the two outliers
+	tbl.HideRows([]int{0, 2, 3})
+
+	if err := tbl.PrintJson(); err != nil {
+		t.Fatal(err)
+	}
+	var output struct {
+		Data []map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &output); err != nil {
+		t.Fatalf("invalid json %v: %s", err, out.String())
+	}
+	var rows []string
+	for _, record := range output.Data {
+		rows = append(rows, record["PODNAME"]+"/"+record["CONTAINER"])
+	}
+	expected := []string{"web-1/proxy", "web-3/web"}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("json rows %v not equal to expected %v", rows, expected)
+	}
+
+	// a pod with all of its rows hidden is left out of the nested json
+	out.Reset()
+	if err := tbl.PrintJsonNested("containers", "PODNAME"); err != nil {
+		t.Fatal(err)
+	}
+	var nested struct {
+		Data []struct {
+			Podname    string              `json:"PODNAME"`
+			Containers []map[string]string `json:"containers"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &nested); err != nil {
+		t.Fatalf("invalid json %v: %s", err, out.String())
+	}
+	rows = []string{}
+	for _, pod := range nested.Data {
+		for _, record := range pod.Containers {
+			rows = append(rows, pod.Podname+"/"+record["CONTAINER"])
+		}
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("nested json rows %v not equal to expected %v", rows, expected)
+	}
+
+}
+
+// *****************
+// yaml, list and csv hidden rows
+// *****************
+type printHiddenRowsTest struct {
+	outputAs string
+	expected string
+}
+
+var printHiddenRowsTests = []printHiddenRowsTest{
+	{"yaml", "data:\n- PODNAME: \"web-1\"\n  CONTAINER: \"proxy\"\n- PODNAME: \"web-3\"\n  CONTAINER: \"web\"\n"},
+	{"list", "PODNAME: web-1\nCONTAINER: proxy\nPODNAME: web-3\nCONTAINER: web\n"},
+	{"csv", "\"PODNAME\", \"CONTAINER\"\n\"web-1\", \"proxy\"\n\"web-3\", \"web\"\n"},
+}
+
+func TestPrintHiddenRows(t *testing.T) {
+	out := bytes.Buffer{}
+	tbl := Table{Out: &out}
+	tbl.SetHeader("PODNAME", "CONTAINER")
+	tbl.AddRow(NewCellText("web-1"), NewCellText("web"))
+	tbl.AddRow(NewCellText("web-1"), NewCellText("proxy"))
+	tbl.AddRow(NewCellText("web-2"), NewCellText("web"))
+	tbl.AddRow(NewCellText("web-3"), NewCellText("web"))
+	tbl.HideRows([]int{0, 2})
+
+	for _, test := range printHiddenRowsTests {
+		out.Reset()
+		switch test.outputAs {
+		case "yaml":
+			tbl.PrintYaml()
+		case "list":
+			tbl.PrintList()
+		case "csv":
+			tbl.PrintCsv()
+		}
+		if out.String() != test.expected {
+			t.Errorf("%s output %q not equal to expected %q", test.outputAs, out.String(), test.expected)
+		}
+	}
+
+}
+
+// *****************
+// print without the header
+// *****************
+func TestPrintNoHeader(t *testing.T) {
+	out := bytes.Buffer{}
+	tbl := Table{Out: &out, ColourOutput: COLOUR_NONE, NoHeader: true}
+	tbl.SetHeader("T", "CONTAINER", "RESTARTS", "MESSAGE")
+	tbl.AddRow(NewCellText("C"), NewCellText("a-very-long-container-name"), NewCellInt("0", 0), NewCellText(""))
+	tbl.AddRow(NewCellText("C"), NewCellText("web"), NewCellInt("12", 12), NewCellText(""))
+	tbl.HideRows([]int{0})
+	tbl.HideColumn(0)
+
+	// the hidden row and the header titles dont widen the columns
+	tbl.Print()
+	if expected := "web  12  -\n"; out.String() != expected {
+		t.Errorf("Output %q not equal to expected %q", out.String(), expected)
+	}
+
+	out.Reset()
+	tbl.PrintCsv()
+	if strings.Contains(out.String(), "CONTAINER") {
+		t.Errorf("Output %q should not contain the header", out.String())
+	}
+
+	if output := countLine(tbl); output != "1 container" {
+		t.Errorf("Output %q not equal to expected %q", output, "1 container")
+	}
+	tbl.hideRow[0] = false
+	if output := countLine(tbl); output != "2 containers" {
+		t.Errorf("Output %q not equal to expected %q", output, "2 containers")
+	}
+
+}
+
+// *****************
+// yaml escaping
+// *****************
+var printYamlEscapeTests = []string{
+	"plain",
+	`back-off "restarting" failed container`,
+	`C:\data\logs`,
+	"key: value, other: {x}",
+	"- [list] # comment",
+	"",
+}
+
+func TestPrintYamlEscape(t *testing.T) {
+
+	for _, layout := range []int{LAYOUT_DEFAULT, LAYOUT_COMPACT} {
+		for _, message := range printYamlEscapeTests {
+			out := bytes.Buffer{}
+			tbl := Table{Out: &out, Layout: layout}
+			tbl.SetHeader("CONTAINER", "MESSAGE")
+			tbl.AddRow(NewCellText("web"), NewCellText(message))
+			tbl.PrintYaml()
+
+			var output struct {
+				Data []map[string]string `json:"data"`
+			}
+			if err := yaml.Unmarshal(out.Bytes(), &output); err != nil {
+				t.Fatalf("invalid yaml %v: %s", err, out.String())
+			}
+			if len(output.Data) != 1 || output.Data[0]["MESSAGE"] != message {
+				t.Errorf("Output %v not equal to expected %q (layout %d)", output.Data, message, layout)
+			}
+		}
+	}
+
+}
+
+// *****************
+// print styles
+// *****************
+type printStyleTest struct {
+	style    int
+	expected string
+}
+
+var printStyleTests = []printStyleTest{
+	{STYLE_DEFAULT, "CONTAINER    RESTARTS  MESSAGE\nweb          12        -\na-long-name  0         back-off\n"},
+	// a single space between columns
+	{STYLE_COMPACT, "CONTAINER   RESTARTS MESSAGE\nweb         12       -\na-long-name 0        back-off\n"},
+	{STYLE_BOX, `┌─────────────┬──────────┬──────────┐
+│ CONTAINER   │ RESTARTS │ MESSAGE  │
+├─────────────┼──────────┼──────────┤
+│ web         │ 12       │ -        │
+│ a-long-name │ 0        │ back-off │
+└─────────────┴──────────┴──────────┘
+`},
+}
+
+func TestPrintStyle(t *testing.T) {
+
+	for _, test := range printStyleTests {
+		out := bytes.Buffer{}
+		tbl := Table{Out: &out, ColourOutput: COLOUR_NONE, Style: test.style}
+		tbl.SetHeader("CONTAINER", "RESTARTS", "MESSAGE")
+		tbl.AddRow(NewCellText("web"), NewCellInt("12", 12), NewCellText(""))
+		tbl.AddRow(NewCellText("a-long-name"), NewCellInt("0", 0), NewCellText("back-off"))
+
+		tbl.Print()
+		if out.String() != test.expected {
+			t.Errorf("Output %q not equal to expected %q for style %d", out.String(), test.expected, test.style)
+		}
+	}
+
+}
+
+// *****************
+// header alias
+// *****************
+type headerAliasTest struct {
+	alias    map[string]string
+	expected string
+	err      bool
+}
+
+var headerAliasTests = []headerAliasTest{
+	{map[string]string{}, "CONTAINER  RESTARTS\nweb        12\nproxy      0\n", false},
+	{map[string]string{"RESTARTS": "RST", "CONTAINER": "C"}, "C      RST\nweb    12\nproxy  0\n", false},
+	// the alias cant be used in place of the column name
+	{map[string]string{"RST": "R"}, "", true},
+}
+
+func TestSetHeaderAlias(t *testing.T) {
+
+	for _, test := range headerAliasTests {
+		out := bytes.Buffer{}
+		tbl := Table{Out: &out, ColourOutput: COLOUR_NONE}
+		tbl.SetHeader("CONTAINER", "RESTARTS")
+		tbl.AddRow(NewCellText("proxy"), NewCellInt("0", 0))
+		tbl.AddRow(NewCellText("web"), NewCellInt("12", 12))
+
+		err := tbl.SetHeaderAlias(test.alias)
+		if (err != nil) != test.err {
+			t.Errorf("Error %v not expected for %v", err, test.alias)
+		}
+		if err != nil {
+			continue
+		}
+
+		// sorting still uses the original column names
+		if err := tbl.SortByNames("!RESTARTS"); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		tbl.Print()
+		if out.String() != test.expected {
+			t.Errorf("Output %q not equal to expected %q", out.String(), test.expected)
+		}
+	}
+
+}
+
+// *****************
+// json nested
+// *****************
+type printJsonNestedTest struct {
+	podColumns  []string
+	expected    string
+	expectError bool
+}
+
+var printJsonNestedTests = []printJsonNestedTest{
+	{[]string{"NAMESPACE", "PODNAME"}, `{"data":[` +
+		`{"NAMESPACE":"default","PODNAME":"web-1","containers":[{"CONTAINER":"web","RESTARTS":"3"},{"CONTAINER":"proxy","RESTARTS":"0"}]},` +
+		`{"NAMESPACE":"default","PODNAME":"web-2","containers":[{"CONTAINER":"web","RESTARTS":"1"}]}]}`, false},
+	// only the columns that are in the table are used to group the rows
+	{[]string{"PODNAME", "SCHEDULER"}, `{"data":[` +
+		`{"PODNAME":"web-1","containers":[{"NAMESPACE":"default","CONTAINER":"web","RESTARTS":"3"},{"NAMESPACE":"default","CONTAINER":"proxy","RESTARTS":"0"}]},` +
+		`{"PODNAME":"web-2","containers":[{"NAMESPACE":"default","CONTAINER":"web","RESTARTS":"1"}]}]}`, false},
+	{[]string{"SCHEDULER"}, "", true},
+}
+
+func TestPrintJsonNested(t *testing.T) {
+
+	for _, test := range printJsonNestedTests {
+		out := bytes.Buffer{}
+		tbl := Table{Out: &out}
+		tbl.SetHeader("NAMESPACE", "PODNAME", "CONTAINER", "RESTARTS")
+		tbl.AddRow(NewCellText("default"), NewCellText("web-1"), NewCellText("web"), NewCellInt("3", 3))
+		tbl.AddRow(NewCellText("default"), NewCellText("web-1"), NewCellText("proxy"), NewCellInt("0", 0))
+		tbl.AddRow(NewCellText("default"), NewCellText("web-2"), NewCellText("web"), NewCellInt("1", 1))
+		// hidden rows are left out of their pod
+		tbl.AddRow(NewCellText("default"), NewCellText("web-2"), NewCellText("proxy"), NewCellInt("0", 0))
+		tbl.HideRows([]int{3})
+
+		err := tbl.PrintJsonNested("containers", test.podColumns...)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error for %v", test.podColumns)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		output := bytes.Buffer{}
+		if err := json.Compact(&output, out.Bytes()); err != nil {
+			t.Fatalf("invalid json %v: %s", err, out.String())
+		}
+		if output.String() != test.expected {
+			t.Errorf("Output %s not equal to expected %s", output.String(), test.expected)
+		}
+	}
+
+}
+
+// *****************
+// print styles with reflow
+// *****************
+var printStyleReflowTests = []printStyleTest{
+	{STYLE_COMPACT, "CONTAINER RESTARTS\nweb       12\n    MESSAGE: back-off restarting failed container\nproxy     0\n"},
+	// the box is widened to keep the message inside it
+	{STYLE_BOX, `┌───────────┬───────────────────────────────────────┐
+│ CONTAINER │ RESTARTS                              │
+├───────────┼───────────────────────────────────────┤
+│ web       │ 12                                    │
+│     MESSAGE: back-off restarting failed container │
+│ proxy     │ 0                                     │
+└───────────┴───────────────────────────────────────┘
+`},
+}
+
+func TestPrintStyleReflow(t *testing.T) {
+
+	for _, test := range printStyleReflowTests {
+		out := bytes.Buffer{}
+		tbl := Table{Out: &out, ColourOutput: COLOUR_NONE, Style: test.style}
+		tbl.SetHeader("CONTAINER", "RESTARTS", "MESSAGE")
+		tbl.AddRow(NewCellText("web"), NewCellInt("12", 12), NewCellText("back-off restarting failed container"))
+		tbl.AddRow(NewCellText("proxy"), NewCellInt("0", 0), NewCellText(""))
+		tbl.ReflowColumns("MESSAGE")
+
+		tbl.Print()
+		if out.String() != test.expected {
+			t.Errorf("Output %q not equal to expected %q for style %d", out.String(), test.expected, test.style)
+		}
+	}
+
+}
+
+// *****************
+// box style with ascii
+// *****************
+func TestPrintStyleASCII(t *testing.T) {
+	out := bytes.Buffer{}
+	tbl := Table{Out: &out, ColourOutput: COLOUR_NONE, Style: STYLE_BOX, ASCII: true}
+	tbl.SetHeader("CONTAINER", "RESTARTS")
+	tbl.AddRow(NewCellText("web"), NewCellInt("12", 12))
+
+	tbl.Print()
+	expected := `+-----------+----------+
+| CONTAINER | RESTARTS |
++-----------+----------+
+| web       | 12       |
++-----------+----------+
+`
+	if out.String() != expected {
+		t.Errorf("Output %q not equal to expected %q", out.String(), expected)
+	}
+
+}
@@ -0,0 +1,228 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var topologyShort = "List the zone and region of the node each pod is running on"
+
+var topologyDescription = ` Prints the topology.kubernetes.io/zone and topology.kubernetes.io/region labels of the node each pod
+is scheduled on, useful for checking that replicas are spread across zones. Pods are grouped into
+workloads by their name with the generated suffix removed, SAME-ZONE is the number of pods from the
+same workload in the pods zone and SKEW is how many more that is than an even spread across the zones
+in use. Use --oddities to only show the pods that are out of line with the rest.
+If no name is specified the topology of all pods in the current namespace are shown.`
+
+var topologyExample = `  # List the zone and region of each pod
+  %[1]s topology
+
+  # List the zone and region of each pod output in JSON format
+  %[1]s topology -o json
+
+  # List the zone and region of a single pod
+  %[1]s topology my-pod-4jh36
+
+  # List only the pods that are in a zone with more replicas than the others
+  %[1]s topology --oddities
+
+  # List the zone and region of all pods where label app matches web
+  %[1]s topology -l app=web`
+
+const labelTopologyZone = "topology.kubernetes.io/zone"
+const labelTopologyRegion = "topology.kubernetes.io/region"
+
+func Topology(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args []string) error {
+	log := logger{location: "Topology"}
+	log.Debug("Start")
+
+	loopinfo := topology{}
+	builder := RowBuilder{}
+	builder.DontListContainers = true
+	builder.PodName = args
+
+	connect := Connector{}
+	if err := connect.LoadConfig(kubeFlags); err != nil {
+		return err
+	}
+
+	commonFlagList, err := processCommonFlags(cmd)
+	if err != nil {
+		return err
+	}
+	// the zone means little without the node it came from
+	commonFlagList.showNodeName = true
+	connect.Flags = commonFlagList
+	builder.Connection = &connect
+	builder.SetFlagsFrom(commonFlagList)
+
+	// we need the connection to look up the labels of each node
+	loopinfo.Connection = &connect
+
+	table := Table{}
+	table.ColourOutput = commonFlagList.outputAsColour
+	table.CustomColours = commonFlagList.useTheseColours
+
+	builder.Table = &table
+	builder.ShowTreeView = commonFlagList.showTreeView
+
+	if err := builder.Build(&loopinfo); err != nil {
+		return err
+	}
+
+	if err := table.SortByNames(commonFlagList.sortList...); err != nil {
+		return err
+	}
+
+	// do we need to find the outliers, we have enough data to compute a range
+	if commonFlagList.showOddities {
+		if err := hideInRangeRows(&table, builder.DefaultHeaderLen+3, commonFlagList); err != nil { // 3 = skew column
+			return err
+		}
+	}
+
+	return outputTableAs(table, commonFlagList)
+
+}
+
+type topology struct {
+	Connection *Connector
+
+	podList     []v1.Pod               // every pod being built, set by SetPodList so pods from a file or stdin are included
+	podTopology map[string]podTopology // worked out for every pod on the first call to BuildPodRow
+}
+
+// podTopology holds where a pod is running along with how evenly its workload is spread
+type podTopology struct {
+	zone     string
+	region   string
+	sameZone int // pods from the same workload in this zone, including this pod
+	skew     int // sameZone minus the number of pods each zone would have if the workload was spread evenly
+}
+
+// SetPodList keeps the pods the builder loaded so the spread is worked out from the same pods as the rows
+func (s *topology) SetPodList(podList []v1.Pod) {
+	s.podList = podList
+}
+
+func (s *topology) Headers() []string {
+	return []string{
+		"ZONE", "REGION", "SAME-ZONE", "SKEW",
+	}
+}
+
+func (s *topology) BuildContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *topology) BuildEphemeralContainerStatus(container v1.ContainerStatus, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *topology) HideColumns(info BuilderInformation) []int {
+	return []int{}
+}
+
+func (s *topology) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, error) {
+	out := []Cell{
+		NewCellText(""),
+		NewCellText(""),
+		NewCellInt("", 0),
+		NewCellInt("", 0),
+	}
+	return out, nil
+}
+
+func (s *topology) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *topology) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
+	return [][]Cell{}, nil
+}
+
+func (s *topology) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
+	if s.podTopology == nil {
+		nodeLabels, err := s.Connection.GetNodeLabels(s.podList)
+		if err != nil {
+			return [][]Cell{}, err
+		}
+		s.podTopology = topologySpread(s.podList, nodeLabels)
+	}
+
+	return [][]Cell{s.topologyBuildRow(s.podTopology[pod.Namespace+"/"+pod.Name])}, nil
+}
+
+func (s *topology) topologyBuildRow(spread podTopology) []Cell {
+	skewColour := colourOk
+	if spread.skew > 0 {
+		skewColour = colourBad
+	}
+
+	sameZone := ""
+	skew := ""
+	// pods that arent scheduled or are on nodes without a zone label cant be counted
+	if len(spread.zone) > 0 {
+		sameZone = fmt.Sprintf("%d", spread.sameZone)
+		skew = fmt.Sprintf("%d", spread.skew)
+	}
+
+	return []Cell{
+		NewCellText(spread.zone),
+		NewCellText(spread.region),
+		NewCellInt(sameZone, int64(spread.sameZone)),
+		NewCellColourInt(skewColour, skew, int64(spread.skew)),
+	}
+}
+
+// topologySpread works out the zone and region of every pod from its nodes labels, pods are grouped into workloads by
+//
+//	namespace and podNamePrefix so the number of replicas in each zone can be compared with an even spread across
+//	every zone the pods are running in. the result is keyed by namespace/podname
+func topologySpread(podList []v1.Pod, nodeLabels map[string]map[string]string) map[string]podTopology {
+	out := make(map[string]podTopology)
+	zones := make(map[string]bool)
+	workloadSize := make(map[string]int)
+	zoneCount := make(map[string]int)
+
+	for _, pod := range podList {
+		labels := nodeLabels[pod.Spec.NodeName]
+		spread := podTopology{
+			zone:   labels[labelTopologyZone],
+			region: labels[labelTopologyRegion],
+		}
+		out[pod.Namespace+"/"+pod.Name] = spread
+
+		if len(spread.zone) == 0 {
+			continue
+		}
+		workload := pod.Namespace + "/" + podNamePrefix(pod)
+		zones[spread.zone] = true
+		workloadSize[workload]++
+		zoneCount[workload+"/"+spread.zone]++
+	}
+
+	for _, pod := range podList {
+		key := pod.Namespace + "/" + pod.Name
+		spread := out[key]
+		if len(spread.zone) == 0 {
+			continue
+		}
+
+		workload := pod.Namespace + "/" + podNamePrefix(pod)
+		// the most pods each zone should have when spread evenly, rounded up as replicas cant be split
+		even := (workloadSize[workload] + len(zones) - 1) / len(zones)
+
+		spread.sameZone = zoneCount[workload+"/"+spread.zone]
+		spread.skew = spread.sameZone - even
+		if spread.skew < 0 {
+			spread.skew = 0
+		}
+		out[key] = spread
+	}
+
+	return out
+}
@@ -0,0 +1,113 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// *****************
+// topologySpread
+// *****************
+type topologySpreadTest struct {
+	podName  string
+	zone     string
+	sameZone int
+	skew     int
+}
+
+var topologySpreadTests = []topologySpreadTest{
+	// three web replicas across two zones, one zone has two which is an even spread
+	{"web-7d4b9c-aaaaa", "zone-a", 2, 0},
+	{"web-7d4b9c-bbbbb", "zone-a", 2, 0},
+	{"web-7d4b9c-ccccc", "zone-b", 1, 0},
+	// all api replicas landed in one zone
+	{"api-5f6c7d-aaaaa", "zone-a", 3, 1},
+	{"api-5f6c7d-bbbbb", "zone-a", 3, 1},
+	{"api-5f6c7d-ccccc", "zone-a", 3, 1},
+	// a node without a zone label isnt counted
+	{"db-0", "", 0, 0},
+}
+
+func TestTopologySpread(t *testing.T) {
+	nodeLabels := map[string]map[string]string{
+		"node-a": {labelTopologyZone: "zone-a", labelTopologyRegion: "eu-west"},
+		"node-b": {labelTopologyZone: "zone-b", labelTopologyRegion: "eu-west"},
+		"node-c": {},
+	}
+	nodeForZone := map[string]string{"zone-a": "node-a", "zone-b": "node-b", "": "node-c"}
+
+	podList := []v1.Pod{}
+	for _, test := range topologySpreadTests {
+		pod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: test.podName, Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: nodeForZone[test.zone]},
+		}
+		if test.podName != "db-0" {
+			pod.GenerateName = test.podName[:len(test.podName)-5]
+			pod.Labels = map[string]string{"pod-template-hash": test.podName[4 : len(test.podName)-6]}
+		}
+		podList = append(podList, pod)
+	}
+
+	spread := topologySpread(podList, nodeLabels)
+
+	for _, test := range topologySpreadTests {
+		output := spread["default/"+test.podName]
+		if output.zone != test.zone {
+			t.Errorf("Output zone %q not equal to expected %q for %s", output.zone, test.zone, test.podName)
+		}
+		if output.sameZone != test.sameZone || output.skew != test.skew {
+			t.Errorf("Output %d/%d not equal to expected %d/%d for %s", output.sameZone, output.skew, test.sameZone, test.skew, test.podName)
+		}
+	}
+
+}
+
+// *****************
+// topology from a file
+// *****************
+type topologyFileTest struct {
+	podName  string
+	zone     string
+	region   string
+	sameZone string
+}
+
+var topologyFileTests = []topologyFileTest{
+	{"web-1", "zone-a", "eu-west", "2"},
+	{"web-2", "zone-a", "eu-west", "2"},
+	{"web-3", "zone-b", "eu-west", "1"},
+}
+
+func TestTopologyFromFile(t *testing.T) {
+	pods := ""
+	for _, test := range topologyFileTests {
+		node := "node-a"
+		if test.zone == "zone-b" {
+			node = "node-b"
+		}
+		pods += "---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: " + test.podName + "\n  namespace: default\n  generateName: web-\nspec:\n  nodeName: " + node + "\n  containers:\n  - name: web\n"
+	}
+
+	// the nodes are cached in the connector so no api server is needed
+	connect := Connector{nodeList: map[string]v1.Node{
+		"node-a": {ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{labelTopologyZone: "zone-a", labelTopologyRegion: "eu-west"}}},
+		"node-b": {ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{labelTopologyZone: "zone-b", labelTopologyRegion: "eu-west"}}},
+	}}
+	loop := topology{Connection: &connect}
+	tbl, _ := buildTestTable(t, RowBuilder{DontListContainers: true}, &loop, commonFlags{}, pods)
+
+	for i, test := range topologyFileTests {
+		// the first row holds the headers
+		row := tbl.data[i+1]
+		output := []string{row[len(row)-4].text, row[len(row)-3].text, row[len(row)-2].text}
+		expected := []string{test.zone, test.region, test.sameZone}
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("Output %v not equal to expected %v for %s", output, expected, test.podName)
+		}
+	}
+
+}
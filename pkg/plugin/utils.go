@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -18,23 +21,138 @@ var colourBad = [2]int{31, 0}
 var colourOk = [2]int{32, 0}
 var colourWarn = [2]int{33, 0}
 
-// always returns false if the flagList.container is empty as we expect to show all containers
-// returns true if we dont have a match
+// always returns false if both flagList.container and flagList.excludeContainer are empty as we expect to show all containers
+// returns true if we dont have a match or the container has been excluded
 func skipContainerName(flagList commonFlags, containerName string) bool {
 	log := logger{location: "Resource"}
 	log.Debug("Start")
 
-	if len(flagList.container) == 0 {
+	if len(flagList.container) > 0 {
+		found := false
+		for _, name := range flagList.container {
+			if name == containerName {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			log.Debug("skipping -", containerName)
+			return true
+		}
+	}
+
+	for _, name := range flagList.excludeContainer {
+		if name == containerName {
+			log.Debug("excluding -", containerName)
+			return true
+		}
+	}
+
+	return false
+
+}
+
+// skipContainer returns true when the container is left out by the container name flags or its image from the pod
+//
+//	spec doesnt match --image-filter and --image-tag
+func skipContainer(flagList commonFlags, pod v1.Pod, containerName string) bool {
+	if skipContainerName(flagList, containerName) {
+		return true
+	}
+
+	if len(flagList.imageFilter) == 0 && len(flagList.imageTag) == 0 {
 		return false
 	}
 
-	if flagList.container == containerName {
+	return skipContainerImage(flagList, podSpecImage(pod, containerName))
+}
+
+// skipContainerImage returns true when the image doesnt contain --image-filter or its tag isnt --image-tag, with
+//
+//	--image-regex both are matched as regular expressions instead
+func skipContainerImage(flagList commonFlags, imageName string) bool {
+	log := logger{location: "skipContainerImage"}
+	log.Debug("Start")
+
+	_, tag := splitImageName(imageName)
+
+	if flagList.imageRegex {
+		if flagList.imageFilterRegex != nil && !flagList.imageFilterRegex.MatchString(imageName) {
+			log.Debug("skipping image -", imageName)
+			return true
+		}
+		if flagList.imageTagRegex != nil && !flagList.imageTagRegex.MatchString(tag) {
+			log.Debug("skipping tag -", tag)
+			return true
+		}
 		return false
 	}
 
-	log.Debug("skipping -", containerName)
-	return true
+	if len(flagList.imageFilter) > 0 && !strings.Contains(imageName, flagList.imageFilter) {
+		log.Debug("skipping image -", imageName)
+		return true
+	}
+	if len(flagList.imageTag) > 0 && tag != flagList.imageTag {
+		log.Debug("skipping tag -", tag)
+		return true
+	}
+
+	return false
+}
+
+// podSpecImage returns the image set in the pod spec for the named container, init and ephemeral containers included
+func podSpecImage(pod v1.Pod, containerName string) string {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == containerName {
+			return container.Image
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == containerName {
+			return container.Image
+		}
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == containerName {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// splitImageName splits the image reference into the name and the tag, a port number in the registry host is left
+//
+//	as part of the name
+func splitImageName(imageName string) (string, string) {
+	name := imageName
+	tag := ""
+
+	if strings.Contains(imageName, "/") {
+		arrPath := strings.Split(imageName, "/")
+		if c := len(arrPath); c > 0 {
+			tmp := strings.Split(arrPath[c-1], ":")
+			if len(tmp) > 0 {
+				tag = strings.Join(tmp[1:], ":")
+				//calculate the uri length
+				namelen := len(imageName) - len(tag)
+				if len(tag) > 0 {
+					// check a tag was supplied so we dont cut off the last char of the image name
+					namelen--
+				}
+				name = imageName[0:namelen]
+			}
+		}
+	} else {
+		arrImage := strings.Split(imageName, ":")
+		// an image without a tag is left as the name
+		if c := len(arrImage); c > 1 {
+			tag = arrImage[c-1]
+			name = strings.Join(arrImage[:c-1], ":")
+		}
+	}
 
+	return name, tag
 }
 
 // returns a memory multiplier that matches the byteType string
@@ -87,6 +205,44 @@ func memoryHumanReadable(memorySize int64, displayAs string) string {
 	return outVal
 }
 
+// wrapText splits text into lines of at most width characters breaking on spaces, the line breaks already in the text
+//
+//	are kept and words longer than width are split
+func wrapText(text string, width int) []string {
+	var lines []string
+
+	if width < 1 {
+		width = 1
+	}
+
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			for len([]rune(word)) > width {
+				if len(line) > 0 {
+					lines = append(lines, line)
+					line = ""
+				}
+				lines = append(lines, string([]rune(word)[:width]))
+				word = string([]rune(word)[width:])
+			}
+
+			switch {
+			case len(line) == 0:
+				line = word
+			case len([]rune(line))+1+len([]rune(word)) > width:
+				lines = append(lines, line)
+				line = word
+			default:
+				line += " " + word
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
 // checks if number is NaN, always returns a valid number
 func validateFloat64(number float64) float64 {
 	if number != number {
@@ -95,23 +251,309 @@ func validateFloat64(number float64) float64 {
 	return number
 }
 
-// prints a table on the terminal of a given outType
-func outputTableAs(t Table, outType string) {
+// prints a table on the terminal of a given outType, with --error-on-empty ErrNoMatch is returned when the table has
+//
+//	no visible rows
+func outputTableAs(t Table, flags commonFlags) error {
 
-	switch outType {
+	// with --contexts the rows are printed once every context has been queried
+	if flags.contextResults != nil {
+		return flags.contextResults.add(t, flags)
+	}
+
+	if len(flags.renameColumns) > 0 {
+		if err := t.SetHeaderAlias(flags.renameColumns); err != nil {
+			return err
+		}
+	}
+
+	if flags.countOnly {
+		printCountAs(t, flags.outputAs)
+		return checkTableHasRows(t, flags)
+	}
+
+	t.Layout = flags.outputLayout
+
+	if len(flags.splitOutputDir) > 0 {
+		if err := writeSplitFiles(t, flags); err != nil {
+			return err
+		}
+		return checkTableHasRows(t, flags)
+	}
+
+	if err := printTableAs(t, flags, flags.outputAs); err != nil {
+		return err
+	}
+
+	// the count is written to stderr so the table can still be piped to other commands
+	if len(flags.outputAs) == 0 && !flags.quiet {
+		if line := countLine(t); len(line) > 0 {
+			fmt.Fprintln(os.Stderr, line)
+		}
+	}
+
+	for _, file := range flags.outputFiles {
+		if err := writeTableFile(t, flags, file); err != nil {
+			return err
+		}
+	}
+
+	return checkTableHasRows(t, flags)
+}
+
+// defaultPodColumns groups -o json-nested when the table wasnt made by the builder and so has no PodColumns
+var defaultPodColumns = []string{"CONTEXT", "NAMESPACE", "NODE", "PODNAME", "UID", "SCHEDULER"}
+
+// checkOutputVersion returns an error when the json and yaml layouts cant be printed in version, an empty version is
+//
+//	the latest. v1 is the only layout so far, json-nested, --with-metadata and the optional columns are all opt-in so
+//	they dont change it. a change to an existing layout needs a new entry in outputVersions and a branch in the printer
+func checkOutputVersion(version string) error {
+	switch version {
+	case "", "v1":
+		return nil
+	}
+	return fmt.Errorf("unknown output version %s, supported versions are %s", version, strings.Join(outputVersions, ", "))
+}
+
+// printTableAs prints the table in the outputAs format, an empty format is the default table
+func printTableAs(t Table, flags commonFlags, outputAs string) error {
+	switch outputAs {
 
 	case "":
+		t.Style = flags.tableStyle
+		t.NoHeader = flags.noHeaders
+		t.Symbols = flags.showSymbols
+		t.ASCII = flags.useASCII
+		// the tree view puts the container name in its NAME column, the env and volume NAME columns are left alone
+		if flags.showTreeView {
+			t.TruncateColumns(flags.truncateNames, "NAME")
+		} else {
+			t.TruncateColumns(flags.truncateNames, "CONTAINER")
+		}
+		if flags.messageReflow {
+			t.ReflowColumns("MESSAGE")
+		}
 		t.Print()
 	case "csv":
+		t.NoHeader = flags.noHeaders
 		t.PrintCsv()
 	case "list":
 		t.PrintList()
 	case "json":
-		t.PrintJson()
+		if err := checkOutputVersion(flags.outputVersion); err != nil {
+			return err
+		}
+		if flags.withMetadata {
+			if err := t.PrintJsonWithMetadata(flags.activeFilters); err != nil {
+				return err
+			}
+		} else if err := t.PrintJson(); err != nil {
+			return err
+		}
+	case "json-nested":
+		if err := checkOutputVersion(flags.outputVersion); err != nil {
+			return err
+		}
+		podColumns := t.PodColumns
+		if len(podColumns) == 0 {
+			podColumns = defaultPodColumns
+		}
+		if err := t.PrintJsonNested("containers", podColumns...); err != nil {
+			return err
+		}
 	case "yaml":
+		if err := checkOutputVersion(flags.outputVersion); err != nil {
+			return err
+		}
 		t.PrintYaml()
+	case "template":
+		if err := t.PrintTemplate(flags.outputTemplate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeTableFile writes the table to one of the --output-file files, the colour codes are left out as the file
+//
+//	wont be read by a terminal
+func writeTableFile(t Table, flags commonFlags, file outputFile) error {
+	out, err := os.Create(file.filename)
+	if err != nil {
+		return fmt.Errorf("unable to create output file: %w", err)
+	}
+
+	t.Out = out
+	t.ColourOutput = COLOUR_NONE
+
+	outputAs := file.format
+	if outputAs == "table" {
+		outputAs = ""
+	}
+
+	if err := printTableAs(t, flags, outputAs); err != nil {
+		out.Close()
+		return err
 	}
 
+	return out.Close()
+}
+
+// splitFileExtension is the extension given to each --split-output-dir file for the output format
+var splitFileExtension = map[string]string{
+	"table":       "txt",
+	"csv":         "csv",
+	"list":        "txt",
+	"json":        "json",
+	"json-nested": "json",
+	"yaml":        "yaml",
+	"template":    "txt",
+}
+
+// writeSplitFiles writes the table to --split-output-dir in place of stdout with one file for each group of rows, the
+//
+//	files are named after the values the rows were grouped by joined with an _ eg. namespace_podname.json
+func writeSplitFiles(t Table, flags commonFlags) error {
+	if err := os.MkdirAll(flags.splitOutputDir, 0755); err != nil {
+		return fmt.Errorf("unable to create output directory: %w", err)
+	}
+
+	valueList, tableList, err := t.SplitRows(splitByColumns[flags.splitBy]...)
+	if err != nil {
+		return err
+	}
+
+	format := flags.outputAs
+	if format == "" {
+		format = "table"
+	}
+
+	for i, part := range tableList {
+		filename := filepath.Join(flags.splitOutputDir, strings.Join(valueList[i], "_")+"."+splitFileExtension[format])
+		if err := writeTableFile(part, flags, outputFile{format: format, filename: filename}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hideInRangeRows hides the rows with a columnID value inside the range worked out by ListOutOfRange so only the
+//
+//	oddities are left, with --explain-oddities the value and range of each row left is written to stderr
+func hideInRangeRows(t *Table, columnID int, flags commonFlags) error {
+	row2Remove, stats, err := t.ListOutOfRange(columnID)
+	if err != nil {
+		return err
+	}
+	t.HideRows(row2Remove)
+
+	if flags.explainOddities {
+		for _, line := range t.explainOutOfRange(stats) {
+			fmt.Fprintln(os.Stderr, line)
+		}
+	}
+
+	return nil
+}
+
+// checkTableHasRows returns ErrNoMatch when --error-on-empty is set and every row in the table has been filtered out
+//
+//	or no rows were added, an empty table is not an error otherwise
+func checkTableHasRows(t Table, flags commonFlags) error {
+	if flags.errorOnEmpty && len(t.getVisibleRows()) == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
+
+// countContainersAndPods counts the visible containers and the number of unique pods they belong to, in tree view
+//
+//	there is no pod name column so the pod rows are counted instead
+func countContainersAndPods(t Table) (int, int) {
+	var containers int
+
+	podColumn := -1
+	namespaceColumn := -1
+	contextColumn := -1
+	for i, h := range t.head {
+		switch h.title {
+		case "CONTEXT":
+			contextColumn = i
+		case "PODNAME":
+			podColumn = i
+		case "NAMESPACE":
+			namespaceColumn = i
+		}
+	}
+
+	podRows := 0
+	podList := make(map[string]bool)
+	for _, row := range t.getVisibleRows() {
+		// column 0 is always the container type
+		switch row[0].text {
+		case TypeIDContainer, TypeIDInitContainer, TypeIDEphemeralContainer:
+			containers++
+		case TypeIDPod:
+			podRows++
+		}
+
+		if podColumn >= 0 && namespaceColumn >= 0 {
+			key := row[namespaceColumn].text + "/" + row[podColumn].text
+			if contextColumn >= 0 {
+				// the same pod name can be in more than one cluster with --contexts
+				key = row[contextColumn].text + "/" + key
+			}
+			podList[key] = true
+		}
+	}
+
+	if podColumn == -1 {
+		return containers, podRows
+	}
+
+	return containers, len(podList)
+}
+
+// countLine returns the number of visible containers as a line of text, eg. "1 container" or "3 containers". tables
+//
+//	with only pod rows are counted as pods and an empty string is returned when there are no rows
+func countLine(t Table) string {
+	containers, pods := countContainersAndPods(t)
+
+	switch {
+	case containers == 1:
+		return "1 container"
+	case containers > 1:
+		return fmt.Sprintf("%d containers", containers)
+	case pods == 1:
+		return "1 pod"
+	case pods > 1:
+		return fmt.Sprintf("%d pods", pods)
+	}
+
+	return ""
+}
+
+// printCountAs prints the number of matching containers and pods instead of the table
+func printCountAs(t Table, outType string) {
+	containers, pods := countContainersAndPods(t)
+	out := t.out()
+
+	switch outType {
+	case "":
+		fmt.Fprintln(out, containers)
+	case "csv":
+		fmt.Fprintln(out, "\"containers\", \"pods\"")
+		fmt.Fprintf(out, "\"%d\", \"%d\"\n", containers, pods)
+	case "list", "yaml":
+		fmt.Fprintln(out, "containers:", containers)
+		fmt.Fprintln(out, "pods:", pods)
+	case "json", "json-nested":
+		fmt.Fprintf(out, "{\"containers\":%d,\"pods\":%d}\n", containers, pods)
+	}
 }
 
 // takes a port object and returns either the number or the name as a string with a proceeding :
@@ -165,6 +607,28 @@ func setColourBoolean(value bool) [2]int {
 	return colour
 }
 
+// nodeConditionColour sets the colour of a node condition status, the Ready condition is good when True
+//
+//	all the other conditions (MemoryPressure, DiskPressure etc) are bad when True
+func nodeConditionColour(conditionType string, status string) [2]int {
+	switch status {
+	case "True":
+		if conditionType == "Ready" {
+			return colourOk
+		}
+		return colourBad
+	case "False":
+		if conditionType == "Ready" {
+			return colourBad
+		}
+		return colourOk
+	case "Unknown":
+		return colourWarn
+	}
+
+	return [2]int{-1, 0}
+}
+
 // splitColourString decodes a given colour string item (0.0 or x0.0) into its component parts
 //
 //	returns state srting (g, w, b) if found, colour, modifier and error state
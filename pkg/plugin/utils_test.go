@@ -1,7 +1,12 @@
 package plugin
 
 import (
+	"bytes"
 	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -17,10 +22,16 @@ type skipContainerNameTest struct {
 }
 
 var skipContainerNameTests = []skipContainerNameTest{
-	{commonFlags{container: ""}, "thisname", false},
-	{commonFlags{container: "notthis"}, "thisname", true},
-	{commonFlags{container: "thisname"}, "thisname", false},
-	{commonFlags{container: "notthis"}, "", true},
+	{commonFlags{container: []string{}}, "thisname", false},
+	{commonFlags{container: []string{"notthis"}}, "thisname", true},
+	{commonFlags{container: []string{"thisname"}}, "thisname", false},
+	{commonFlags{container: []string{"notthis"}}, "", true},
+	{commonFlags{container: []string{"notthis", "thisname"}}, "thisname", false},
+	{commonFlags{container: []string{"notthis", "northis"}}, "thisname", true},
+	{commonFlags{excludeContainer: []string{"thisname"}}, "thisname", true},
+	{commonFlags{excludeContainer: []string{"notthis"}}, "thisname", false},
+	{commonFlags{container: []string{"thisname"}, excludeContainer: []string{"thisname"}}, "thisname", true},
+	{commonFlags{container: []string{"thisname", "notthis"}, excludeContainer: []string{"notthis"}}, "thisname", false},
 }
 
 func TestSkipContainerName(t *testing.T) {
@@ -33,6 +44,70 @@ func TestSkipContainerName(t *testing.T) {
 
 }
 
+// *****************
+// skipContainerImage
+// *****************
+type skipContainerImageTest struct {
+	flags    commonFlags
+	image    string
+	expected bool
+}
+
+var skipContainerImageTests = []skipContainerImageTest{
+	{commonFlags{imageFilter: "nginx"}, "nginx:1.21", false},
+	{commonFlags{imageFilter: "nginx"}, "registry.local:5000/web/nginx:1.21", false},
+	{commonFlags{imageFilter: "nginx"}, "istio/proxyv2:1.18", true},
+	{commonFlags{imageTag: "1.21"}, "nginx:1.21", false},
+	{commonFlags{imageTag: "1.21"}, "nginx:1.21.6", true},
+	{commonFlags{imageTag: "1.21"}, "registry.local:5000/nginx:1.21", false},
+	// an image without a tag has no tag to match
+	{commonFlags{imageTag: "nginx"}, "nginx", true},
+	{commonFlags{imageFilter: "nginx", imageTag: "1.21"}, "nginx:1.20", true},
+	{commonFlags{imageRegex: true, imageFilterRegex: regexp.MustCompile(`^(nginx|busybox):`)}, "busybox:1.36", false},
+	{commonFlags{imageRegex: true, imageFilterRegex: regexp.MustCompile(`^(nginx|busybox):`)}, "istio/nginx:1.0", true},
+	{commonFlags{imageRegex: true, imageTagRegex: regexp.MustCompile(`^1\.2[0-9]`)}, "nginx:1.21.6", false},
+	{commonFlags{imageRegex: true, imageTagRegex: regexp.MustCompile(`^1\.2[0-9]`)}, "nginx:1.19", true},
+}
+
+func TestSkipContainerImage(t *testing.T) {
+
+	for _, test := range skipContainerImageTests {
+		if output := skipContainerImage(test.flags, test.image); output != test.expected {
+			t.Errorf("Output %t not equal to expected %t (image %s)", output, test.expected, test.image)
+		}
+	}
+
+}
+
+// *****************
+// splitImageName
+// *****************
+type splitImageNameTest struct {
+	image string
+	name  string
+	tag   string
+}
+
+var splitImageNameTests = []splitImageNameTest{
+	{"nginx:1.21", "nginx", "1.21"},
+	// an image without a tag is all name, the image command used to show it in the TAG column
+	{"nginx", "nginx", ""},
+	{"istio/proxyv2:1.18", "istio/proxyv2", "1.18"},
+	{"istio/proxyv2", "istio/proxyv2", ""},
+	{"registry.local:5000/web/nginx:1.21", "registry.local:5000/web/nginx", "1.21"},
+	{"registry.local:5000/web/nginx", "registry.local:5000/web/nginx", ""},
+}
+
+func TestSplitImageName(t *testing.T) {
+
+	for _, test := range splitImageNameTests {
+		if name, tag := splitImageName(test.image); name != test.name || tag != test.tag {
+			t.Errorf("Output %q %q not equal to expected %q %q", name, tag, test.name, test.tag)
+		}
+	}
+
+}
+
 // ********************
 // skipmemoryGetUnitLst
 // ********************
@@ -170,3 +245,217 @@ func TestDemo(t *testing.T) {
 		}
 	}
 }
+
+// *****************
+// writeTableFile
+// *****************
+func TestWriteTableFile(t *testing.T) {
+	tbl := Table{ColourOutput: COLOUR_MIX}
+	tbl.SetHeader("CONTAINER", "RESTARTS")
+	tbl.AddRow(NewCellText("web"), NewCellColourInt(colourBad, "3", 3))
+
+	flags := commonFlags{outputVersion: "v1"}
+	expected := map[string]string{
+		"table": "CONTAINER  RESTARTS\nweb        3\n",
+		"csv":   "\"CONTAINER\", \"RESTARTS\"\n\"web\", \"3\"\n",
+		"json":  "{\"data\":[\n{\"CONTAINER\": \"web\", \"RESTARTS\": \"3\"}\n]}\n",
+	}
+
+	for format, want := range expected {
+		filename := filepath.Join(t.TempDir(), "out."+format)
+		if err := writeTableFile(tbl, flags, outputFile{format: format, filename: filename}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		output, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(output) != want {
+			t.Errorf("Output %q not equal to expected %q for %s", output, want, format)
+		}
+	}
+
+}
+
+// *****************
+// wrapText
+// *****************
+type wrapTextTest struct {
+	text     string
+	width    int
+	expected []string
+}
+
+var wrapTextTests = []wrapTextTest{
+	{"short message", 20, []string{"short message"}},
+	{"the quick brown fox jumps", 10, []string{"the quick", "brown fox", "jumps"}},
+	{"first line\nsecond line", 20, []string{"first line", "second line"}},
+	{"aaaaaaaaaaaa bb", 5, []string{"aaaaa", "aaaaa", "aa bb"}},
+	{"  padded  ", 20, []string{"padded"}},
+}
+
+func TestWrapText(t *testing.T) {
+
+	for _, test := range wrapTextTests {
+		if output := wrapText(test.text, test.width); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %q not equal to expected %q", output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// checkTableHasRows
+// *****************
+type checkTableHasRowsTest struct {
+	rows         int
+	hidden       int
+	errorOnEmpty bool
+	expected     error
+}
+
+var checkTableHasRowsTests = []checkTableHasRowsTest{
+	{0, 0, false, nil},
+	{2, 2, false, nil},
+	{2, 1, false, nil},
+	{0, 0, true, ErrNoMatch},
+	{2, 2, true, ErrNoMatch},
+	{2, 1, true, nil},
+}
+
+func TestCheckTableHasRows(t *testing.T) {
+
+	for _, test := range checkTableHasRowsTests {
+		tbl := Table{}
+		tbl.SetHeader("CONTAINER")
+		for i := 0; i < test.rows; i++ {
+			tbl.AddRow(NewCellText("web"))
+		}
+		for i := 0; i < test.hidden; i++ {
+			tbl.HideRows([]int{i})
+		}
+
+		err := checkTableHasRows(tbl, commonFlags{errorOnEmpty: test.errorOnEmpty})
+		if err != test.expected {
+			t.Errorf("Error %v not equal to expected %v for %d rows with %d hidden and errorOnEmpty %t", err, test.expected, test.rows, test.hidden, test.errorOnEmpty)
+		}
+	}
+
+}
+
+// *****************
+// printTableAs truncate-names
+// *****************
+type truncateNamesTest struct {
+	head     []string
+	tree     bool
+	expected string
+}
+
+var truncateNamesTests = []truncateNamesTest{
+	// the env var and volume NAME columns are not container names
+	{[]string{"CONTAINER", "NAME"}, false, "CONTAINER  NAME\nistio…     istio-proxy-config\n"},
+	{[]string{"NAME", "NAME"}, true, "NAME    NAME\nistio…  istio-proxy-config\n"},
+}
+
+func TestPrintTableAsTruncateNames(t *testing.T) {
+
+	for _, test := range truncateNamesTests {
+		out := bytes.Buffer{}
+		tbl := Table{Out: &out}
+		tbl.SetHeader(test.head...)
+		tbl.AddRow(NewCellText("istio-proxy"), NewCellText("istio-proxy-config"))
+
+		flags := commonFlags{truncateNames: 6, showTreeView: test.tree}
+		if err := printTableAs(tbl, flags, ""); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if out.String() != test.expected {
+			t.Errorf("Output %q not equal to expected %q (tree %t)", out.String(), test.expected, test.tree)
+		}
+	}
+
+}
+
+// *****************
+// count-only
+// *****************
+type countOnlyTest struct {
+	flags    commonFlags
+	outType  string
+	expected string
+}
+
+var countOnlyTests = []countOnlyTest{
+	{commonFlags{}, "", "3\n"},
+	{commonFlags{}, "json", "{\"containers\":3,\"pods\":2}\n"},
+	// only the rows left after filtering are counted
+	{commonFlags{container: []string{"web"}}, "json", "{\"containers\":2,\"pods\":2}\n"},
+	{commonFlags{container: []string{"proxy"}}, "yaml", "containers: 1\npods: 1\n"},
+	{commonFlags{container: []string{"missing"}}, "csv", "\"containers\", \"pods\"\n\"0\", \"0\"\n"},
+}
+
+func TestPrintCountAs(t *testing.T) {
+	pods := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web-1\n  namespace: default\nspec:\n  containers:\n  - name: web\n  - name: proxy\n" +
+		"---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: web-2\n  namespace: default\nspec:\n  containers:\n  - name: web\n"
+
+	for _, test := range countOnlyTests {
+		tbl, _ := buildTestTable(t, RowBuilder{LoopSpec: true}, &image{}, test.flags, pods)
+		out := bytes.Buffer{}
+		tbl.Out = &out
+		printCountAs(tbl, test.outType)
+		if out.String() != test.expected {
+			t.Errorf("Output %q not equal to expected %q for %v", out.String(), test.expected, test.flags.container)
+		}
+	}
+
+}
+
+// *****************
+// output version
+// *****************
+type outputVersionTest struct {
+	version     string
+	outputAs    string
+	expected    string
+	expectError bool
+}
+
+var outputVersionTests = []outputVersionTest{
+	// the v1 layout is pinned, a change to it needs a new version
+	{"v1", "json", "{\"data\":[\n{\"CONTAINER\": \"web\", \"RESTARTS\": \"3\"}\n]}\n", false},
+	{"v1", "yaml", "data:\n- CONTAINER: \"web\"\n  RESTARTS: \"3\"\n", false},
+	// empty is the latest layout
+	{"", "json", "{\"data\":[\n{\"CONTAINER\": \"web\", \"RESTARTS\": \"3\"}\n]}\n", false},
+	{"v2", "json", "", true},
+	{"v2", "json-nested", "", true},
+	{"v2", "yaml", "", true},
+	// the table and csv layouts arent versioned
+	{"v2", "csv", "\"CONTAINER\", \"RESTARTS\"\n\"web\", \"3\"\n", false},
+}
+
+func TestOutputVersion(t *testing.T) {
+
+	for _, test := range outputVersionTests {
+		out := bytes.Buffer{}
+		tbl := Table{Out: &out}
+		tbl.SetHeader("CONTAINER", "RESTARTS")
+		tbl.AddRow(NewCellText("web"), NewCellInt("3", 3))
+
+		err := printTableAs(tbl, commonFlags{outputVersion: test.version}, test.outputAs)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error for %s %s", test.version, test.outputAs)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if out.String() != test.expected {
+			t.Errorf("Output %q not equal to expected %q for %s %s", out.String(), test.expected, test.version, test.outputAs)
+		}
+	}
+
+}
@@ -14,8 +14,9 @@ var volumesShort = "Display container volumes and mount points"
 
 var volumesDescription = ` Prints configured volume information at the container level, volume type, backing information,
 read-write state and mount point are all avaliable, volume size is only available if found in
-the pod configuration. If no name is specified the volume information for all pods in the
-current namespace are shown.`
+the pod configuration. Raw block devices are listed with a KIND of device and the device path in the
+MOUNT-POINT column, use --device to only list the block devices. If no name is specified the volume
+information for all pods in the current namespace are shown.`
 
 var volumesExample = `  # List volumes from containers inside pods from current namespace
   %[1]s volumes
@@ -38,6 +39,9 @@ var volumesExample = `  # List volumes from containers inside pods from current
   # namespace sorted by volume name in ascending order
   %[1]s volumes -c web-container --sort MOUNT-POINT
 
+  # List only the raw block devices given to each container
+  %[1]s volumes --device
+
   # List container volume info from all pods where label app equals web
   %[1]s volumes -l app=web
 
@@ -87,8 +91,7 @@ func Volumes(cmd *cobra.Command, kubeFlags *genericclioptions.ConfigFlags, args
 		return err
 	}
 
-	outputTableAs(table, commonFlagList.outputAs)
-	return nil
+	return outputTableAs(table, commonFlagList)
 
 }
 
@@ -100,6 +103,7 @@ func (s *volumes) Headers() []string {
 	if !s.ShowVolumeDevice {
 		return []string{
 			"VOLUME",
+			"KIND",
 			"TYPE",
 			"BACKING",
 			"SIZE",
@@ -107,8 +111,12 @@ func (s *volumes) Headers() []string {
 			"MOUNT-POINT",
 		}
 	} else {
+		// the --device column names are kept from before the mounts and devices were merged so scripts still work
 		return []string{
 			"PVC_NAME",
+			"TYPE",
+			"BACKING",
+			"SIZE",
 			"DEVICE_PATH",
 		}
 	}
@@ -137,11 +145,15 @@ func (s *volumes) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, e
 			NewCellText(""),
 			NewCellText(""),
 			NewCellText(""),
+			NewCellText(""),
 		}
 	} else {
 		out = []Cell{
 			NewCellText(""),
 			NewCellText(""),
+			NewCellText(""),
+			NewCellText(""),
+			NewCellText(""),
 		}
 	}
 
@@ -149,34 +161,29 @@ func (s *volumes) BuildBranch(info BuilderInformation, rows [][]Cell) ([]Cell, e
 }
 
 func (s *volumes) BuildContainerSpec(container v1.Container, info BuilderInformation) ([][]Cell, error) {
-	out := [][]Cell{}
-	Pod := info.Data.pod
-	if !s.ShowVolumeDevice {
-		podVolumes := s.createVolumeMap(Pod.Spec.Volumes)
-		for _, mount := range container.VolumeMounts {
-			out = append(out, s.volumesBuildRow(info, podVolumes, mount))
-		}
-	} else {
-		for _, mount := range container.VolumeDevices {
-			out = append(out, s.mountsBuildRow(mount))
-		}
-	}
-	return out, nil
+	return s.volumesBuildRows(info, container.VolumeMounts, container.VolumeDevices), nil
 }
 
 func (s *volumes) BuildEphemeralContainerSpec(container v1.EphemeralContainer, info BuilderInformation) ([][]Cell, error) {
+	return s.volumesBuildRows(info, container.VolumeMounts, container.VolumeDevices), nil
+}
+
+// volumesBuildRows returns a row for each mount followed by a row for each raw block device, only the devices are
+//
+//	listed when ShowVolumeDevice is set
+func (s *volumes) volumesBuildRows(info BuilderInformation, mountList []v1.VolumeMount, deviceList []v1.VolumeDevice) [][]Cell {
 	out := [][]Cell{}
+	podVolumes := s.createVolumeMap(info.Data.pod.Spec.Volumes)
+
 	if !s.ShowVolumeDevice {
-		podVolumes := s.createVolumeMap(info.Data.pod.Spec.Volumes)
-		for _, mount := range container.VolumeMounts {
+		for _, mount := range mountList {
 			out = append(out, s.volumesBuildRow(info, podVolumes, mount))
 		}
-	} else {
-		for _, mount := range container.VolumeDevices {
-			out = append(out, s.mountsBuildRow(mount))
-		}
 	}
-	return out, nil
+	for _, device := range deviceList {
+		out = append(out, s.mountsBuildRow(podVolumes, device))
+	}
+	return out
 }
 
 func (s *volumes) createVolumeMap(volumes []v1.Volume) map[string]map[string]Cell {
@@ -308,39 +315,61 @@ func (s *volumes) decodeVolumeType(volType string, volume v1.VolumeSource) map[s
 
 func (s *volumes) volumesBuildRow(info BuilderInformation, podVolumes map[string]map[string]Cell, mount v1.VolumeMount) []Cell {
 	var cellList []Cell
-	var volumeType Cell
-	var size Cell
-	var backing Cell
-
-	if podVolumes[mount.Name] != nil {
-		volume := podVolumes[mount.Name]
-		volumeType = volume["type"]
-		size = volume["size"]
-		backing = volume["backing"]
-	}
+
+	volumeType, backing, size := volumeSourceCells(podVolumes, mount.Name)
 
 	cellList = append(cellList,
 		NewCellText(mount.Name),
+		NewCellText("mount"),
 		volumeType,
 		backing,
 		size,
-		NewCellText(fmt.Sprintf("%t", mount.ReadOnly)),
+		NewCellBool(mount.ReadOnly),
 		NewCellText(mount.MountPath))
 
 	return cellList
 }
 
-func (s *volumes) mountsBuildRow(mountInfo v1.VolumeDevice) []Cell {
+// mountsBuildRow builds the row for a raw block device, devices have no read only setting so the RO column is left empty
+func (s *volumes) mountsBuildRow(podVolumes map[string]map[string]Cell, mountInfo v1.VolumeDevice) []Cell {
 	var cellList []Cell
 
-	cellList = append(cellList,
-		NewCellText(mountInfo.Name),
-		NewCellText(mountInfo.DevicePath),
-	)
+	volumeType, backing, size := volumeSourceCells(podVolumes, mountInfo.Name)
+
+	if s.ShowVolumeDevice {
+		cellList = append(cellList,
+			NewCellText(mountInfo.Name),
+			volumeType,
+			backing,
+			size,
+			NewCellText(mountInfo.DevicePath),
+		)
+	} else {
+		cellList = append(cellList,
+			NewCellText(mountInfo.Name),
+			NewCellText("device"),
+			volumeType,
+			backing,
+			size,
+			NewCellText(""),
+			NewCellText(mountInfo.DevicePath),
+		)
+	}
 
 	return cellList
 }
 
+// volumeSourceCells returns the type, backing and size cells of the named pod volume, empty cells are returned when
+//
+//	the volume isnt found
+func volumeSourceCells(podVolumes map[string]map[string]Cell, name string) (Cell, Cell, Cell) {
+	volume := podVolumes[name]
+	if volume == nil {
+		return Cell{}, Cell{}, Cell{}
+	}
+	return volume["type"], volume["backing"], volume["size"]
+}
+
 func (s *volumes) BuildPodRow(pod v1.Pod, info BuilderInformation) ([][]Cell, error) {
 	return [][]Cell{}, nil
 }
@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// *****************
+// volumesBuildRows
+// *****************
+func TestVolumesBuildRows(t *testing.T) {
+	info := BuilderInformation{}
+	info.Data.pod = v1.Pod{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "db-config"}}}},
+				{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "db-data-0"}}},
+			},
+		},
+	}
+	mountList := []v1.VolumeMount{{Name: "config", MountPath: "/etc/db", ReadOnly: true}}
+	deviceList := []v1.VolumeDevice{{Name: "data", DevicePath: "/dev/xvda"}}
+
+	tests := []struct {
+		showDevice bool
+		expected   [][]string
+	}{
+		{false, [][]string{
+			{"config", "mount", "ConfigMap", "db-config", "", "true", "/etc/db"},
+			{"data", "device", "PersistentVolumeClaim", "db-data-0", "", "", "/dev/xvda"},
+		}},
+		// --device only lists the block devices
+		{true, [][]string{
+			{"data", "PersistentVolumeClaim", "db-data-0", "", "/dev/xvda"},
+		}},
+	}
+
+	for _, test := range tests {
+		loop := volumes{ShowVolumeDevice: test.showDevice}
+		var output [][]string
+		for _, row := range loop.volumesBuildRows(info, mountList, deviceList) {
+			var line []string
+			for _, cell := range row {
+				line = append(line, cell.text)
+			}
+			output = append(output, line)
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("device %t: Output %q not equal to expected %q", test.showDevice, output, test.expected)
+		}
+	}
+
+}
+
+// *****************
+// volumes headers
+// *****************
+type volumesHeadersTest struct {
+	showDevice bool
+	expected   []string
+}
+
+var volumesHeadersTests = []volumesHeadersTest{
+	{false, []string{"VOLUME", "KIND", "TYPE", "BACKING", "SIZE", "RO", "MOUNT-POINT"}},
+	// the --device names are unchanged so existing scripts can still read them
+	{true, []string{"PVC_NAME", "TYPE", "BACKING", "SIZE", "DEVICE_PATH"}},
+}
+
+func TestVolumesHeaders(t *testing.T) {
+
+	for _, test := range volumesHeadersTests {
+		loop := volumes{ShowVolumeDevice: test.showDevice}
+		if output := loop.Headers(); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Output %v not equal to expected %v (device %t)", output, test.expected, test.showDevice)
+		}
+	}
+
+}